@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/vegasq/ostop/internal/check"
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// runCheck implements `ostop check`: evaluate cluster health once and exit
+// with a Nagios plugin status (0 OK, 1 WARNING, 2 CRITICAL, 3 UNKNOWN)
+func runCheck(args []string) int {
+	fs := flag.NewFlagSet("ostop check", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	conn := registerConnectionFlags(fs)
+	only := fs.String("only", "", "Comma-separated checks to run (default: all of "+strings.Join(check.Names(), ", ")+")")
+	skip := fs.String("skip", "", "Comma-separated checks to leave out")
+	window := fs.Duration("rejection-window", check.DefaultRejectionWindow, "Time between the two thread pool samples (0 counts every rejection since node start)")
+	threshold := fs.Int64("rejection-threshold", check.DefaultRejectionThreshold, "New thread pool rejections in the window that make the threadpool check critical")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			fs.SetOutput(os.Stderr)
+			fs.PrintDefaults()
+		}
+		return unknown(err)
+	}
+
+	checks, err := check.Select(splitList(*only), splitList(*skip))
+	if err != nil {
+		return unknown(err)
+	}
+
+	_, cluster, err := conn.resolve(fs)
+	if err != nil {
+		return unknown(err)
+	}
+
+	// A probe runs unattended, so never prompt for secrets
+	opts, err := cluster.ClientOptions(false)
+	if err != nil {
+		return unknown(err)
+	}
+
+	osClient, warnings, err := connect(opts, conn.timeout)
+	if err != nil {
+		return unknown(err)
+	}
+	printWarnings(warnings)
+
+	checkOpts := check.Options{
+		Timeout:            conn.timeout,
+		RejectionWindow:    *window,
+		RejectionThreshold: *threshold,
+	}
+	results := check.Run(context.Background(), source.NewOpenSearch(osClient), checkOpts, checks)
+	fmt.Print(check.Report(results))
+	return check.Overall(results).ExitCode()
+}
+
+// unknown reports a probe that couldn't run at all
+func unknown(err error) int {
+	fmt.Printf("OPENSEARCH %s - %v\n", check.Unknown, err)
+	return check.Unknown.ExitCode()
+}
+
+// splitList splits a comma-separated flag value, ignoring empty entries
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,240 @@
+// Package check evaluates cluster health against the same thresholds the UI
+// highlights, for use as a monitoring probe.
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// Status is the outcome of a check, ordered by severity for reporting
+type Status int
+
+const (
+	OK Status = iota
+	Unknown
+	Warning
+	Critical
+)
+
+// String returns the Nagios name of a status
+func (s Status) String() string {
+	switch s {
+	case OK:
+		return "OK"
+	case Warning:
+		return "WARNING"
+	case Critical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ExitCode returns the Nagios plugin exit code for a status
+func (s Status) ExitCode() int {
+	switch s {
+	case OK:
+		return 0
+	case Warning:
+		return 1
+	case Critical:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Name    string
+	Status  Status
+	Summary string
+	Details []string // One line per offending node, pool, etc.
+}
+
+// Check is a named probe against the cluster
+type Check struct {
+	Name        string
+	Description string
+	run         func(ctx context.Context, d *clusterData) Result
+}
+
+// All lists every check in the order they're reported
+var All = []Check{
+	{Name: "status", Description: "cluster status is not red or yellow", run: runStatus},
+	{Name: "unassigned", Description: "no unassigned shards", run: runUnassigned},
+	{Name: "disk", Description: "disk usage per node below the allocation view thresholds", run: runDisk},
+	{Name: "threadpool", Description: "no new thread pool rejections between two samples", run: runThreadPool},
+	{Name: "hotspots", Description: "heap, CPU, RAM and disk per node below the resources view thresholds", run: runHotspots},
+}
+
+// Names returns the names of all checks
+func Names() []string {
+	names := make([]string, len(All))
+	for i, c := range All {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Select returns the checks to run. An empty only means all checks; skip
+// removes checks from that set. Unknown names are an error.
+func Select(only, skip []string) ([]Check, error) {
+	for _, name := range append(append([]string(nil), only...), skip...) {
+		if _, ok := find(name); !ok {
+			return nil, fmt.Errorf("unknown check %q (valid: %s)", name, strings.Join(Names(), ", "))
+		}
+	}
+
+	var selected []Check
+	for _, c := range All {
+		if len(only) > 0 && !contains(only, c.Name) {
+			continue
+		}
+		if contains(skip, c.Name) {
+			continue
+		}
+		selected = append(selected, c)
+	}
+
+	if len(selected) == 0 {
+		return nil, errors.New("no checks selected")
+	}
+	return selected, nil
+}
+
+// find looks up a check by name
+func find(name string) (Check, bool) {
+	for _, c := range All {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Check{}, false
+}
+
+// contains reports whether name is in names
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Defaults for Options
+const (
+	DefaultRejectionWindow    = 5 * time.Second
+	DefaultRejectionThreshold = 1
+)
+
+// Options tunes how the checks run
+type Options struct {
+	Timeout time.Duration // Bounds each API request
+
+	// The threadpool check counts rejections between two samples taken
+	// RejectionWindow apart, as the counters are cumulative since node
+	// start. Zero compares the cumulative counters instead.
+	RejectionWindow    time.Duration
+	RejectionThreshold int64 // New rejections that make the check critical
+}
+
+// Run runs the checks in order. Each API request is bounded by the timeout;
+// a check whose data can't be fetched reports Unknown.
+func Run(ctx context.Context, src source.ClusterSource, opts Options, checks []Check) []Result {
+	d := &clusterData{src: src, timeout: opts.Timeout, opts: opts}
+
+	results := make([]Result, 0, len(checks))
+	for _, c := range checks {
+		res := c.run(ctx, d)
+		res.Name = c.Name
+		results = append(results, res)
+	}
+	return results
+}
+
+// Overall returns the most severe status across results
+func Overall(results []Result) Status {
+	worst := OK
+	for _, r := range results {
+		if r.Status > worst {
+			worst = r.Status
+		}
+	}
+	return worst
+}
+
+// Report renders a one-line summary followed by one line per check and its
+// details, in the format monitoring systems expect from a plugin
+func Report(results []Result) string {
+	var b strings.Builder
+
+	overall := Overall(results)
+	var failing []string
+	for _, r := range results {
+		if r.Status != OK {
+			failing = append(failing, fmt.Sprintf("%s: %s", r.Name, r.Summary))
+		}
+	}
+
+	if len(failing) == 0 {
+		b.WriteString(fmt.Sprintf("OPENSEARCH %s - %d checks passed\n", overall, len(results)))
+	} else {
+		b.WriteString(fmt.Sprintf("OPENSEARCH %s - %s\n", overall, strings.Join(failing, "; ")))
+	}
+
+	for _, r := range results {
+		b.WriteString(fmt.Sprintf("[%s] %s: %s\n", r.Status, r.Name, r.Summary))
+		for _, detail := range r.Details {
+			b.WriteString(fmt.Sprintf("    %s\n", detail))
+		}
+	}
+
+	return b.String()
+}
+
+// clusterData fetches cluster data on demand, sharing responses between
+// checks that need the same API
+type clusterData struct {
+	src     source.ClusterSource
+	timeout time.Duration
+	opts    Options
+
+	health        *source.ClusterHealth
+	healthErr     error
+	healthFetched bool
+}
+
+// requestContext bounds a single API request by the configured timeout
+func (d *clusterData) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d.timeout)
+}
+
+// fetchError turns a fetch failure into an Unknown result
+func (d *clusterData) fetchError(what string, err error) Result {
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("request timed out after %s", d.timeout)
+	}
+	return Result{Status: Unknown, Summary: fmt.Sprintf("could not fetch %s: %v", what, err)}
+}
+
+// clusterHealth fetches cluster health once per run
+func (d *clusterData) clusterHealth(ctx context.Context) (*source.ClusterHealth, error) {
+	if !d.healthFetched {
+		ctx, cancel := d.requestContext(ctx)
+		defer cancel()
+		d.health, d.healthErr = d.src.ClusterHealth(ctx)
+		d.healthFetched = true
+	}
+	return d.health, d.healthErr
+}
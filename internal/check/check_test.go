@@ -0,0 +1,340 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// stubSource serves canned data for the APIs the checks use
+type stubSource struct {
+	source.ClusterSource
+	health      *source.ClusterHealth
+	allocation  []source.AllocationInfo
+	pools       []source.ThreadPoolInfo
+	nodes       []source.NodeInfo
+	err         error
+	healthCalls int
+}
+
+func (s *stubSource) ClusterHealth(ctx context.Context) (*source.ClusterHealth, error) {
+	s.healthCalls++
+	return s.health, s.err
+}
+
+func (s *stubSource) Allocation(ctx context.Context) ([]source.AllocationInfo, error) {
+	return s.allocation, s.err
+}
+
+func (s *stubSource) ThreadPool(ctx context.Context) ([]source.ThreadPoolInfo, error) {
+	return s.pools, s.err
+}
+
+func (s *stubSource) Nodes(ctx context.Context) ([]source.NodeInfo, error) {
+	return s.nodes, s.err
+}
+
+func TestStatus_ExitCode(t *testing.T) {
+	tests := []struct {
+		status Status
+		name   string
+		code   int
+	}{
+		{OK, "OK", 0},
+		{Warning, "WARNING", 1},
+		{Critical, "CRITICAL", 2},
+		{Unknown, "UNKNOWN", 3},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.String(); got != tt.name {
+			t.Errorf("String() = %q, want %q", got, tt.name)
+		}
+		if got := tt.status.ExitCode(); got != tt.code {
+			t.Errorf("%s ExitCode() = %d, want %d", tt.name, got, tt.code)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name    string
+		only    []string
+		skip    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "all", want: Names()},
+		{name: "only", only: []string{"disk", "status"}, want: []string{"status", "disk"}},
+		{name: "skip", skip: []string{"threadpool", "hotspots"}, want: []string{"status", "unassigned", "disk"}},
+		{name: "only_and_skip", only: []string{"disk", "status"}, skip: []string{"status"}, want: []string{"disk"}},
+		{name: "unknown", only: []string{"cpu"}, wantErr: true},
+		{name: "nothing_left", only: []string{"disk"}, skip: []string{"disk"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks, err := Select(tt.only, tt.skip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Select() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, c := range checks {
+				got = append(got, c.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Select() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   Status
+	}{
+		{"green", OK},
+		{"yellow", Warning},
+		{"red", Critical},
+		{"", Unknown},
+	}
+
+	for _, tt := range tests {
+		res := evaluateStatus(&source.ClusterHealth{ClusterName: "prod", Status: tt.status})
+		if res.Status != tt.want {
+			t.Errorf("status %q = %s, want %s", tt.status, res.Status, tt.want)
+		}
+	}
+}
+
+func TestEvaluateUnassigned(t *testing.T) {
+	if res := evaluateUnassigned(&source.ClusterHealth{}); res.Status != OK {
+		t.Errorf("no unassigned shards = %s, want OK", res.Status)
+	}
+	res := evaluateUnassigned(&source.ClusterHealth{UnassignedShards: 3})
+	if res.Status != Warning || !strings.Contains(res.Summary, "3 unassigned") {
+		t.Errorf("unassigned shards = %s %q, want WARNING with the count", res.Status, res.Summary)
+	}
+}
+
+func TestEvaluateDisk(t *testing.T) {
+	tests := []struct {
+		name     string
+		percents []string
+		want     Status
+	}{
+		{"healthy", []string{"40", "60"}, OK},
+		{"warning", []string{"40", "75"}, Warning},
+		{"critical", []string{"89", "90"}, Critical},
+		{"unassigned_row", []string{""}, OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var allocation []source.AllocationInfo
+			for i, p := range tt.percents {
+				allocation = append(allocation, source.AllocationInfo{Node: fmt.Sprintf("node-%d", i), DiskPercent: p})
+			}
+			if res := evaluateDisk(allocation); res.Status != tt.want {
+				t.Errorf("evaluateDisk() = %s, want %s", res.Status, tt.want)
+			}
+		})
+	}
+
+	// Fullest node first
+	res := evaluateDisk([]source.AllocationInfo{
+		{Node: "a", DiskPercent: "80"},
+		{Node: "b", DiskPercent: "95"},
+	})
+	if len(res.Details) != 2 || !strings.HasPrefix(res.Details[0], "b:") {
+		t.Errorf("details = %v, want fullest node first", res.Details)
+	}
+}
+
+func TestEvaluateThreadPool(t *testing.T) {
+	res := evaluateThreadPool(nil, []source.ThreadPoolInfo{
+		{NodeName: "n1", Name: "search", Rejected: "0"},
+		{NodeName: "n1", Name: "write", Rejected: "12"},
+		{NodeName: "n2", Name: "write", Rejected: "30"},
+	}, 0, 1)
+	if res.Status != Critical {
+		t.Errorf("rejections = %s, want CRITICAL", res.Status)
+	}
+	if !strings.Contains(res.Summary, "42") {
+		t.Errorf("summary = %q, want the total", res.Summary)
+	}
+	if len(res.Details) != 2 || !strings.HasPrefix(res.Details[0], "n2/write") {
+		t.Errorf("details = %v, want pools with rejections, most first", res.Details)
+	}
+
+	if res := evaluateThreadPool(nil, []source.ThreadPoolInfo{{Rejected: "0"}}, 0, 1); res.Status != OK {
+		t.Errorf("no rejections = %s, want OK", res.Status)
+	}
+}
+
+func TestEvaluateThreadPool_Delta(t *testing.T) {
+	before := []source.ThreadPoolInfo{
+		{NodeName: "n1", Name: "write", Rejected: "500"},
+		{NodeName: "n2", Name: "write", Rejected: "30"},
+	}
+
+	tests := []struct {
+		name      string
+		after     []source.ThreadPoolInfo
+		threshold int64
+		want      Status
+		summary   string
+	}{
+		{"old rejections only", before, 1, OK, "no thread pool rejections in the last 5s"},
+		{"new rejections", []source.ThreadPoolInfo{
+			{NodeName: "n1", Name: "write", Rejected: "503"},
+			{NodeName: "n2", Name: "write", Rejected: "31"},
+		}, 1, Critical, "4 thread pool rejection(s) in the last 5s"},
+		{"below threshold", []source.ThreadPoolInfo{
+			{NodeName: "n1", Name: "write", Rejected: "503"},
+		}, 10, OK, "3 thread pool rejection(s) in the last 5s"},
+		{"node restarted", []source.ThreadPoolInfo{
+			{NodeName: "n1", Name: "write", Rejected: "2"},
+		}, 1, Critical, "2 thread pool rejection(s) in the last 5s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := evaluateThreadPool(before, tt.after, 5*time.Second, tt.threshold)
+			if res.Status != tt.want || res.Summary != tt.summary {
+				t.Errorf("result = %s %q, want %s %q", res.Status, res.Summary, tt.want, tt.summary)
+			}
+		})
+	}
+}
+
+func TestRun_ThreadPoolWindow(t *testing.T) {
+	src := &stubSource{pools: []source.ThreadPoolInfo{{NodeName: "n1", Name: "write", Rejected: "900"}}}
+
+	opts := Options{Timeout: time.Second, RejectionWindow: time.Millisecond, RejectionThreshold: 1}
+	threadpool, _ := find("threadpool")
+	res := Run(t.Context(), src, opts, []Check{threadpool})[0]
+	if res.Status != OK {
+		t.Errorf("unchanged rejection counter = %s %q, want OK", res.Status, res.Summary)
+	}
+}
+
+func TestNodeHotspot(t *testing.T) {
+	tests := []struct {
+		name       string
+		node       source.NodeInfo
+		want       Status
+		wantReason string
+	}{
+		{"normal", source.NodeInfo{HeapPercent: "50", CPU: "20", RAMPercent: "60", DiskUsedPercent: "70"}, OK, ""},
+		{"heap_warning", source.NodeInfo{HeapPercent: "80", CPU: "20"}, Warning, "Heap: 80%"},
+		{"disk_below_warning", source.NodeInfo{DiskUsedPercent: "80"}, OK, ""},
+		{"disk_warning", source.NodeInfo{DiskUsedPercent: "86"}, Warning, "Disk: 86%"},
+		{"critical_lists_critical_only", source.NodeInfo{HeapPercent: "80", CPU: "95"}, Critical, "CPU: 95%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, reason := NodeHotspot(tt.node)
+			if status != tt.want || reason != tt.wantReason {
+				t.Errorf("NodeHotspot() = %s %q, want %s %q", status, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestEvaluateHotspots_TruncatesDetails(t *testing.T) {
+	var nodes []source.NodeInfo
+	for i := 0; i < maxDetails+5; i++ {
+		nodes = append(nodes, source.NodeInfo{Name: fmt.Sprintf("node-%d", i), HeapPercent: "95"})
+	}
+
+	res := evaluateHotspots(nodes)
+	if res.Status != Critical {
+		t.Errorf("status = %s, want CRITICAL", res.Status)
+	}
+	if len(res.Details) != maxDetails+1 || res.Details[maxDetails] != "... and 5 more" {
+		t.Errorf("details = %v, want %d lines plus a count of the rest", res.Details, maxDetails)
+	}
+}
+
+func TestRun(t *testing.T) {
+	src := &stubSource{
+		health:     &source.ClusterHealth{ClusterName: "prod", Status: "yellow", UnassignedShards: 2},
+		allocation: []source.AllocationInfo{{Node: "node-1", DiskPercent: "93"}},
+		pools:      []source.ThreadPoolInfo{{Rejected: "0"}},
+		nodes:      []source.NodeInfo{{Name: "node-1", HeapPercent: "40"}},
+	}
+
+	results := Run(t.Context(), src, Options{Timeout: time.Second}, All)
+	if len(results) != len(All) {
+		t.Fatalf("Run() returned %d results, want %d", len(results), len(All))
+	}
+	if src.healthCalls != 1 {
+		t.Errorf("cluster health fetched %d times, want 1 shared by the checks", src.healthCalls)
+	}
+	if got := Overall(results); got != Critical {
+		t.Errorf("Overall() = %s, want CRITICAL from disk", got)
+	}
+
+	report := Report(results)
+	lines := strings.Split(strings.TrimSpace(report), "\n")
+	if !strings.HasPrefix(lines[0], "OPENSEARCH CRITICAL - ") {
+		t.Errorf("summary line = %q", lines[0])
+	}
+	for _, want := range []string{"[WARNING] status", "[CRITICAL] disk", "node-1: 93% disk used", "[OK] hotspots"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestRun_FetchErrorIsUnknown(t *testing.T) {
+	src := &stubSource{err: fmt.Errorf("connection refused")}
+
+	results := Run(t.Context(), src, Options{Timeout: time.Second}, All)
+	for _, res := range results {
+		if res.Status != Unknown {
+			t.Errorf("%s = %s, want UNKNOWN", res.Name, res.Status)
+		}
+		if !strings.Contains(res.Summary, "connection refused") {
+			t.Errorf("%s summary = %q, want the cause", res.Name, res.Summary)
+		}
+	}
+	if Overall(results).ExitCode() != 3 {
+		t.Error("a cluster that can't be reached should exit UNKNOWN")
+	}
+}
+
+func TestOverall_WarningBeatsUnknown(t *testing.T) {
+	results := []Result{{Status: OK}, {Status: Unknown}, {Status: Warning}}
+	if got := Overall(results); got != Warning {
+		t.Errorf("Overall() = %s, want WARNING", got)
+	}
+	if got := Overall([]Result{{Status: OK}}); got != OK {
+		t.Errorf("Overall() = %s, want OK", got)
+	}
+}
+
+func TestRun_Timeout(t *testing.T) {
+	src := &blockingSource{}
+	results := Run(t.Context(), src, Options{Timeout: 10 * time.Millisecond}, []Check{All[0]})
+	if results[0].Status != Unknown || !strings.Contains(results[0].Summary, "timed out after 10ms") {
+		t.Errorf("result = %s %q, want UNKNOWN timeout", results[0].Status, results[0].Summary)
+	}
+}
+
+// blockingSource waits for the request context to end
+type blockingSource struct {
+	source.ClusterSource
+}
+
+func (b *blockingSource) ClusterHealth(ctx context.Context) (*source.ClusterHealth, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
@@ -0,0 +1,317 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// Thresholds shared with the UI so the probe and the views agree on what is
+// worth flagging
+const (
+	DiskCriticalPercent = 90.0 // Allocation view: node in red
+	DiskWarningPercent  = 75.0 // Allocation view: node in yellow
+
+	HotspotCriticalPercent    = 90.0 // Resources view: heap, CPU, RAM or disk in red
+	HotspotWarningPercent     = 75.0 // Resources view: heap, CPU or RAM in yellow
+	HotspotDiskWarningPercent = 85.0 // Resources view: disk in yellow
+)
+
+// maxDetails caps the per-check detail lines so a large cluster doesn't
+// flood the alert
+const maxDetails = 10
+
+// DiskStatus classifies a node's disk usage like the allocation view does
+func DiskStatus(percent float64) Status {
+	switch {
+	case percent >= DiskCriticalPercent:
+		return Critical
+	case percent >= DiskWarningPercent:
+		return Warning
+	default:
+		return OK
+	}
+}
+
+// NodeHotspot classifies a node's resource usage like the resources view
+// does. The reason lists the metrics over threshold, e.g. "Heap: 92% CPU: 95%".
+func NodeHotspot(node source.NodeInfo) (Status, string) {
+	metrics := []struct {
+		label   string
+		value   float64
+		warning float64
+	}{
+		{"Heap", parsePercent(node.HeapPercent), HotspotWarningPercent},
+		{"CPU", parsePercent(node.CPU), HotspotWarningPercent},
+		{"RAM", parsePercent(node.RAMPercent), HotspotWarningPercent},
+		{"Disk", parsePercent(node.DiskUsedPercent), HotspotDiskWarningPercent},
+	}
+
+	// Critical nodes list only their critical metrics
+	for _, threshold := range []Status{Critical, Warning} {
+		var reasons []string
+		for _, m := range metrics {
+			limit := m.warning
+			if threshold == Critical {
+				limit = HotspotCriticalPercent
+			}
+			if m.value >= limit {
+				reasons = append(reasons, fmt.Sprintf("%s: %.0f%%", m.label, m.value))
+			}
+		}
+		if len(reasons) > 0 {
+			return threshold, strings.Join(reasons, " ")
+		}
+	}
+
+	return OK, ""
+}
+
+// parseCount parses a CAT API counter; missing values count as zero
+func parseCount(s string) int64 {
+	v, _ := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	return v
+}
+
+// parsePercent parses a CAT API percentage; missing values count as zero
+func parsePercent(s string) float64 {
+	v, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return v
+}
+
+// runStatus checks the cluster status colour
+func runStatus(ctx context.Context, d *clusterData) Result {
+	health, err := d.clusterHealth(ctx)
+	if err != nil {
+		return d.fetchError("cluster health", err)
+	}
+	return evaluateStatus(health)
+}
+
+// evaluateStatus maps red to critical and yellow to warning
+func evaluateStatus(health *source.ClusterHealth) Result {
+	summary := fmt.Sprintf("cluster %s is %s", health.ClusterName, health.Status)
+	switch health.Status {
+	case "green":
+		return Result{Status: OK, Summary: summary}
+	case "yellow":
+		return Result{Status: Warning, Summary: summary}
+	case "red":
+		return Result{Status: Critical, Summary: summary}
+	default:
+		return Result{Status: Unknown, Summary: summary}
+	}
+}
+
+// runUnassigned checks for unassigned shards
+func runUnassigned(ctx context.Context, d *clusterData) Result {
+	health, err := d.clusterHealth(ctx)
+	if err != nil {
+		return d.fetchError("cluster health", err)
+	}
+	return evaluateUnassigned(health)
+}
+
+// evaluateUnassigned warns on any unassigned shard; lost primaries turn the
+// cluster red, which the status check reports as critical
+func evaluateUnassigned(health *source.ClusterHealth) Result {
+	if health.UnassignedShards == 0 {
+		return Result{Status: OK, Summary: "no unassigned shards"}
+	}
+	return Result{Status: Warning, Summary: fmt.Sprintf("%d unassigned shard(s)", health.UnassignedShards)}
+}
+
+// runDisk checks per-node disk usage from the allocation API
+func runDisk(ctx context.Context, d *clusterData) Result {
+	ctx, cancel := d.requestContext(ctx)
+	defer cancel()
+
+	allocation, err := d.src.Allocation(ctx)
+	if err != nil {
+		return d.fetchError("allocation", err)
+	}
+	return evaluateDisk(allocation)
+}
+
+// evaluateDisk flags nodes over the allocation view thresholds, fullest first
+func evaluateDisk(allocation []source.AllocationInfo) Result {
+	type nodeDisk struct {
+		name    string
+		percent float64
+	}
+
+	var flagged []nodeDisk
+	critical, warning := 0, 0
+	for _, node := range allocation {
+		// Unassigned shards show up as a row without a disk percentage
+		if node.DiskPercent == "" {
+			continue
+		}
+		percent := parsePercent(node.DiskPercent)
+		switch DiskStatus(percent) {
+		case Critical:
+			critical++
+		case Warning:
+			warning++
+		default:
+			continue
+		}
+		flagged = append(flagged, nodeDisk{name: node.Node, percent: percent})
+	}
+	sort.Slice(flagged, func(i, j int) bool { return flagged[i].percent > flagged[j].percent })
+
+	res := Result{Status: OK, Summary: fmt.Sprintf("all nodes below %.0f%% disk usage", DiskWarningPercent)}
+	switch {
+	case critical > 0:
+		res.Status = Critical
+		res.Summary = fmt.Sprintf("%d node(s) at >=%.0f%% disk usage", critical, DiskCriticalPercent)
+	case warning > 0:
+		res.Status = Warning
+		res.Summary = fmt.Sprintf("%d node(s) at >=%.0f%% disk usage", warning, DiskWarningPercent)
+	}
+
+	for _, node := range flagged {
+		res.Details = append(res.Details, fmt.Sprintf("%s: %.0f%% disk used", node.name, node.percent))
+	}
+	res.Details = truncateDetails(res.Details)
+	return res
+}
+
+// runThreadPool checks for thread pool rejections
+func runThreadPool(ctx context.Context, d *clusterData) Result {
+	sample := func() ([]source.ThreadPoolInfo, error) {
+		ctx, cancel := d.requestContext(ctx)
+		defer cancel()
+		return d.src.ThreadPool(ctx)
+	}
+
+	var before []source.ThreadPoolInfo
+	window := d.opts.RejectionWindow
+	if window > 0 {
+		var err error
+		if before, err = sample(); err != nil {
+			return d.fetchError("thread pools", err)
+		}
+		select {
+		case <-time.After(window):
+		case <-ctx.Done():
+			return d.fetchError("thread pools", ctx.Err())
+		}
+	}
+
+	after, err := sample()
+	if err != nil {
+		return d.fetchError("thread pools", err)
+	}
+	return evaluateThreadPool(before, after, window, d.opts.RejectionThreshold)
+}
+
+// evaluateThreadPool flags the rejections pools recorded between two
+// samples, critical once they reach threshold. Rejection counters are
+// cumulative since node start, so without a first sample every rejection
+// the node ever recorded counts.
+func evaluateThreadPool(before, after []source.ThreadPoolInfo, window time.Duration, threshold int64) Result {
+	type rejection struct {
+		node, pool string
+		count      int64
+	}
+
+	baseline := make(map[string]int64, len(before))
+	for _, pool := range before {
+		baseline[pool.NodeName+"/"+pool.Name] = parseCount(pool.Rejected)
+	}
+
+	var rejections []rejection
+	var total int64
+	for _, pool := range after {
+		count := parseCount(pool.Rejected)
+		if last := baseline[pool.NodeName+"/"+pool.Name]; count >= last {
+			count -= last
+		} // Otherwise the node restarted, and every rejection is new
+		if count <= 0 {
+			continue
+		}
+		total += count
+		rejections = append(rejections, rejection{node: pool.NodeName, pool: pool.Name, count: count})
+	}
+
+	period := "since node start"
+	if window > 0 {
+		period = "in the last " + window.String()
+	}
+	if total == 0 {
+		return Result{Status: OK, Summary: "no thread pool rejections " + period}
+	}
+
+	status := Critical
+	if total < max(threshold, 1) {
+		status = OK
+	}
+	sort.Slice(rejections, func(i, j int) bool { return rejections[i].count > rejections[j].count })
+	res := Result{
+		Status:  status,
+		Summary: fmt.Sprintf("%d thread pool rejection(s) %s", total, period),
+	}
+	for _, r := range rejections {
+		res.Details = append(res.Details, fmt.Sprintf("%s/%s: %d rejected", r.node, r.pool, r.count))
+	}
+	res.Details = truncateDetails(res.Details)
+	return res
+}
+
+// runHotspots checks per-node heap, CPU, RAM and disk
+func runHotspots(ctx context.Context, d *clusterData) Result {
+	ctx, cancel := d.requestContext(ctx)
+	defer cancel()
+
+	nodes, err := d.src.Nodes(ctx)
+	if err != nil {
+		return d.fetchError("nodes", err)
+	}
+	return evaluateHotspots(nodes)
+}
+
+// evaluateHotspots flags nodes over the resources view thresholds
+func evaluateHotspots(nodes []source.NodeInfo) Result {
+	res := Result{Status: OK, Summary: "all nodes within normal thresholds"}
+
+	critical, warning := 0, 0
+	var criticalDetails, warningDetails []string
+	for _, node := range nodes {
+		status, reason := NodeHotspot(node)
+		switch status {
+		case Critical:
+			critical++
+			criticalDetails = append(criticalDetails, fmt.Sprintf("%s: %s", node.Name, reason))
+		case Warning:
+			warning++
+			warningDetails = append(warningDetails, fmt.Sprintf("%s: %s", node.Name, reason))
+		}
+	}
+
+	switch {
+	case critical > 0:
+		res.Status = Critical
+		res.Summary = fmt.Sprintf("%d node(s) critical, %d warning", critical, warning)
+	case warning > 0:
+		res.Status = Warning
+		res.Summary = fmt.Sprintf("%d node(s) warning", warning)
+	}
+
+	res.Details = truncateDetails(append(criticalDetails, warningDetails...))
+	return res
+}
+
+// truncateDetails keeps the first maxDetails lines and notes how many were cut
+func truncateDetails(details []string) []string {
+	if len(details) <= maxDetails {
+		return details
+	}
+	extra := len(details) - maxDetails
+	return append(details[:maxDetails:maxDetails], fmt.Sprintf("... and %d more", extra))
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	opensearch "github.com/opensearch-project/opensearch-go/v2"
+	"golang.org/x/term"
+)
+
+// AuthMode selects how requests to a non-AWS cluster are authenticated
+type AuthMode string
+
+const (
+	AuthNone   AuthMode = "none"
+	AuthBasic  AuthMode = "basic"
+	AuthBearer AuthMode = "bearer"
+	AuthAPIKey AuthMode = "apikey"
+)
+
+// DefaultAPIKeyHeader is the header used for API-key authentication unless overridden
+const DefaultAPIKeyHeader = "Authorization"
+
+// Auth holds the credentials for a non-AWS cluster
+type Auth struct {
+	Mode         AuthMode
+	Username     string // Basic auth user
+	Password     string // Basic auth password
+	Token        string // Bearer token or API key
+	APIKeyHeader string // Header carrying the API key (default: Authorization)
+}
+
+// ParseAuthMode validates an --auth value; an empty string means no authentication
+func ParseAuthMode(s string) (AuthMode, error) {
+	switch AuthMode(strings.ToLower(s)) {
+	case "", AuthNone:
+		return AuthNone, nil
+	case AuthBasic:
+		return AuthBasic, nil
+	case AuthBearer:
+		return AuthBearer, nil
+	case AuthAPIKey, "api-key":
+		return AuthAPIKey, nil
+	default:
+		return "", fmt.Errorf("unknown auth mode %q (expected none, basic, bearer or apikey)", s)
+	}
+}
+
+// String returns a short, secret-free description of the auth mode
+func (a Auth) String() string {
+	switch a.Mode {
+	case AuthBasic:
+		return fmt.Sprintf("basic (%s)", a.Username)
+	case AuthBearer:
+		return "bearer"
+	case AuthAPIKey:
+		return "apikey"
+	default:
+		return "none"
+	}
+}
+
+// apply configures the OpenSearch client config for the selected auth mode
+func (a Auth) apply(cfg *opensearch.Config) error {
+	switch a.Mode {
+	case "", AuthNone:
+		return nil
+
+	case AuthBasic:
+		if a.Username == "" {
+			return fmt.Errorf("basic auth requires a username")
+		}
+		cfg.Username = a.Username
+		cfg.Password = a.Password
+
+	case AuthBearer:
+		if a.Token == "" {
+			return fmt.Errorf("bearer auth requires a token")
+		}
+		cfg.Header = http.Header{}
+		cfg.Header.Set("Authorization", "Bearer "+a.Token)
+
+	case AuthAPIKey:
+		if a.Token == "" {
+			return fmt.Errorf("apikey auth requires an API key")
+		}
+		header := a.APIKeyHeader
+		if header == "" {
+			header = DefaultAPIKeyHeader
+		}
+		cfg.Header = http.Header{}
+		// The standard Authorization header needs the ApiKey scheme; custom
+		// headers (e.g. x-api-key behind a gateway) carry the raw key
+		if strings.EqualFold(header, "Authorization") {
+			cfg.Header.Set(header, "ApiKey "+a.Token)
+		} else {
+			cfg.Header.Set(header, a.Token)
+		}
+
+	default:
+		return fmt.Errorf("unknown auth mode %q", a.Mode)
+	}
+
+	return nil
+}
+
+// AuthSource says where to find credentials for an auth mode. Secrets are
+// referenced by environment variable or file, never given inline.
+type AuthSource struct {
+	Mode         string
+	Username     string
+	PasswordEnv  string
+	PasswordFile string
+	TokenEnv     string
+	TokenFile    string
+	APIKeyHeader string
+}
+
+// Resolve reads the referenced secrets and returns ready-to-use credentials.
+// When interactive is false a missing secret source is an error rather than
+// a terminal prompt (e.g. when switching clusters inside the TUI).
+func (s AuthSource) Resolve(interactive bool) (Auth, error) {
+	mode := s.Mode
+	if mode == "" && s.Username != "" {
+		mode = string(AuthBasic)
+	}
+
+	authMode, err := ParseAuthMode(mode)
+	if err != nil {
+		return Auth{}, err
+	}
+
+	auth := Auth{Mode: authMode, Username: s.Username, APIKeyHeader: s.APIKeyHeader}
+
+	switch authMode {
+	case AuthBasic:
+		if s.Username == "" {
+			return Auth{}, fmt.Errorf("a username is required for basic auth")
+		}
+		if !interactive && s.PasswordEnv == "" && s.PasswordFile == "" {
+			return Auth{}, fmt.Errorf("no password source configured for %s (set a password env var or file)", s.Username)
+		}
+		auth.Password, err = ReadSecret(s.PasswordEnv, s.PasswordFile, fmt.Sprintf("Password for %s: ", s.Username))
+		if err != nil {
+			return Auth{}, fmt.Errorf("password: %w", err)
+		}
+	case AuthBearer, AuthAPIKey:
+		if !interactive && s.TokenEnv == "" && s.TokenFile == "" {
+			return Auth{}, fmt.Errorf("no %s token source configured (set a token env var or file)", authMode)
+		}
+		auth.Token, err = ReadSecret(s.TokenEnv, s.TokenFile, fmt.Sprintf("%s token: ", authMode))
+		if err != nil {
+			return Auth{}, fmt.Errorf("token: %w", err)
+		}
+	}
+
+	return auth, nil
+}
+
+// ReadSecret resolves a secret from an environment variable or a file, falling
+// back to an interactive prompt when neither is given. Secrets are never taken
+// from argv so they don't leak into shell history or process listings.
+func ReadSecret(envVar, file, prompt string) (string, error) {
+	if envVar != "" {
+		value, ok := os.LookupEnv(envVar)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", envVar)
+		}
+		return value, nil
+	}
+
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no secret provided and stdin is not a terminal")
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	data, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret: %w", err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,236 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ==============================================================================
+// Authentication Tests
+// ==============================================================================
+
+// TestParseAuthMode tests --auth value validation
+func TestParseAuthMode(t *testing.T) {
+	tests := []struct {
+		input       string
+		want        AuthMode
+		expectError bool
+	}{
+		{"", AuthNone, false},
+		{"none", AuthNone, false},
+		{"basic", AuthBasic, false},
+		{"BASIC", AuthBasic, false},
+		{"bearer", AuthBearer, false},
+		{"apikey", AuthAPIKey, false},
+		{"api-key", AuthAPIKey, false},
+		{"kerberos", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAuthMode(tt.input)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ParseAuthMode(%q) expected error, got nil", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAuthMode(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAuthMode(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAuthLabel tests the title bar description of each auth mode
+func TestAuthLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{"none", Options{Endpoint: "http://localhost:9200"}, "none"},
+		{"basic", Options{Endpoint: "http://localhost:9200", Auth: Auth{Mode: AuthBasic, Username: "admin", Password: "secret"}}, "basic (admin)"},
+		{"bearer", Options{Endpoint: "http://localhost:9200", Auth: Auth{Mode: AuthBearer, Token: "secret"}}, "bearer"},
+		{"apikey", Options{Endpoint: "http://localhost:9200", Auth: Auth{Mode: AuthAPIKey, Token: "secret"}}, "apikey"},
+		{"aws", Options{Endpoint: "https://my-domain.us-east-1.es.amazonaws.com", Region: "us-east-1"}, "sigv4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AuthLabel(tt.opts)
+			if got != tt.want {
+				t.Errorf("AuthLabel() = %q, want %q", got, tt.want)
+			}
+			if strings.Contains(got, "secret") {
+				t.Errorf("AuthLabel() leaked a secret: %q", got)
+			}
+		})
+	}
+}
+
+// TestNewLocalClient_AuthHeaders tests that each auth mode sends the expected header
+func TestNewLocalClient_AuthHeaders(t *testing.T) {
+	tests := []struct {
+		name       string
+		auth       Auth
+		header     string
+		wantHeader string
+	}{
+		{"none", Auth{Mode: AuthNone}, "Authorization", ""},
+		{"basic", Auth{Mode: AuthBasic, Username: "admin", Password: "admin"}, "Authorization", "Basic YWRtaW46YWRtaW4="},
+		{"bearer", Auth{Mode: AuthBearer, Token: "tok"}, "Authorization", "Bearer tok"},
+		{"apikey_default_header", Auth{Mode: AuthAPIKey, Token: "key"}, "Authorization", "ApiKey key"},
+		{"apikey_custom_header", Auth{Mode: AuthAPIKey, Token: "key", APIKeyHeader: "x-api-key"}, "X-Api-Key", "key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get(tt.header)
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, `{"status":"green"}`)
+			}))
+			defer server.Close()
+
+			client, _, err := newLocalClient(Options{Endpoint: server.URL, Auth: tt.auth})
+			if err != nil {
+				t.Fatalf("newLocalClient() error = %v", err)
+			}
+
+			res, err := client.Cluster.Health()
+			if err != nil {
+				t.Fatalf("health request failed: %v", err)
+			}
+			res.Body.Close()
+
+			if got != tt.wantHeader {
+				t.Errorf("%s header = %q, want %q", tt.header, got, tt.wantHeader)
+			}
+		})
+	}
+}
+
+// TestNewLocalClient_AuthValidation tests that incomplete credentials are rejected
+func TestNewLocalClient_AuthValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		auth Auth
+	}{
+		{"basic_without_username", Auth{Mode: AuthBasic, Password: "x"}},
+		{"bearer_without_token", Auth{Mode: AuthBearer}},
+		{"apikey_without_key", Auth{Mode: AuthAPIKey}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := newLocalClient(Options{Endpoint: "http://localhost:9200", Auth: tt.auth})
+			if err == nil {
+				t.Error("newLocalClient() expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestNewClient_AWSRejectsAuth tests that explicit auth modes are refused for SigV4 endpoints
+func TestNewClient_AWSRejectsAuth(t *testing.T) {
+	_, _, err := NewClient(Options{
+		Endpoint: "https://my-domain.us-east-1.es.amazonaws.com",
+		Region:   "us-east-1",
+		Auth:     Auth{Mode: AuthBasic, Username: "admin"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "not supported for AWS") {
+		t.Errorf("NewClient() error = %v, want auth-not-supported error", err)
+	}
+}
+
+// TestReadSecret tests resolving secrets from env vars and files
+func TestReadSecret(t *testing.T) {
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("OSTOP_TEST_SECRET", "from-env")
+		got, err := ReadSecret("OSTOP_TEST_SECRET", "", "")
+		if err != nil {
+			t.Fatalf("ReadSecret() error = %v", err)
+		}
+		if got != "from-env" {
+			t.Errorf("ReadSecret() = %q, want %q", got, "from-env")
+		}
+	})
+
+	t.Run("env_missing", func(t *testing.T) {
+		_, err := ReadSecret("OSTOP_TEST_SECRET_MISSING", "", "")
+		if err == nil {
+			t.Error("ReadSecret() expected error for unset variable")
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "secret")
+		if err := os.WriteFile(path, []byte("from-file\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadSecret("", path, "")
+		if err != nil {
+			t.Fatalf("ReadSecret() error = %v", err)
+		}
+		if got != "from-file" {
+			t.Errorf("ReadSecret() = %q, want %q", got, "from-file")
+		}
+	})
+
+	t.Run("file_missing", func(t *testing.T) {
+		_, err := ReadSecret("", filepath.Join(t.TempDir(), "nope"), "")
+		if err == nil {
+			t.Error("ReadSecret() expected error for missing file")
+		}
+	})
+}
+
+// TestAuthSource_Resolve tests turning secret references into credentials
+func TestAuthSource_Resolve(t *testing.T) {
+	t.Setenv("OSTOP_TEST_PASSWORD", "pw")
+	t.Setenv("OSTOP_TEST_TOKEN", "tok")
+
+	tests := []struct {
+		name        string
+		source      AuthSource
+		want        Auth
+		expectError bool
+	}{
+		{"empty", AuthSource{}, Auth{Mode: AuthNone}, false},
+		{"username_implies_basic", AuthSource{Username: "admin", PasswordEnv: "OSTOP_TEST_PASSWORD"}, Auth{Mode: AuthBasic, Username: "admin", Password: "pw"}, false},
+		{"bearer_env", AuthSource{Mode: "bearer", TokenEnv: "OSTOP_TEST_TOKEN"}, Auth{Mode: AuthBearer, Token: "tok"}, false},
+		{"apikey_header", AuthSource{Mode: "apikey", TokenEnv: "OSTOP_TEST_TOKEN", APIKeyHeader: "x-api-key"}, Auth{Mode: AuthAPIKey, Token: "tok", APIKeyHeader: "x-api-key"}, false},
+		{"basic_without_username", AuthSource{Mode: "basic", PasswordEnv: "OSTOP_TEST_PASSWORD"}, Auth{}, true},
+		{"basic_without_source", AuthSource{Mode: "basic", Username: "admin"}, Auth{}, true},
+		{"bearer_without_source", AuthSource{Mode: "bearer"}, Auth{}, true},
+		{"unknown_mode", AuthSource{Mode: "digest"}, Auth{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.source.Resolve(false)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Resolve() expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"net/http"
 	"strings"
@@ -12,21 +11,48 @@ import (
 	requestsigner "github.com/opensearch-project/opensearch-go/v2/signer/awsv2"
 )
 
-// NewClient creates an OpenSearch client with automatic AWS signing detection
-func NewClient(endpoint, region, profile string, insecure bool) (*opensearch.Client, error) {
-	// Detect if endpoint is AWS OpenSearch
-	isAWS := strings.Contains(endpoint, ".es.amazonaws.com") ||
-		strings.Contains(endpoint, ".aoss.amazonaws.com")
+// Options holds everything needed to connect to a cluster
+type Options struct {
+	Endpoint string
+	Region   string     // AWS region, required for AWS endpoints
+	Profile  string     // AWS shared config profile
+	Insecure bool       // Skip TLS verification (development only)
+	TLS      TLSOptions // Custom CA bundle and client certificate
+	Auth     Auth       // Credentials for non-AWS clusters
+}
 
-	if isAWS {
-		if region == "" {
-			return nil, fmt.Errorf("--region required for AWS OpenSearch endpoints")
+// NewClient creates an OpenSearch client with automatic AWS signing detection.
+// Warnings about CAs skipped from the bundle are returned for the caller to
+// show, since the terminal may belong to the UI.
+func NewClient(opts Options) (*opensearch.Client, []string, error) {
+	if IsAWSEndpoint(opts.Endpoint) {
+		if opts.Region == "" {
+			return nil, nil, fmt.Errorf("--region required for AWS OpenSearch endpoints")
 		}
-		return newAWSClient(endpoint, region, profile)
+		if opts.Auth.Mode != "" && opts.Auth.Mode != AuthNone {
+			return nil, nil, fmt.Errorf("--auth %s is not supported for AWS OpenSearch endpoints (SigV4 is used)", opts.Auth.Mode)
+		}
+		client, err := newAWSClient(opts.Endpoint, opts.Region, opts.Profile)
+		return client, nil, err
 	}
 
 	// Local or non-AWS OpenSearch
-	return newLocalClient(endpoint, insecure)
+	return newLocalClient(opts)
+}
+
+// IsAWSEndpoint reports whether the endpoint is an AWS managed OpenSearch domain or collection
+func IsAWSEndpoint(endpoint string) bool {
+	return strings.Contains(endpoint, ".es.amazonaws.com") ||
+		strings.Contains(endpoint, ".aoss.amazonaws.com")
+}
+
+// AuthLabel returns a short description of how requests are authenticated,
+// suitable for display next to the endpoint
+func AuthLabel(opts Options) string {
+	if IsAWSEndpoint(opts.Endpoint) {
+		return "sigv4"
+	}
+	return opts.Auth.String()
 }
 
 // newAWSClient creates a client with AWS Signature V4 signing
@@ -65,24 +91,32 @@ func newAWSClient(endpoint, region, profile string) (*opensearch.Client, error)
 }
 
 // newLocalClient creates a client for local/non-AWS OpenSearch
-func newLocalClient(endpoint string, insecure bool) (*opensearch.Client, error) {
+func newLocalClient(opts Options) (*opensearch.Client, []string, error) {
 	cfg := opensearch.Config{
-		Addresses: []string{endpoint},
+		Addresses: []string{opts.Endpoint},
 	}
 
-	// Allow insecure TLS for local development
-	if insecure {
+	// Custom CA, mutual TLS, or insecure TLS for local development
+	var warnings []string
+	if opts.Insecure || !opts.TLS.IsZero() {
+		tlsConfig, skipped, err := buildTLSConfig(opts.TLS, opts.Insecure)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnings = skipped
 		cfg.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
+			TLSClientConfig: tlsConfig,
 		}
 	}
 
+	if err := opts.Auth.apply(&cfg); err != nil {
+		return nil, nil, err
+	}
+
 	client, err := opensearch.NewClient(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create OpenSearch client: %w", err)
+		return nil, nil, fmt.Errorf("failed to create OpenSearch client: %w", err)
 	}
 
-	return client, nil
+	return client, warnings, nil
 }
@@ -48,7 +48,7 @@ func TestNewClient_AWSEndpointDetection(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewClient(tt.endpoint, tt.region, "", false)
+			_, _, err := NewClient(Options{Endpoint: tt.endpoint, Region: tt.region})
 
 			if tt.expectError {
 				if err == nil {
@@ -116,7 +116,7 @@ func TestNewLocalClient_InsecureFlag(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := newLocalClient(tt.endpoint, tt.insecure)
+			client, _, err := newLocalClient(Options{Endpoint: tt.endpoint, Insecure: tt.insecure})
 			if err != nil {
 				t.Fatalf("newLocalClient() error = %v", err)
 			}
@@ -146,7 +146,7 @@ func TestNewLocalClient_Success(t *testing.T) {
 
 	for _, endpoint := range endpoints {
 		t.Run(endpoint, func(t *testing.T) {
-			client, err := newLocalClient(endpoint, false)
+			client, _, err := newLocalClient(Options{Endpoint: endpoint})
 			if err != nil {
 				t.Errorf("newLocalClient(%q) error = %v, want nil", endpoint, err)
 			}
@@ -174,7 +174,7 @@ func TestNewClient_EndpointVariations(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := NewClient(tt.endpoint, tt.region, "", false)
+			client, _, err := NewClient(Options{Endpoint: tt.endpoint, Region: tt.region})
 			// We don't check for connection success, just that client creation doesn't fail
 			// due to endpoint format issues
 			if err != nil {
@@ -196,7 +196,7 @@ func TestNewClient_ProfileParameter(t *testing.T) {
 	endpoint := "http://localhost:9200"
 	profile := "test-profile"
 
-	client, err := NewClient(endpoint, "", profile, false)
+	client, _, err := NewClient(Options{Endpoint: endpoint, Profile: profile})
 	// Should succeed for local endpoints regardless of profile
 	if err != nil {
 		// Check it's not a profile-related error
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	opensearch "github.com/opensearch-project/opensearch-go/v2"
+)
+
+// TLSOptions configures certificate verification and mutual TLS
+type TLSOptions struct {
+	CACert     string // PEM bundle of CAs used to verify the server (replaces system roots)
+	ClientCert string // PEM client certificate for mutual TLS
+	ClientKey  string // PEM private key matching ClientCert
+}
+
+// IsZero reports whether no TLS options were set
+func (o TLSOptions) IsZero() bool {
+	return o.CACert == "" && o.ClientCert == "" && o.ClientKey == ""
+}
+
+// buildTLSConfig loads the CA bundle and client key pair, validating them up
+// front so a bad or expired certificate is reported at startup. It also
+// returns a warning for each CA skipped from the bundle.
+func buildTLSConfig(opts TLSOptions, insecure bool) (*tls.Config, []string, error) {
+	cfg := &tls.Config{
+		InsecureSkipVerify: insecure,
+	}
+
+	var warnings []string
+	if opts.CACert != "" {
+		pool, skipped, err := loadCAPool(opts.CACert)
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.RootCAs = pool
+		warnings = skipped
+	}
+
+	if (opts.ClientCert == "") != (opts.ClientKey == "") {
+		return nil, nil, fmt.Errorf("--client-cert and --client-key must be given together")
+	}
+
+	if opts.ClientCert != "" {
+		pair, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load client certificate %s: %w", opts.ClientCert, err)
+		}
+		leaf, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse client certificate %s: %w", opts.ClientCert, err)
+		}
+		if err := checkValidity(leaf, opts.ClientCert); err != nil {
+			return nil, nil, err
+		}
+		pair.Leaf = leaf
+		cfg.Certificates = []tls.Certificate{pair}
+	}
+
+	return cfg, warnings, nil
+}
+
+// loadCAPool reads a PEM bundle into a certificate pool. Expired and not yet
+// valid CAs are skipped and returned as warnings, as system and corporate
+// bundles often still carry retired roots; only a bundle with no usable CA is
+// rejected.
+func loadCAPool(path string) (*x509.CertPool, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	count := 0
+	var skipped []error
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse certificate in CA bundle %s: %w", path, err)
+		}
+		if err := checkValidity(cert, path); err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		pool.AddCert(cert)
+		count++
+	}
+
+	if count == 0 && len(skipped) > 0 {
+		return nil, nil, fmt.Errorf("CA bundle %s has no currently valid certificates: %w", path, errors.Join(skipped...))
+	}
+	if count == 0 {
+		return nil, nil, fmt.Errorf("CA bundle %s contains no PEM certificates", path)
+	}
+
+	warnings := make([]string, 0, len(skipped))
+	for _, err := range skipped {
+		warnings = append(warnings, fmt.Sprintf("skipping CA: %v", err))
+	}
+	return pool, warnings, nil
+}
+
+// checkValidity returns an error if the certificate is expired or not yet valid
+func checkValidity(cert *x509.Certificate, path string) error {
+	now := time.Now()
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("certificate %q in %s expired on %s", cert.Subject.CommonName, path, cert.NotAfter.Format(time.RFC3339))
+	}
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("certificate %q in %s is not valid until %s", cert.Subject.CommonName, path, cert.NotBefore.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// CheckTLS performs one request against the cluster and returns a descriptive
+// error if the TLS handshake fails. Non-TLS failures (connection refused,
+// HTTP errors) are ignored here and left for the UI to report.
+func CheckTLS(ctx context.Context, c *opensearch.Client) error {
+	res, err := c.Info(c.Info.WithContext(ctx))
+	if err == nil {
+		res.Body.Close()
+		return nil
+	}
+
+	if msg, ok := describeTLSError(err); ok {
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// describeTLSError turns certificate verification and handshake failures into
+// an actionable message
+func describeTLSError(err error) (string, bool) {
+	var unknownAuthority x509.UnknownAuthorityError
+	if errors.As(err, &unknownAuthority) {
+		return "server certificate is signed by an unknown authority (use --ca-cert to trust your CA)", true
+	}
+
+	var hostname x509.HostnameError
+	if errors.As(err, &hostname) {
+		return fmt.Sprintf("server certificate does not match the endpoint host: %v", hostname), true
+	}
+
+	var invalid x509.CertificateInvalidError
+	if errors.As(err, &invalid) {
+		if invalid.Reason == x509.Expired {
+			return fmt.Sprintf("server certificate is expired or not yet valid: %v", invalid), true
+		}
+		return fmt.Sprintf("server certificate is invalid: %v", invalid), true
+	}
+
+	var verification *tls.CertificateVerificationError
+	if errors.As(err, &verification) {
+		return fmt.Sprintf("server certificate verification failed: %v", verification.Err), true
+	}
+
+	// Servers reject a missing or untrusted client certificate with a TLS alert
+	if strings.Contains(err.Error(), "remote error: tls:") {
+		return fmt.Sprintf("server rejected the TLS handshake, check --client-cert/--client-key: %v", err), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,264 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ==============================================================================
+// TLS Tests
+// ==============================================================================
+
+// writeTestCert generates a self-signed certificate valid between notBefore and
+// notAfter and writes the certificate and key as PEM files into dir
+func writeTestCert(t *testing.T, dir, name string, notBefore, notAfter time.Time) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certPath = filepath.Join(dir, name+".pem")
+	keyPath = filepath.Join(dir, name+"-key.pem")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certPath, keyPath
+}
+
+// writeServerCA writes the httptest server certificate as a CA bundle
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "server-ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestBuildTLSConfig tests CA bundle and client certificate loading
+func TestBuildTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	validCert, validKey := writeTestCert(t, dir, "valid", now.Add(-time.Hour), now.Add(time.Hour))
+	expiredCert, expiredKey := writeTestCert(t, dir, "expired", now.Add(-2*time.Hour), now.Add(-time.Hour))
+	futureCert, futureKey := writeTestCert(t, dir, "future", now.Add(time.Hour), now.Add(2*time.Hour))
+
+	garbage := filepath.Join(dir, "garbage.pem")
+	if err := os.WriteFile(garbage, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     TLSOptions
+		errorMsg string
+	}{
+		{"ca_only", TLSOptions{CACert: validCert}, ""},
+		{"client_pair", TLSOptions{ClientCert: validCert, ClientKey: validKey}, ""},
+		{"ca_and_client_pair", TLSOptions{CACert: validCert, ClientCert: validCert, ClientKey: validKey}, ""},
+		{"ca_missing_file", TLSOptions{CACert: filepath.Join(dir, "missing.pem")}, "failed to read CA bundle"},
+		{"ca_no_certificates", TLSOptions{CACert: garbage}, "contains no PEM certificates"},
+		{"ca_expired", TLSOptions{CACert: expiredCert}, "expired on"},
+		{"ca_none_valid", TLSOptions{CACert: futureCert}, "no currently valid certificates"},
+		{"client_expired", TLSOptions{ClientCert: expiredCert, ClientKey: expiredKey}, "expired on"},
+		{"client_not_yet_valid", TLSOptions{ClientCert: futureCert, ClientKey: futureKey}, "not valid until"},
+		{"client_cert_without_key", TLSOptions{ClientCert: validCert}, "must be given together"},
+		{"client_key_mismatch", TLSOptions{ClientCert: validCert, ClientKey: expiredKey}, "failed to load client certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, _, err := buildTLSConfig(tt.opts, false)
+
+			if tt.errorMsg != "" {
+				if err == nil {
+					t.Fatalf("buildTLSConfig() expected error containing %q, got nil", tt.errorMsg)
+				}
+				if !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("buildTLSConfig() error = %v, want error containing %q", err, tt.errorMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("buildTLSConfig() error = %v", err)
+			}
+			if tt.opts.CACert != "" && cfg.RootCAs == nil {
+				t.Error("buildTLSConfig() should set RootCAs when a CA bundle is given")
+			}
+			if tt.opts.ClientCert != "" && len(cfg.Certificates) != 1 {
+				t.Errorf("buildTLSConfig() certificates = %d, want 1", len(cfg.Certificates))
+			}
+		})
+	}
+}
+
+// TestLoadCAPool_SkipsInvalidCAs tests that only currently valid CAs of a
+// bundle end up in the pool, with a warning for each skipped one
+func TestLoadCAPool_SkipsInvalidCAs(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	validCert, _ := writeTestCert(t, dir, "valid", now.Add(-time.Hour), now.Add(time.Hour))
+	expiredCert, _ := writeTestCert(t, dir, "expired", now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	var bundle []byte
+	for _, path := range []string{expiredCert, validCert} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bundle = append(bundle, data...)
+	}
+	mixed := filepath.Join(dir, "mixed.pem")
+	if err := os.WriteFile(mixed, bundle, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	pool, warnings, err := loadCAPool(mixed)
+	if err != nil {
+		t.Fatalf("loadCAPool() error = %v", err)
+	}
+	want, _, err := loadCAPool(validCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pool.Equal(want) {
+		t.Error("loadCAPool() should hold only the valid CA")
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "skipping CA") || !strings.Contains(warnings[0], "expired") {
+		t.Errorf("loadCAPool() warnings = %v, want one skipped expired CA", warnings)
+	}
+}
+
+// TestNewLocalClient_TLSErrorsAtCreation tests that certificate problems fail client creation
+func TestNewLocalClient_TLSErrorsAtCreation(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	expiredCert, expiredKey := writeTestCert(t, dir, "expired", now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	_, _, err := newLocalClient(Options{
+		Endpoint: "https://localhost:9200",
+		TLS:      TLSOptions{ClientCert: expiredCert, ClientKey: expiredKey},
+	})
+	if err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("newLocalClient() error = %v, want expired certificate error", err)
+	}
+}
+
+// TestCheckTLS tests startup verification against a TLS server
+func TestCheckTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"version":{"number":"2.11.0","distribution":"opensearch"}}`)
+	}))
+	defer server.Close()
+
+	t.Run("unknown_authority", func(t *testing.T) {
+		c, _, err := newLocalClient(Options{Endpoint: server.URL})
+		if err != nil {
+			t.Fatalf("newLocalClient() error = %v", err)
+		}
+		err = CheckTLS(context.Background(), c)
+		if err == nil || !strings.Contains(err.Error(), "--ca-cert") {
+			t.Errorf("CheckTLS() error = %v, want unknown authority hint", err)
+		}
+	})
+
+	t.Run("trusted_ca", func(t *testing.T) {
+		c, _, err := newLocalClient(Options{Endpoint: server.URL, TLS: TLSOptions{CACert: writeServerCA(t, server)}})
+		if err != nil {
+			t.Fatalf("newLocalClient() error = %v", err)
+		}
+		if err := CheckTLS(context.Background(), c); err != nil {
+			t.Errorf("CheckTLS() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		c, _, err := newLocalClient(Options{Endpoint: server.URL, Insecure: true})
+		if err != nil {
+			t.Fatalf("newLocalClient() error = %v", err)
+		}
+		if err := CheckTLS(context.Background(), c); err != nil {
+			t.Errorf("CheckTLS() error = %v, want nil", err)
+		}
+	})
+}
+
+// TestCheckTLS_ClientCertificateRequired tests that mTLS rejections are reported
+func TestCheckTLS_ClientCertificateRequired(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{}`)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	c, _, err := newLocalClient(Options{Endpoint: server.URL, TLS: TLSOptions{CACert: writeServerCA(t, server)}})
+	if err != nil {
+		t.Fatalf("newLocalClient() error = %v", err)
+	}
+
+	err = CheckTLS(context.Background(), c)
+	if err == nil {
+		t.Fatal("CheckTLS() expected error when server requires a client certificate")
+	}
+	if !strings.Contains(err.Error(), "--client-cert") {
+		t.Errorf("CheckTLS() error = %v, want client certificate hint", err)
+	}
+}
+
+// TestCheckTLS_IgnoresConnectionErrors tests that non-TLS failures are left to the UI
+func TestCheckTLS_IgnoresConnectionErrors(t *testing.T) {
+	c, _, err := newLocalClient(Options{Endpoint: "https://127.0.0.1:1"})
+	if err != nil {
+		t.Fatalf("newLocalClient() error = %v", err)
+	}
+	if err := CheckTLS(context.Background(), c); err != nil {
+		t.Errorf("CheckTLS() error = %v, want nil for connection errors", err)
+	}
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vegasq/ostop/internal/client"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the on-disk ostop configuration
+type Config struct {
+	Default         string        `yaml:"default"`          // Cluster used when neither --cluster nor --endpoint is given
+	RefreshInterval time.Duration `yaml:"refresh_interval"` // Auto-refresh interval for clusters that don't set their own
+	SnapshotMaxAge  time.Duration `yaml:"snapshot_max_age"` // Snapshot age warning threshold for clusters that don't set their own
+	Clusters        []Cluster     `yaml:"clusters"`         // Named cluster profiles, in picker order
+}
+
+// MinRefreshInterval is the shortest allowed auto-refresh interval
+const MinRefreshInterval = time.Second
+
+// Cluster is a named cluster profile
+type Cluster struct {
+	Name            string        `yaml:"name"`
+	Endpoint        string        `yaml:"endpoint"`
+	Region          string        `yaml:"region"`
+	Profile         string        `yaml:"aws_profile"`
+	Insecure        bool          `yaml:"insecure"`
+	Auth            Auth          `yaml:"auth"`
+	TLS             TLS           `yaml:"tls"`
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	SnapshotMaxAge  time.Duration `yaml:"snapshot_max_age"`
+}
+
+// Auth references credentials for a cluster; secrets come from env vars or files
+type Auth struct {
+	Mode         string `yaml:"mode"`
+	Username     string `yaml:"username"`
+	PasswordEnv  string `yaml:"password_env"`
+	PasswordFile string `yaml:"password_file"`
+	TokenEnv     string `yaml:"token_env"`
+	TokenFile    string `yaml:"token_file"`
+	APIKeyHeader string `yaml:"api_key_header"`
+}
+
+// TLS holds certificate paths for a cluster
+type TLS struct {
+	CACert     string `yaml:"ca_cert"`
+	ClientCert string `yaml:"client_cert"`
+	ClientKey  string `yaml:"client_key"`
+}
+
+// DefaultPath returns ~/.config/ostop/config.yaml, honouring XDG_CONFIG_HOME
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "ostop", "config.yaml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "ostop", "config.yaml")
+}
+
+// Load reads and validates a config file. A missing file yields an empty
+// config unless required is set.
+func Load(path string, required bool) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !required {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	// Profiles inherit the top-level refresh interval and snapshot age
+	for i := range cfg.Clusters {
+		if cfg.Clusters[i].RefreshInterval == 0 {
+			cfg.Clusters[i].RefreshInterval = cfg.RefreshInterval
+		}
+		if cfg.Clusters[i].SnapshotMaxAge == 0 {
+			cfg.Clusters[i].SnapshotMaxAge = cfg.SnapshotMaxAge
+		}
+	}
+
+	return &cfg, nil
+}
+
+// validate checks cluster names are present and unique and that refresh
+// intervals and snapshot ages are sane
+func (c *Config) validate() error {
+	if err := validateRefreshInterval(c.RefreshInterval); err != nil {
+		return err
+	}
+	if c.SnapshotMaxAge < 0 {
+		return fmt.Errorf("snapshot_max_age %s is negative", c.SnapshotMaxAge)
+	}
+
+	seen := make(map[string]bool)
+	for i, cluster := range c.Clusters {
+		if cluster.Name == "" {
+			return fmt.Errorf("cluster #%d has no name", i+1)
+		}
+		if seen[cluster.Name] {
+			return fmt.Errorf("duplicate cluster name %q", cluster.Name)
+		}
+		seen[cluster.Name] = true
+		if cluster.Endpoint == "" {
+			return fmt.Errorf("cluster %q has no endpoint", cluster.Name)
+		}
+		if err := validateRefreshInterval(cluster.RefreshInterval); err != nil {
+			return fmt.Errorf("cluster %q: %w", cluster.Name, err)
+		}
+		if cluster.SnapshotMaxAge < 0 {
+			return fmt.Errorf("cluster %q: snapshot_max_age %s is negative", cluster.Name, cluster.SnapshotMaxAge)
+		}
+	}
+
+	if c.Default != "" && !seen[c.Default] {
+		return fmt.Errorf("default cluster %q is not defined", c.Default)
+	}
+
+	return nil
+}
+
+// validateRefreshInterval rejects intervals that would hammer the cluster.
+// Zero means unset.
+func validateRefreshInterval(d time.Duration) error {
+	if d != 0 && d < MinRefreshInterval {
+		return fmt.Errorf("refresh_interval %s is below the minimum of %s", d, MinRefreshInterval)
+	}
+	return nil
+}
+
+// Find returns the named cluster profile
+func (c *Config) Find(name string) (Cluster, error) {
+	for _, cluster := range c.Clusters {
+		if cluster.Name == name {
+			return cluster, nil
+		}
+	}
+	return Cluster{}, fmt.Errorf("cluster %q not found in config", name)
+}
+
+// DefaultCluster returns the cluster to use when none is named: the
+// configured default, or the only cluster if there is exactly one
+func (c *Config) DefaultCluster() (Cluster, bool) {
+	if c.Default != "" {
+		cluster, err := c.Find(c.Default)
+		return cluster, err == nil
+	}
+	if len(c.Clusters) == 1 {
+		return c.Clusters[0], true
+	}
+	return Cluster{}, false
+}
+
+// Names returns cluster names in config order
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Clusters))
+	for _, cluster := range c.Clusters {
+		names = append(names, cluster.Name)
+	}
+	return names
+}
+
+// ClientOptions resolves secrets and converts the profile to client options.
+// Interactive allows prompting on the terminal for secrets with no source.
+func (c Cluster) ClientOptions(interactive bool) (client.Options, error) {
+	auth, err := client.AuthSource{
+		Mode:         c.Auth.Mode,
+		Username:     c.Auth.Username,
+		PasswordEnv:  c.Auth.PasswordEnv,
+		PasswordFile: expandHome(c.Auth.PasswordFile),
+		TokenEnv:     c.Auth.TokenEnv,
+		TokenFile:    expandHome(c.Auth.TokenFile),
+		APIKeyHeader: c.Auth.APIKeyHeader,
+	}.Resolve(interactive)
+	if err != nil {
+		return client.Options{}, err
+	}
+
+	return client.Options{
+		Endpoint: c.Endpoint,
+		Region:   c.Region,
+		Profile:  c.Profile,
+		Insecure: c.Insecure,
+		TLS: client.TLSOptions{
+			CACert:     expandHome(c.TLS.CACert),
+			ClientCert: expandHome(c.TLS.ClientCert),
+			ClientKey:  expandHome(c.TLS.ClientKey),
+		},
+		Auth: auth,
+	}, nil
+}
+
+// expandHome replaces a leading ~/ with the user's home directory
+func expandHome(path string) string {
+	if len(path) < 2 || path[:2] != "~/" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
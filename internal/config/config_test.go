@@ -0,0 +1,239 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vegasq/ostop/internal/client"
+)
+
+// writeConfig writes a config file into a temp dir and returns its path
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const sampleConfig = `
+default: staging
+refresh_interval: 15s
+snapshot_max_age: 36h
+clusters:
+  - name: local
+    endpoint: http://localhost:9200
+  - name: staging
+    endpoint: https://opensearch.staging.internal:9200
+    auth:
+      mode: basic
+      username: admin
+      password_env: OSTOP_TEST_STAGING_PASSWORD
+    tls:
+      ca_cert: /etc/ostop/root-ca.pem
+    refresh_interval: 30s
+    snapshot_max_age: 168h
+  - name: prod-aws
+    endpoint: https://search-prod.us-east-1.es.amazonaws.com
+    region: us-east-1
+    aws_profile: prod
+`
+
+// TestLoad tests parsing a config with several cluster profiles
+func TestLoad(t *testing.T) {
+	cfg, err := Load(writeConfig(t, sampleConfig), true)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(cfg.Clusters) != 3 {
+		t.Fatalf("Load() clusters = %d, want 3", len(cfg.Clusters))
+	}
+
+	names := cfg.Names()
+	want := []string{"local", "staging", "prod-aws"}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("Names()[%d] = %q, want %q", i, names[i], want[i])
+		}
+	}
+
+	staging, err := cfg.Find("staging")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if staging.RefreshInterval != 30*time.Second {
+		t.Errorf("RefreshInterval = %v, want 30s", staging.RefreshInterval)
+	}
+	if staging.SnapshotMaxAge != 168*time.Hour {
+		t.Errorf("SnapshotMaxAge = %v, want 168h", staging.SnapshotMaxAge)
+	}
+	if staging.Auth.Username != "admin" || staging.Auth.PasswordEnv != "OSTOP_TEST_STAGING_PASSWORD" {
+		t.Errorf("Auth = %+v, want admin with password env", staging.Auth)
+	}
+	if staging.TLS.CACert != "/etc/ostop/root-ca.pem" {
+		t.Errorf("TLS.CACert = %q", staging.TLS.CACert)
+	}
+
+	prod, _ := cfg.Find("prod-aws")
+	if prod.Region != "us-east-1" || prod.Profile != "prod" {
+		t.Errorf("prod-aws region/profile = %q/%q", prod.Region, prod.Profile)
+	}
+	if prod.RefreshInterval != 15*time.Second {
+		t.Errorf("prod-aws RefreshInterval = %v, want inherited 15s", prod.RefreshInterval)
+	}
+	if prod.SnapshotMaxAge != 36*time.Hour {
+		t.Errorf("prod-aws SnapshotMaxAge = %v, want inherited 36h", prod.SnapshotMaxAge)
+	}
+}
+
+// TestLoad_MissingFile tests the optional vs required config file behaviour
+func TestLoad_MissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.yaml")
+
+	cfg, err := Load(path, false)
+	if err != nil {
+		t.Fatalf("Load() optional missing file error = %v", err)
+	}
+	if len(cfg.Clusters) != 0 {
+		t.Errorf("Load() clusters = %d, want 0", len(cfg.Clusters))
+	}
+
+	if _, err := Load(path, true); err == nil {
+		t.Error("Load() required missing file should fail")
+	}
+}
+
+// TestLoad_Invalid tests validation of malformed configs
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		errorMsg string
+	}{
+		{"bad_yaml", "clusters: [", "failed to parse"},
+		{"missing_name", "clusters:\n  - endpoint: http://a:9200\n", "has no name"},
+		{"missing_endpoint", "clusters:\n  - name: a\n", "has no endpoint"},
+		{"duplicate_name", "clusters:\n  - name: a\n    endpoint: http://a\n  - name: a\n    endpoint: http://b\n", "duplicate cluster name"},
+		{"unknown_default", "default: b\nclusters:\n  - name: a\n    endpoint: http://a\n", "default cluster \"b\""},
+		{"bad_duration", "clusters:\n  - name: a\n    endpoint: http://a\n    refresh_interval: soon\n", "failed to parse"},
+		{"refresh_too_short", "clusters:\n  - name: a\n    endpoint: http://a\n    refresh_interval: 100ms\n", "below the minimum"},
+		{"global_refresh_too_short", "refresh_interval: -5s\nclusters:\n  - name: a\n    endpoint: http://a\n", "below the minimum"},
+		{"negative_snapshot_age", "clusters:\n  - name: a\n    endpoint: http://a\n    snapshot_max_age: -1h\n", "negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfig(t, tt.content), true)
+			if err == nil {
+				t.Fatalf("Load() expected error containing %q", tt.errorMsg)
+			}
+			if !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Load() error = %v, want error containing %q", err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+// TestDefaultCluster tests choosing a cluster when none is named
+func TestDefaultCluster(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    Config
+		want   string
+		wantOK bool
+	}{
+		{"explicit_default", Config{Default: "b", Clusters: []Cluster{{Name: "a"}, {Name: "b"}}}, "b", true},
+		{"single_cluster", Config{Clusters: []Cluster{{Name: "only"}}}, "only", true},
+		{"ambiguous", Config{Clusters: []Cluster{{Name: "a"}, {Name: "b"}}}, "", false},
+		{"empty", Config{}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.cfg.DefaultCluster()
+			if ok != tt.wantOK || got.Name != tt.want {
+				t.Errorf("DefaultCluster() = (%q, %v), want (%q, %v)", got.Name, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestFind_Unknown tests looking up an undefined cluster
+func TestFind_Unknown(t *testing.T) {
+	cfg := Config{Clusters: []Cluster{{Name: "a"}}}
+	if _, err := cfg.Find("b"); err == nil {
+		t.Error("Find() expected error for unknown cluster")
+	}
+}
+
+// TestClientOptions tests converting a profile into client options
+func TestClientOptions(t *testing.T) {
+	t.Setenv("OSTOP_TEST_STAGING_PASSWORD", "s3cret")
+
+	cluster := Cluster{
+		Name:     "staging",
+		Endpoint: "https://opensearch.staging.internal:9200",
+		Insecure: true,
+		Auth:     Auth{Mode: "basic", Username: "admin", PasswordEnv: "OSTOP_TEST_STAGING_PASSWORD"},
+		TLS:      TLS{CACert: "/etc/ca.pem", ClientCert: "/etc/admin.pem", ClientKey: "/etc/admin-key.pem"},
+	}
+
+	opts, err := cluster.ClientOptions(false)
+	if err != nil {
+		t.Fatalf("ClientOptions() error = %v", err)
+	}
+
+	if opts.Endpoint != cluster.Endpoint || !opts.Insecure {
+		t.Errorf("ClientOptions() endpoint/insecure = %q/%v", opts.Endpoint, opts.Insecure)
+	}
+	if opts.Auth.Mode != client.AuthBasic || opts.Auth.Password != "s3cret" {
+		t.Errorf("ClientOptions() auth = %+v", opts.Auth)
+	}
+	if opts.TLS.CACert != "/etc/ca.pem" || opts.TLS.ClientCert != "/etc/admin.pem" || opts.TLS.ClientKey != "/etc/admin-key.pem" {
+		t.Errorf("ClientOptions() tls = %+v", opts.TLS)
+	}
+}
+
+// TestClientOptions_NonInteractive tests that missing secrets fail instead of prompting
+func TestClientOptions_NonInteractive(t *testing.T) {
+	cluster := Cluster{
+		Name:     "staging",
+		Endpoint: "https://opensearch.staging.internal:9200",
+		Auth:     Auth{Mode: "basic", Username: "admin"},
+	}
+
+	if _, err := cluster.ClientOptions(false); err == nil {
+		t.Error("ClientOptions(false) should fail when no password source is configured")
+	}
+}
+
+// TestExpandHome tests ~/ expansion of file paths
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+
+	if got := expandHome("~/certs/ca.pem"); got != filepath.Join(home, "certs", "ca.pem") {
+		t.Errorf("expandHome() = %q", got)
+	}
+	if got := expandHome("/etc/ca.pem"); got != "/etc/ca.pem" {
+		t.Errorf("expandHome() should leave absolute paths alone, got %q", got)
+	}
+	if got := expandHome(""); got != "" {
+		t.Errorf("expandHome() should leave empty paths alone, got %q", got)
+	}
+}
+
+// TestDefaultPath tests XDG_CONFIG_HOME handling
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	if got := DefaultPath(); got != "/tmp/xdg/ostop/config.yaml" {
+		t.Errorf("DefaultPath() = %q, want /tmp/xdg/ostop/config.yaml", got)
+	}
+}
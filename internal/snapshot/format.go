@@ -0,0 +1,212 @@
+package snapshot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format for snapshot data
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatCSV   Format = "csv"
+	FormatTable Format = "table"
+)
+
+// ParseFormat parses a --format value
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatJSON, FormatYAML, FormatCSV, FormatTable:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown format %q (valid: json, yaml, csv, table)", s)
+	}
+}
+
+// Write prints data in the given format. color enables bold table headers
+// and should only be set when writing to a terminal.
+func Write(w io.Writer, data interface{}, format Format, color bool) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	case FormatYAML:
+		return writeYAML(w, data)
+	case FormatCSV:
+		return writeCSV(w, data)
+	case FormatTable:
+		return writeTable(w, data, color)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// writeYAML converts through JSON first so keys match the API field names
+func writeYAML(w io.Writer, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode data: %w", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return fmt.Errorf("failed to encode data: %w", err)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(generic); err != nil {
+		return fmt.Errorf("failed to encode data: %w", err)
+	}
+	return enc.Close()
+}
+
+// writeCSV prints one header row and one row per record
+func writeCSV(w io.Writer, data interface{}) error {
+	header, rows := tabulate(data)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// writeTable prints space-aligned columns
+func writeTable(w io.Writer, data interface{}, color bool) error {
+	header, rows := tabulate(data)
+
+	widths := make([]int, len(header))
+	for i, name := range header {
+		widths[i] = utf8.RuneCountInString(name)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var b strings.Builder
+	writeRow := func(cells []string, bold bool) {
+		for i, cell := range cells {
+			padded := cell
+			if i < len(cells)-1 {
+				padded += strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2)
+			}
+			if bold {
+				padded = "\x1b[1m" + padded + "\x1b[0m"
+			}
+			b.WriteString(padded)
+		}
+		b.WriteString("\n")
+	}
+
+	upper := make([]string, len(header))
+	for i, name := range header {
+		upper[i] = strings.ToUpper(name)
+	}
+	writeRow(upper, color)
+	for _, row := range rows {
+		writeRow(row, false)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// tabulate flattens a struct or a slice of structs into a header and rows.
+// Column names are the JSON field names; nested structs use dotted names.
+func tabulate(data interface{}) ([]string, [][]string) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	var records []reflect.Value
+	elemType := v.Type()
+	if v.Kind() == reflect.Slice {
+		elemType = elemType.Elem()
+		for i := 0; i < v.Len(); i++ {
+			records = append(records, v.Index(i))
+		}
+	} else {
+		records = append(records, v)
+	}
+
+	var header []string
+	var paths [][]int
+	collectColumns(elemType, "", nil, &header, &paths)
+
+	rows := make([][]string, 0, len(records))
+	for _, rec := range records {
+		row := make([]string, len(paths))
+		for i, path := range paths {
+			row[i] = formatCell(rec.FieldByIndex(path))
+		}
+		rows = append(rows, row)
+	}
+
+	return header, rows
+}
+
+// formatCell renders a field for a table cell, joining slices such as index
+// lists with commas instead of Go's bracketed form
+func formatCell(v reflect.Value) string {
+	if v.Kind() != reflect.Slice {
+		return fmt.Sprint(v.Interface())
+	}
+	items := make([]string, v.Len())
+	for i := range items {
+		items[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(items, ",")
+}
+
+// collectColumns walks struct fields depth-first, recording column names and
+// field index paths
+func collectColumns(t reflect.Type, prefix string, index []int, header *[]string, paths *[][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		path := append(append([]int(nil), index...), i)
+		if field.Type.Kind() == reflect.Struct {
+			collectColumns(field.Type, name, path, header, paths)
+			continue
+		}
+
+		*header = append(*header, name)
+		*paths = append(*paths, path)
+	}
+}
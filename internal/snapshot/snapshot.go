@@ -0,0 +1,179 @@
+// Package snapshot fetches cluster data once and prints it for scripts and
+// cron jobs, without starting the interactive UI.
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// fetcher loads the data for one view
+type fetcher func(ctx context.Context, src source.ClusterSource) (interface{}, error)
+
+// fetchers maps view names to the source call that produces them. Names match
+// the data sources polled by the UI. Views whose data is nested (ISM, ingest
+// pipelines, index templates, node memory and allocation explanations) are
+// left out since they don't fit the csv and table formats.
+var fetchers = map[string]fetcher{
+	"health": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.ClusterHealth(ctx)
+	},
+	"stats": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.ClusterStats(ctx)
+	},
+	"nodes": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.Nodes(ctx)
+	},
+	"indices": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.Indices(ctx)
+	},
+	"shards": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.Shards(ctx)
+	},
+	"allocation": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.Allocation(ctx)
+	},
+	"thread_pool": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.ThreadPool(ctx)
+	},
+	"tasks": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.Tasks(ctx)
+	},
+	"pending_tasks": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.PendingTasks(ctx)
+	},
+	"recovery": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.Recovery(ctx)
+	},
+	"segments": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.Segments(ctx)
+	},
+	"fielddata": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.Fielddata(ctx)
+	},
+	"plugins": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.Plugins(ctx)
+	},
+	"templates": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.Templates(ctx)
+	},
+	"node_activity": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		return src.NodeActivity(ctx)
+	},
+	"aliases": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		overview, err := src.Aliases(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return overview.Aliases, nil
+	},
+	"data_streams": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		overview, err := src.Aliases(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return overview.DataStreams, nil
+	},
+	"snapshots": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		overview, err := src.Snapshots(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return snapshotRows(overview), nil
+	},
+	"cluster_settings": func(ctx context.Context, src source.ClusterSource) (interface{}, error) {
+		settings, err := src.ClusterSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return settingRows(settings), nil
+	},
+}
+
+// snapshotRow is one snapshot with the repository it's in
+type snapshotRow struct {
+	Repository        string `json:"repository"`
+	Snapshot          string `json:"snapshot"`
+	State             string `json:"state"`
+	Indices           int    `json:"indices"`
+	StartTimeInMillis int64  `json:"start_time_in_millis"`
+	DurationInMillis  int64  `json:"duration_in_millis"`
+	FailedShards      int    `json:"failed_shards"`
+}
+
+// snapshotRows lists every repository's snapshots, newest first within a
+// repository
+func snapshotRows(overview *source.SnapshotOverview) []snapshotRow {
+	var rows []snapshotRow
+	for _, repo := range overview.Repositories {
+		for _, s := range repo.Snapshots {
+			rows = append(rows, snapshotRow{
+				Repository:        repo.Name,
+				Snapshot:          s.Snapshot,
+				State:             s.State,
+				Indices:           len(s.Indices),
+				StartTimeInMillis: s.StartTimeInMillis,
+				DurationInMillis:  s.DurationInMillis,
+				FailedShards:      s.Shards.Failed,
+			})
+		}
+	}
+	return rows
+}
+
+// settingRow is one cluster setting set by an operator
+type settingRow struct {
+	Scope   string `json:"scope"`
+	Setting string `json:"setting"`
+	Value   string `json:"value"`
+}
+
+// settingRows lists the persistent and then the transient settings, each
+// sorted by key. Defaults are left out.
+func settingRows(settings *source.ClusterSettings) []settingRow {
+	var rows []settingRow
+	for _, scope := range []struct {
+		name   string
+		values map[string]string
+	}{{"persistent", settings.Persistent}, {"transient", settings.Transient}} {
+		keys := make([]string, 0, len(scope.values))
+		for key := range scope.values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			rows = append(rows, settingRow{Scope: scope.name, Setting: key, Value: scope.values[key]})
+		}
+	}
+	return rows
+}
+
+// Views returns the names accepted by Fetch, sorted
+func Views() []string {
+	names := make([]string, 0, len(fetchers))
+	for name := range fetchers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckView returns an error if view isn't one of Views
+func CheckView(view string) error {
+	if _, ok := fetchers[view]; !ok {
+		return fmt.Errorf("unknown view %q (valid: %s)", view, strings.Join(Views(), ", "))
+	}
+	return nil
+}
+
+// Fetch loads the data for a single view
+func Fetch(ctx context.Context, src source.ClusterSource, view string) (interface{}, error) {
+	if err := CheckView(view); err != nil {
+		return nil, err
+	}
+	return fetchers[view](ctx, src)
+}
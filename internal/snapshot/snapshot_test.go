@@ -0,0 +1,287 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/vegasq/ostop/internal/source"
+	"gopkg.in/yaml.v3"
+)
+
+// stubSource serves canned data; methods it doesn't override panic, which
+// catches views fetching more than they should
+type stubSource struct {
+	source.ClusterSource
+	nodes     []source.NodeInfo
+	snapshots *source.SnapshotOverview
+	settings  *source.ClusterSettings
+	err       error
+}
+
+func (s *stubSource) Nodes(ctx context.Context) ([]source.NodeInfo, error) {
+	return s.nodes, s.err
+}
+
+func (s *stubSource) Snapshots(ctx context.Context) (*source.SnapshotOverview, error) {
+	return s.snapshots, s.err
+}
+
+func (s *stubSource) ClusterSettings(ctx context.Context) (*source.ClusterSettings, error) {
+	return s.settings, s.err
+}
+
+var testNodes = []source.NodeInfo{
+	{Name: "node-1", IP: "10.0.0.1", HeapPercent: "45", NodeRole: "dim", Master: "*"},
+	{Name: "node-2", IP: "10.0.0.2", HeapPercent: "72", NodeRole: "di", Master: "-"},
+}
+
+func TestFetch(t *testing.T) {
+	src := &stubSource{nodes: testNodes}
+
+	data, err := Fetch(t.Context(), src, "nodes")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if nodes, ok := data.([]source.NodeInfo); !ok || len(nodes) != 2 {
+		t.Errorf("Fetch() = %#v, want the two nodes", data)
+	}
+
+	if _, err := Fetch(t.Context(), src, "bogus"); err == nil || !strings.Contains(err.Error(), "nodes") {
+		t.Errorf("unknown view error = %v, want one listing valid views", err)
+	}
+
+	src.err = fmt.Errorf("connection refused")
+	if _, err := Fetch(t.Context(), src, "nodes"); err == nil {
+		t.Error("Fetch() should return the source error")
+	}
+}
+
+func TestFetch_FlattenedViews(t *testing.T) {
+	src := &stubSource{
+		snapshots: &source.SnapshotOverview{Repositories: []source.SnapshotRepository{
+			{Name: "nightly", Snapshots: []source.SnapshotInfo{{Snapshot: "snap-2", State: "SUCCESS", Indices: []string{"a", "b"}}}},
+		}},
+		settings: &source.ClusterSettings{
+			Persistent: map[string]string{"b.setting": "2", "a.setting": "1"},
+			Transient:  map[string]string{"c.setting": "3"},
+			Defaults:   map[string]string{"d.setting": "4"},
+		},
+	}
+
+	var buf bytes.Buffer
+	data, err := Fetch(t.Context(), src, "snapshots")
+	if err != nil {
+		t.Fatalf("Fetch(snapshots) error = %v", err)
+	}
+	if err := Write(&buf, data, FormatCSV, false); err != nil {
+		t.Fatal(err)
+	}
+	if want := "repository,snapshot,state,indices,start_time_in_millis,duration_in_millis,failed_shards\nnightly,snap-2,SUCCESS,2,0,0,0\n"; buf.String() != want {
+		t.Errorf("snapshots csv = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	data, err = Fetch(t.Context(), src, "cluster_settings")
+	if err != nil {
+		t.Fatalf("Fetch(cluster_settings) error = %v", err)
+	}
+	if err := Write(&buf, data, FormatCSV, false); err != nil {
+		t.Fatal(err)
+	}
+	want := "scope,setting,value\npersistent,a.setting,1\npersistent,b.setting,2\ntransient,c.setting,3\n"
+	if buf.String() != want {
+		t.Errorf("cluster_settings csv = %q, want %q without defaults", buf.String(), want)
+	}
+}
+
+func TestViews(t *testing.T) {
+	views := Views()
+	for _, want := range []string{"health", "nodes", "indices", "shards", "allocation", "thread_pool", "node_activity", "aliases", "data_streams", "snapshots", "cluster_settings"} {
+		if CheckView(want) != nil {
+			t.Errorf("view %q should be supported", want)
+		}
+	}
+	for i := 1; i < len(views); i++ {
+		if views[i-1] > views[i] {
+			t.Errorf("Views() not sorted: %v", views)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Format
+		wantErr bool
+	}{
+		{"json", FormatJSON, false},
+		{"YAML", FormatYAML, false},
+		{"csv", FormatCSV, false},
+		{"table", FormatTable, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseFormat(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrite_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testNodes, FormatJSON, false); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var got []source.NodeInfo
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(got) != 2 || got[1].HeapPercent != "72" {
+		t.Errorf("round-tripped nodes = %#v", got)
+	}
+}
+
+func TestWrite_YAML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testNodes, FormatYAML, false); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var got []map[string]string
+	if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid YAML: %v", err)
+	}
+	if len(got) != 2 || got[0]["heap.percent"] != "45" {
+		t.Errorf("YAML should use the API field names, got %#v", got)
+	}
+}
+
+func TestWrite_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testNodes, FormatCSV, false); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want header + 2 rows", len(records))
+	}
+	if records[0][0] != "ip" || records[0][1] != "heap.percent" {
+		t.Errorf("header = %v", records[0])
+	}
+	if records[2][0] != "10.0.0.2" || records[2][1] != "72" {
+		t.Errorf("row = %v", records[2])
+	}
+}
+
+func TestWrite_CSVNestedStruct(t *testing.T) {
+	stats := &source.ClusterStats{ClusterName: "prod"}
+	stats.Indices.Docs.Count = 42
+
+	var buf bytes.Buffer
+	if err := Write(&buf, stats, FormatCSV, false); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("a single struct should be one row, got %d records", len(records))
+	}
+	col := -1
+	for i, name := range records[0] {
+		if name == "indices.docs.count" {
+			col = i
+		}
+	}
+	if col < 0 || records[1][col] != "42" {
+		t.Errorf("nested fields should be flattened with dotted names, got %v / %v", records[0], records[1])
+	}
+}
+
+func TestWrite_CSVSliceField(t *testing.T) {
+	aliases := []source.AliasGroup{
+		{Name: "logs", WriteIndex: "logs-2", Indices: []string{"logs-1", "logs-2"}},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, aliases, FormatCSV, false); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	want := []string{"name", "write_index", "indices", "filtered"}
+	if strings.Join(records[0], " ") != strings.Join(want, " ") {
+		t.Errorf("header = %v, want %v", records[0], want)
+	}
+	if records[1][2] != "logs-1,logs-2" {
+		t.Errorf("indices = %q, want slice joined with commas", records[1][2])
+	}
+}
+
+func TestWrite_Table(t *testing.T) {
+	tests := []struct {
+		name     string
+		color    bool
+		wantANSI bool
+	}{
+		{"plain", false, false},
+		{"color", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, testNodes, FormatTable, tt.color); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			out := buf.String()
+
+			if got := strings.Contains(out, "\x1b["); got != tt.wantANSI {
+				t.Errorf("ANSI codes present = %v, want %v:\n%s", got, tt.wantANSI, out)
+			}
+			if !strings.Contains(out, "HEAP.PERCENT") || !strings.Contains(out, "node-2") {
+				t.Errorf("table missing header or rows:\n%s", out)
+			}
+
+			if !tt.color {
+				// Columns line up: "node-1" and "node-2" start at the same offset
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				if strings.Index(lines[1], "node-1") != strings.Index(lines[2], "node-2") {
+					t.Errorf("columns not aligned:\n%s", out)
+				}
+			}
+		})
+	}
+}
+
+func TestWrite_TableEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, []source.NodeInfo{}, FormatTable, false); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 1 {
+		t.Errorf("empty data should print only the header, got %q", buf.String())
+	}
+}
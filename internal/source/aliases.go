@@ -0,0 +1,191 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// AliasOverview is every alias and data stream with the indices behind them
+type AliasOverview struct {
+	Aliases     []AliasGroup
+	DataStreams []DataStream
+}
+
+// AliasGroup is an alias and the indices it points at
+type AliasGroup struct {
+	Name       string   `json:"name"`
+	WriteIndex string   `json:"write_index"` // Empty when writes to the alias aren't routed to one index
+	Indices    []string `json:"indices"`
+	Filtered   bool     `json:"filtered"`
+}
+
+// DataStream is a data stream with its backing indices and size
+type DataStream struct {
+	Name           string   `json:"name"`
+	Status         string   `json:"status"` // Health of the backing indices
+	Template       string   `json:"template"`
+	TimestampField string   `json:"timestamp_field"`
+	Generation     int      `json:"generation"`
+	Indices        []string `json:"indices"` // Backing indices, oldest first
+	StoreSizeBytes int64    `json:"store_size_bytes"`
+}
+
+// WriteIndex returns the backing index new documents go to
+func (d DataStream) WriteIndex() string {
+	if len(d.Indices) == 0 {
+		return ""
+	}
+	return d.Indices[len(d.Indices)-1]
+}
+
+// catAlias is a row of the CAT aliases API
+type catAlias struct {
+	Alias        string `json:"alias"`
+	Index        string `json:"index"`
+	Filter       string `json:"filter"`
+	IsWriteIndex string `json:"is_write_index"`
+}
+
+// Aliases lists the cluster's aliases and data streams
+func (o *OpenSearch) Aliases(ctx context.Context) (*AliasOverview, error) {
+	aliases, err := o.catAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	streams, err := o.dataStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AliasOverview{Aliases: aliases, DataStreams: streams}, nil
+}
+
+// catAliases calls the CAT aliases API and groups its rows by alias
+func (o *OpenSearch) catAliases(ctx context.Context) ([]AliasGroup, error) {
+	res, err := o.client.Cat.Aliases(
+		o.client.Cat.Aliases.WithContext(ctx),
+		o.client.Cat.Aliases.WithFormat("json"),
+		o.client.Cat.Aliases.WithH("alias", "index", "filter", "is_write_index"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("aliases request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("aliases API error: %s", res.Status())
+	}
+
+	var rows []catAlias
+	if err := json.NewDecoder(res.Body).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("failed to parse aliases: %w", err)
+	}
+
+	byName := make(map[string]*AliasGroup)
+	for _, row := range rows {
+		group, ok := byName[row.Alias]
+		if !ok {
+			group = &AliasGroup{Name: row.Alias}
+			byName[row.Alias] = group
+		}
+		group.Indices = append(group.Indices, row.Index)
+		if row.Filter != "" && row.Filter != "-" {
+			group.Filtered = true
+		}
+		if row.IsWriteIndex == "true" {
+			group.WriteIndex = row.Index
+		}
+	}
+
+	groups := make([]AliasGroup, 0, len(byName))
+	for _, name := range sortedNames(byName) {
+		group := *byName[name]
+		sort.Strings(group.Indices)
+		// An alias over a single index writes to it without is_write_index
+		if group.WriteIndex == "" && len(group.Indices) == 1 {
+			group.WriteIndex = group.Indices[0]
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// dataStreams lists the data streams with their sizes. The typed client
+// can't build the stats path for all data streams, so both calls go
+// through get.
+func (o *OpenSearch) dataStreams(ctx context.Context) ([]DataStream, error) {
+	var response struct {
+		DataStreams []struct {
+			Name           string `json:"name"`
+			Status         string `json:"status"`
+			Template       string `json:"template"`
+			Generation     int    `json:"generation"`
+			TimestampField struct {
+				Name string `json:"name"`
+			} `json:"timestamp_field"`
+			Indices []struct {
+				IndexName string `json:"index_name"`
+			} `json:"indices"`
+		} `json:"data_streams"`
+	}
+	if err := o.getJSON(ctx, "/_data_stream", "data streams", &response); err != nil {
+		return nil, err
+	}
+
+	var stats struct {
+		DataStreams []struct {
+			DataStream     string `json:"data_stream"`
+			StoreSizeBytes int64  `json:"store_size_bytes"`
+		} `json:"data_streams"`
+	}
+	if len(response.DataStreams) > 0 {
+		if err := o.getJSON(ctx, "/_data_stream/_stats", "data stream stats", &stats); err != nil {
+			return nil, err
+		}
+	}
+	sizes := make(map[string]int64, len(stats.DataStreams))
+	for _, s := range stats.DataStreams {
+		sizes[s.DataStream] = s.StoreSizeBytes
+	}
+
+	streams := make([]DataStream, 0, len(response.DataStreams))
+	for _, d := range response.DataStreams {
+		stream := DataStream{
+			Name:           d.Name,
+			Status:         d.Status,
+			Template:       d.Template,
+			TimestampField: d.TimestampField.Name,
+			Generation:     d.Generation,
+			StoreSizeBytes: sizes[d.Name],
+		}
+		for _, index := range d.Indices {
+			stream.Indices = append(stream.Indices, index.IndexName)
+		}
+		streams = append(streams, stream)
+	}
+	sort.Slice(streams, func(i, j int) bool { return streams[i].Name < streams[j].Name })
+
+	return streams, nil
+}
+
+// getJSON performs a GET through get and decodes the response into v; what
+// names the API in errors
+func (o *OpenSearch) getJSON(ctx context.Context, path, what string, v interface{}) error {
+	res, err := o.get(ctx, path)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", what, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s API error: %s", what, res.Status)
+	}
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// explainWorkers bounds the explain requests made at once by
+// ExplainUnassigned
+const explainWorkers = 4
+
+// AllocationExplanation is the cluster allocation explain API's answer for
+// one shard copy
+type AllocationExplanation struct {
+	Index          string `json:"index"`
+	Shard          int    `json:"shard"`
+	Primary        bool   `json:"primary"`
+	CurrentState   string `json:"current_state"`
+	UnassignedInfo *struct {
+		Reason                   string `json:"reason"`
+		At                       string `json:"at"`
+		FailedAllocationAttempts int    `json:"failed_allocation_attempts"`
+		Details                  string `json:"details"`
+		LastAllocationStatus     string `json:"last_allocation_status"`
+	} `json:"unassigned_info"`
+	CurrentNode *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"current_node"`
+
+	// Set for unassigned shards
+	CanAllocate         string `json:"can_allocate"`
+	AllocateExplanation string `json:"allocate_explanation"`
+
+	// Set for assigned shards, e.g. one stuck initializing
+	CanRemainOnCurrentNode string              `json:"can_remain_on_current_node"`
+	CanRemainDecisions     []AllocationDecider `json:"can_remain_decisions"`
+	CanRebalanceCluster    string              `json:"can_rebalance_cluster"`
+	Explanation            string              `json:"explanation"`
+
+	NodeDecisions []NodeAllocationDecision `json:"node_allocation_decisions"`
+}
+
+// NodeAllocationDecision explains whether a shard can go to one node
+type NodeAllocationDecision struct {
+	NodeID        string              `json:"node_id"`
+	NodeName      string              `json:"node_name"`
+	NodeDecision  string              `json:"node_decision"` // yes, no, throttled, worse_balance
+	WeightRanking int                 `json:"weight_ranking"`
+	Deciders      []AllocationDecider `json:"deciders"`
+}
+
+// AllocationDecider is one allocation decider's verdict, such as
+// disk_threshold or same_shard
+type AllocationDecider struct {
+	Decider     string `json:"decider"`
+	Decision    string `json:"decision"` // YES, NO, THROTTLE
+	Explanation string `json:"explanation"`
+}
+
+// UnassignedReport explains a sample of the cluster's unassigned shards
+type UnassignedReport struct {
+	Total        int // Unassigned shard copies in the cluster
+	Explanations []AllocationExplanation
+}
+
+// AllocationExplain calls the cluster allocation explain API for one shard
+// copy
+func (o *OpenSearch) AllocationExplain(ctx context.Context, index string, shard int, primary bool) (*AllocationExplanation, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"index":   index,
+		"shard":   shard,
+		"primary": primary,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := o.client.Cluster.AllocationExplain(
+		o.client.Cluster.AllocationExplain.WithBody(bytes.NewReader(body)),
+		o.client.Cluster.AllocationExplain.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("allocation explain request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("allocation explain API error: %s", res.Status())
+	}
+
+	var explanation AllocationExplanation
+	if err := json.NewDecoder(res.Body).Decode(&explanation); err != nil {
+		return nil, fmt.Errorf("failed to parse allocation explain: %w", err)
+	}
+
+	return &explanation, nil
+}
+
+// ExplainUnassigned explains up to limit of the cluster's unassigned shard
+// copies
+func (o *OpenSearch) ExplainUnassigned(ctx context.Context, limit int) (*UnassignedReport, error) {
+	unassigned, err := o.unassignedShards(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UnassignedReport{Total: len(unassigned)}
+	if len(unassigned) > limit {
+		unassigned = unassigned[:limit]
+	}
+
+	explanations := make([]*AllocationExplanation, len(unassigned))
+	errs := make([]error, len(unassigned))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, explainWorkers)
+	for i, shard := range unassigned {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			explanations[i], errs[i] = o.AllocationExplain(ctx, shard.Index, shard.Shard, shard.Primary)
+		}()
+	}
+	wg.Wait()
+
+	// A shard can be assigned between listing and explaining it, so only
+	// fail when nothing could be explained
+	var firstErr error
+	for i, explanation := range explanations {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		report.Explanations = append(report.Explanations, *explanation)
+	}
+	if len(report.Explanations) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+
+	return report, nil
+}
+
+// unassignedShard is an entry of the cluster state's unassigned shard list
+type unassignedShard struct {
+	Index   string `json:"index"`
+	Shard   int    `json:"shard"`
+	Primary bool   `json:"primary"`
+}
+
+// unassignedShards lists unassigned shard copies from the cluster state's
+// routing nodes, which is much smaller than a full _cat/shards listing
+func (o *OpenSearch) unassignedShards(ctx context.Context) ([]unassignedShard, error) {
+	res, err := o.client.Cluster.State(
+		o.client.Cluster.State.WithMetric("routing_nodes"),
+		o.client.Cluster.State.WithFilterPath("routing_nodes.unassigned"),
+		o.client.Cluster.State.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("routing nodes request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("routing nodes API error: %s", res.Status())
+	}
+
+	var response struct {
+		RoutingNodes struct {
+			Unassigned []unassignedShard `json:"unassigned"`
+		} `json:"routing_nodes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse routing nodes: %w", err)
+	}
+
+	return response.RoutingNodes.Unassigned, nil
+}
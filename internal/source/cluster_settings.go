@@ -0,0 +1,47 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ClusterSettings are the cluster's flat settings by where their values
+// come from
+type ClusterSettings struct {
+	Persistent map[string]string
+	Transient  map[string]string // Lost on a full cluster restart
+	Defaults   map[string]string
+}
+
+// ClusterSettings calls the cluster get settings API with defaults included
+func (o *OpenSearch) ClusterSettings(ctx context.Context) (*ClusterSettings, error) {
+	res, err := o.client.Cluster.GetSettings(
+		o.client.Cluster.GetSettings.WithFlatSettings(true),
+		o.client.Cluster.GetSettings.WithIncludeDefaults(true),
+		o.client.Cluster.GetSettings.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cluster settings request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("cluster settings API error: %s", res.Status())
+	}
+
+	var response struct {
+		Persistent map[string]interface{} `json:"persistent"`
+		Transient  map[string]interface{} `json:"transient"`
+		Defaults   map[string]interface{} `json:"defaults"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster settings: %w", err)
+	}
+
+	return &ClusterSettings{
+		Persistent: flattenValues(response.Persistent),
+		Transient:  flattenValues(response.Transient),
+		Defaults:   flattenValues(response.Defaults),
+	}, nil
+}
@@ -0,0 +1,153 @@
+package source
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HotThreadsOptions are the parameters of a hot threads sample
+type HotThreadsOptions struct {
+	Node     string        // Node ID or name; empty samples every node
+	Threads  int           // Busiest threads to report per node
+	Interval time.Duration // How long the node samples for
+	Type     string        // cpu, wait or block
+}
+
+// NodeHotThreads is one node's section of the hot threads report
+type NodeHotThreads struct {
+	Node    string
+	NodeID  string
+	Summary string // e.g. "Hot threads at ..., interval=500ms, busiestThreads=3"
+	Threads []HotThread
+}
+
+// HotThread is one of a node's busiest threads
+type HotThread struct {
+	Name    string  // e.g. "opensearch[node-1][search][T#3]"
+	Percent float64 // Share of the interval spent in Type
+	Usage   string  // e.g. "76.4ms out of 500ms"
+	Type    string  // cpu, wait or block
+	Stacks  []HotThreadStack
+}
+
+// HotThreadStack is a stack trace shared by some of a thread's snapshots
+type HotThreadStack struct {
+	Snapshots string // e.g. "8/10 snapshots sharing following 20 elements"
+	Frames    []string
+}
+
+// hotThreadLine matches a thread's headline, e.g.
+// "15.3% (76.4ms out of 500ms) cpu usage by thread 'opensearch[node-1][search][T#3]'"
+var hotThreadLine = regexp.MustCompile(`^([\d.]+)% \((.+)\) (\w+) usage by thread '(.*)'$`)
+
+// nodeHeaderField matches the {...} fields of a node header line
+var nodeHeaderField = regexp.MustCompile(`\{([^}]*)\}`)
+
+// HotThreads calls the nodes hot threads API and parses its text report
+func (o *OpenSearch) HotThreads(ctx context.Context, opts HotThreadsOptions) ([]NodeHotThreads, error) {
+	path := "/_nodes/hot_threads"
+	if opts.Node != "" {
+		path = "/_nodes/" + url.PathEscape(opts.Node) + "/hot_threads"
+	}
+
+	query := url.Values{}
+	if opts.Threads > 0 {
+		query.Set("threads", strconv.Itoa(opts.Threads))
+	}
+	if opts.Interval > 0 {
+		query.Set("interval", fmt.Sprintf("%dms", opts.Interval.Milliseconds()))
+	}
+	if opts.Type != "" {
+		query.Set("type", opts.Type)
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	res, err := o.get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("hot threads request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("hot threads API error: %s", res.Status)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hot threads: %w", err)
+	}
+	return parseHotThreads(string(body)), nil
+}
+
+// parseHotThreads splits a hot threads text report into nodes, threads and
+// their stack traces
+func parseHotThreads(text string) []NodeHotThreads {
+	var nodes []NodeHotThreads
+	var node *NodeHotThreads
+	var thread *HotThread
+	var stack *HotThreadStack
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			continue
+
+		case strings.HasPrefix(line, ":::"):
+			nodes = append(nodes, NodeHotThreads{})
+			node = &nodes[len(nodes)-1]
+			thread, stack = nil, nil
+			fields := nodeHeaderField.FindAllStringSubmatch(line, 2)
+			if len(fields) > 0 {
+				node.Node = fields[0][1]
+			}
+			if len(fields) > 1 {
+				node.NodeID = fields[1][1]
+			}
+
+		case node == nil:
+			// Nothing before the first node header is meaningful
+			continue
+
+		case strings.HasPrefix(line, "Hot threads at"):
+			node.Summary = strings.TrimSuffix(line, ":")
+
+		case hotThreadLine.MatchString(line):
+			match := hotThreadLine.FindStringSubmatch(line)
+			percent, _ := strconv.ParseFloat(match[1], 64)
+			node.Threads = append(node.Threads, HotThread{
+				Name:    match[4],
+				Percent: percent,
+				Usage:   match[2],
+				Type:    match[3],
+			})
+			thread = &node.Threads[len(node.Threads)-1]
+			stack = nil
+
+		case thread == nil:
+			continue
+
+		case strings.Contains(line, "snapshots sharing following") || line == "unique snapshot":
+			thread.Stacks = append(thread.Stacks, HotThreadStack{Snapshots: line})
+			stack = &thread.Stacks[len(thread.Stacks)-1]
+
+		case stack != nil:
+			stack.Frames = append(stack.Frames, line)
+		}
+	}
+
+	return nodes
+}
@@ -0,0 +1,70 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// IndexActivity holds one index's cumulative indexing and search counters,
+// used to derive per-index rates and latencies
+type IndexActivity struct {
+	Index             string
+	IndexTotal        int64 // Documents indexed into primary shards
+	IndexTimeInMillis int64
+	QueryTotal        int64 // Queries run on primary shards, the basis of the cluster search rate
+	QueryTimeInMillis int64
+}
+
+// IndexActivity calls the indices stats API for the indexing and search
+// counters of the named indices (every index when none are named), sorted
+// by index name
+func (o *OpenSearch) IndexActivity(ctx context.Context, names ...string) ([]IndexActivity, error) {
+	res, err := o.client.Indices.Stats(
+		o.client.Indices.Stats.WithIndex(names...),
+		o.client.Indices.Stats.WithMetric("indexing", "search"),
+		o.client.Indices.Stats.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("index activity request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("index activity API error: %s", res.Status())
+	}
+
+	var response struct {
+		Indices map[string]struct {
+			Primaries struct {
+				Indexing struct {
+					IndexTotal        int64 `json:"index_total"`
+					IndexTimeInMillis int64 `json:"index_time_in_millis"`
+				} `json:"indexing"`
+				Search struct {
+					QueryTotal        int64 `json:"query_total"`
+					QueryTimeInMillis int64 `json:"query_time_in_millis"`
+				} `json:"search"`
+			} `json:"primaries"`
+		} `json:"indices"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse index activity: %w", err)
+	}
+
+	indices := make([]IndexActivity, 0, len(response.Indices))
+	for name, stats := range response.Indices {
+		indices = append(indices, IndexActivity{
+			Index:             name,
+			IndexTotal:        stats.Primaries.Indexing.IndexTotal,
+			IndexTimeInMillis: stats.Primaries.Indexing.IndexTimeInMillis,
+			QueryTotal:        stats.Primaries.Search.QueryTotal,
+			QueryTimeInMillis: stats.Primaries.Search.QueryTimeInMillis,
+		})
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i].Index < indices[j].Index
+	})
+	return indices, nil
+}
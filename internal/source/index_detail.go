@@ -0,0 +1,341 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ErrISMUnavailable is returned when the cluster doesn't have the Index
+// State Management plugin installed
+var ErrISMUnavailable = errors.New("index state management plugin not available")
+
+// IndexSettings holds an index's settings in flat form, e.g.
+// "index.number_of_shards". Defaults is only filled when requested.
+type IndexSettings struct {
+	Settings map[string]string
+	Defaults map[string]string
+}
+
+// IndexStats is one index's entry from the indices stats API
+type IndexStats struct {
+	Primaries IndexStatsTotals `json:"primaries"`
+	Total     IndexStatsTotals `json:"total"`
+}
+
+// IndexStatsTotals are the counters summed over an index's primaries or all
+// of its shard copies
+type IndexStatsTotals struct {
+	Docs struct {
+		Count   int64 `json:"count"`
+		Deleted int64 `json:"deleted"`
+	} `json:"docs"`
+	Store struct {
+		SizeInBytes int64 `json:"size_in_bytes"`
+	} `json:"store"`
+	Indexing struct {
+		IndexTotal         int64 `json:"index_total"`
+		IndexTimeInMillis  int64 `json:"index_time_in_millis"`
+		IndexCurrent       int64 `json:"index_current"`
+		IndexFailed        int64 `json:"index_failed"`
+		DeleteTotal        int64 `json:"delete_total"`
+		ThrottleTimeMillis int64 `json:"throttle_time_in_millis"`
+	} `json:"indexing"`
+	Search struct {
+		QueryTotal        int64 `json:"query_total"`
+		QueryTimeInMillis int64 `json:"query_time_in_millis"`
+		QueryCurrent      int64 `json:"query_current"`
+		FetchTotal        int64 `json:"fetch_total"`
+		FetchTimeInMillis int64 `json:"fetch_time_in_millis"`
+		ScrollTotal       int64 `json:"scroll_total"`
+		ScrollCurrent     int64 `json:"scroll_current"`
+	} `json:"search"`
+	Merges struct {
+		Current                int64 `json:"current"`
+		Total                  int64 `json:"total"`
+		TotalTimeInMillis      int64 `json:"total_time_in_millis"`
+		TotalSizeInBytes       int64 `json:"total_size_in_bytes"`
+		TotalThrottledInMillis int64 `json:"total_throttled_time_in_millis"`
+	} `json:"merges"`
+	Refresh struct {
+		Total             int64 `json:"total"`
+		TotalTimeInMillis int64 `json:"total_time_in_millis"`
+	} `json:"refresh"`
+	Flush struct {
+		Total             int64 `json:"total"`
+		TotalTimeInMillis int64 `json:"total_time_in_millis"`
+	} `json:"flush"`
+	QueryCache   CacheStats `json:"query_cache"`
+	RequestCache CacheStats `json:"request_cache"`
+	Fielddata    struct {
+		MemorySizeInBytes int64 `json:"memory_size_in_bytes"`
+		Evictions         int64 `json:"evictions"`
+	} `json:"fielddata"`
+	Segments struct {
+		Count                     int64 `json:"count"`
+		MemoryInBytes             int64 `json:"memory_in_bytes"`
+		TermsMemoryInBytes        int64 `json:"terms_memory_in_bytes"`
+		StoredFieldsMemoryInBytes int64 `json:"stored_fields_memory_in_bytes"`
+		DocValuesMemoryInBytes    int64 `json:"doc_values_memory_in_bytes"`
+		PointsMemoryInBytes       int64 `json:"points_memory_in_bytes"`
+		NormsMemoryInBytes        int64 `json:"norms_memory_in_bytes"`
+		IndexWriterMemoryInBytes  int64 `json:"index_writer_memory_in_bytes"`
+		VersionMapMemoryInBytes   int64 `json:"version_map_memory_in_bytes"`
+		FixedBitSetMemoryInBytes  int64 `json:"fixed_bit_set_memory_in_bytes"`
+	} `json:"segments"`
+}
+
+// CacheStats covers the query and request caches, which report the same
+// counters
+type CacheStats struct {
+	MemorySizeInBytes int64 `json:"memory_size_in_bytes"`
+	Evictions         int64 `json:"evictions"`
+	HitCount          int64 `json:"hit_count"`
+	MissCount         int64 `json:"miss_count"`
+}
+
+// IndexAlias is an alias pointing at an index
+type IndexAlias struct {
+	Name          string
+	Filter        bool
+	IndexRouting  string
+	SearchRouting string
+	IsWriteIndex  bool
+}
+
+// ISMExplanation is one index's entry from the ISM explain API. PolicyID is
+// empty for indices that aren't managed by a policy.
+type ISMExplanation struct {
+	Index    string `json:"index"`
+	PolicyID string `json:"policy_id"`
+	Enabled  *bool  `json:"enabled"`
+	State    struct {
+		Name      string `json:"name"`
+		StartTime int64  `json:"start_time"`
+	} `json:"state"`
+	Action struct {
+		Name            string `json:"name"`
+		StartTime       int64  `json:"start_time"`
+		Failed          bool   `json:"failed"`
+		ConsumedRetries int64  `json:"consumed_retries"`
+	} `json:"action"`
+	Step struct {
+		Name       string `json:"name"`
+		StepStatus string `json:"step_status"`
+	} `json:"step"`
+	RetryInfo struct {
+		Failed          bool  `json:"failed"`
+		ConsumedRetries int64 `json:"consumed_retries"`
+	} `json:"retry_info"`
+	Info struct {
+		Message string `json:"message"`
+		Cause   string `json:"cause"`
+	} `json:"info"`
+}
+
+// Failed reports whether ISM gave up on the index's current action
+func (e ISMExplanation) Failed() bool {
+	return e.Action.Failed || e.RetryInfo.Failed
+}
+
+// IndexSettings calls the get settings API for a single index
+func (o *OpenSearch) IndexSettings(ctx context.Context, index string, includeDefaults bool) (*IndexSettings, error) {
+	res, err := o.client.Indices.GetSettings(
+		o.client.Indices.GetSettings.WithIndex(index),
+		o.client.Indices.GetSettings.WithFlatSettings(true),
+		o.client.Indices.GetSettings.WithIncludeDefaults(includeDefaults),
+		o.client.Indices.GetSettings.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("settings request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("settings API error: %s", res.Status())
+	}
+
+	var response map[string]struct {
+		Settings map[string]interface{} `json:"settings"`
+		Defaults map[string]interface{} `json:"defaults"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	entry, ok := response[index]
+	if !ok {
+		return nil, fmt.Errorf("index %q not found in settings response", index)
+	}
+
+	settings := &IndexSettings{Settings: flattenValues(entry.Settings)}
+	if includeDefaults {
+		settings.Defaults = flattenValues(entry.Defaults)
+	}
+	return settings, nil
+}
+
+// flattenValues renders flat setting values as strings; list settings are
+// comma separated
+func flattenValues(values map[string]interface{}) map[string]string {
+	flat := make(map[string]string, len(values))
+	for key, value := range values {
+		switch v := value.(type) {
+		case string:
+			flat[key] = v
+		case []interface{}:
+			parts := make([]string, 0, len(v))
+			for _, item := range v {
+				parts = append(parts, fmt.Sprint(item))
+			}
+			flat[key] = strings.Join(parts, ",")
+		default:
+			flat[key] = fmt.Sprint(v)
+		}
+	}
+	return flat
+}
+
+// IndexStats calls the indices stats API for a single index
+func (o *OpenSearch) IndexStats(ctx context.Context, index string) (*IndexStats, error) {
+	res, err := o.client.Indices.Stats(
+		o.client.Indices.Stats.WithIndex(index),
+		o.client.Indices.Stats.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("index stats request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("index stats API error: %s", res.Status())
+	}
+
+	var response struct {
+		Indices map[string]IndexStats `json:"indices"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse index stats: %w", err)
+	}
+
+	stats, ok := response.Indices[index]
+	if !ok {
+		return nil, fmt.Errorf("index %q not found in stats response", index)
+	}
+	return &stats, nil
+}
+
+// IndexAliases calls the get alias API for a single index
+func (o *OpenSearch) IndexAliases(ctx context.Context, index string) ([]IndexAlias, error) {
+	res, err := o.client.Indices.GetAlias(
+		o.client.Indices.GetAlias.WithIndex(index),
+		o.client.Indices.GetAlias.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("aliases request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("aliases API error: %s", res.Status())
+	}
+
+	var response map[string]struct {
+		Aliases map[string]struct {
+			Filter        json.RawMessage `json:"filter"`
+			IndexRouting  string          `json:"index_routing"`
+			SearchRouting string          `json:"search_routing"`
+			IsWriteIndex  bool            `json:"is_write_index"`
+		} `json:"aliases"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse aliases: %w", err)
+	}
+
+	aliases := make([]IndexAlias, 0, len(response[index].Aliases))
+	for name, alias := range response[index].Aliases {
+		aliases = append(aliases, IndexAlias{
+			Name:          name,
+			Filter:        len(alias.Filter) > 0,
+			IndexRouting:  alias.IndexRouting,
+			SearchRouting: alias.SearchRouting,
+			IsWriteIndex:  alias.IsWriteIndex,
+		})
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+
+	return aliases, nil
+}
+
+// ISMExplain calls the ISM explain API, falling back to the legacy Open
+// Distro path. An empty index explains every managed index.
+func (o *OpenSearch) ISMExplain(ctx context.Context, index string) ([]ISMExplanation, error) {
+	var lastErr error
+	for _, prefix := range []string{"/_plugins/_ism/explain", "/_opendistro/_ism/explain"} {
+		path := fmt.Sprintf("%s?size=%d", prefix, ismPageSize)
+		if index != "" {
+			path = prefix + "/" + index
+		}
+
+		explanations, err := o.ismExplain(ctx, path)
+		if !errors.Is(err, ErrISMUnavailable) {
+			return explanations, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// ismExplain decodes one ISM explain response, keyed by index name
+func (o *OpenSearch) ismExplain(ctx context.Context, path string) ([]ISMExplanation, error) {
+	res, err := o.get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("ISM explain request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(res.Body)
+		if res.StatusCode == http.StatusNotFound || strings.Contains(string(body), "no handler found") {
+			return nil, ErrISMUnavailable
+		}
+		return nil, fmt.Errorf("ISM explain API error: %s", res.Status)
+	}
+
+	var response map[string]json.RawMessage
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse ISM explain: %w", err)
+	}
+
+	explanations := make([]ISMExplanation, 0, len(response))
+	for name, raw := range response {
+		// Skip summary fields such as total_managed_indices
+		if len(raw) == 0 || raw[0] != '{' {
+			continue
+		}
+		var explanation ISMExplanation
+		if err := json.Unmarshal(raw, &explanation); err != nil {
+			return nil, fmt.Errorf("failed to parse ISM explain for %s: %w", name, err)
+		}
+		explanation.Index = name
+		explanations = append(explanations, explanation)
+	}
+	sort.Slice(explanations, func(i, j int) bool { return explanations[i].Index < explanations[j].Index })
+
+	return explanations, nil
+}
+
+// get performs a GET for APIs the client has no typed helper for, such as
+// plugin endpoints
+func (o *OpenSearch) get(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return o.client.Perform(req)
+}
@@ -0,0 +1,225 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// IngestOverview is every ingest pipeline with its stats summed over all
+// nodes
+type IngestOverview struct {
+	Pipelines []IngestPipeline
+}
+
+// IngestPipeline is an ingest pipeline and how much work it has done
+type IngestPipeline struct {
+	ID          string
+	Description string
+	Stats       IngestStats
+	Processors  []IngestProcessor // In pipeline order
+}
+
+// IngestProcessor is one processor of a pipeline and how much work it has
+// done
+type IngestProcessor struct {
+	Type  string
+	Tag   string
+	Stats IngestStats
+}
+
+// Name returns the processor as the stats API names it, e.g. "set:env"
+func (p IngestProcessor) Name() string {
+	if p.Tag == "" {
+		return p.Type
+	}
+	return p.Type + ":" + p.Tag
+}
+
+// IngestStats are cumulative ingest counters
+type IngestStats struct {
+	Count      int64 `json:"count"`
+	TimeMillis int64 `json:"time_in_millis"`
+	Current    int64 `json:"current"`
+	Failed     int64 `json:"failed"`
+}
+
+// add sums other into s
+func (s *IngestStats) add(other IngestStats) {
+	s.Count += other.Count
+	s.TimeMillis += other.TimeMillis
+	s.Current += other.Current
+	s.Failed += other.Failed
+}
+
+// SimulatedDoc is a sample document after an ingest pipeline ran on it
+type SimulatedDoc struct {
+	Source  map[string]interface{} // Nil when the document failed or was dropped
+	Dropped bool
+	Error   string
+}
+
+// pipelineStats are a pipeline's counters on one node
+type pipelineStats struct {
+	IngestStats
+	Processors []map[string]struct {
+		Type  string      `json:"type"`
+		Stats IngestStats `json:"stats"`
+	} `json:"processors"`
+}
+
+// Ingest lists the ingest pipelines with their stats from every node
+func (o *OpenSearch) Ingest(ctx context.Context) (*IngestOverview, error) {
+	definitions, err := o.ingestPipelines(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := o.client.Nodes.Stats(
+		o.client.Nodes.Stats.WithMetric("ingest"),
+		o.client.Nodes.Stats.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ingest stats request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("ingest stats API error: %s", res.Status())
+	}
+
+	var response struct {
+		Nodes map[string]struct {
+			Ingest struct {
+				Pipelines map[string]pipelineStats `json:"pipelines"`
+			} `json:"ingest"`
+		} `json:"nodes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse ingest stats: %w", err)
+	}
+
+	overview := &IngestOverview{}
+	for _, id := range sortedNames(definitions) {
+		pipeline := definitions[id]
+		for _, node := range response.Nodes {
+			stats, ok := node.Ingest.Pipelines[id]
+			if !ok {
+				continue
+			}
+			pipeline.Stats.add(stats.IngestStats)
+			// Processor stats are listed in pipeline order
+			for i, processor := range stats.Processors {
+				if i >= len(pipeline.Processors) {
+					break
+				}
+				for _, p := range processor {
+					pipeline.Processors[i].Stats.add(p.Stats)
+				}
+			}
+		}
+		overview.Pipelines = append(overview.Pipelines, pipeline)
+	}
+
+	return overview, nil
+}
+
+// ingestPipelines returns the pipeline definitions by ID, without stats
+func (o *OpenSearch) ingestPipelines(ctx context.Context) (map[string]IngestPipeline, error) {
+	res, err := o.client.Ingest.GetPipeline(o.client.Ingest.GetPipeline.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("ingest pipelines request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("ingest pipelines API error: %s", res.Status())
+	}
+
+	var response map[string]struct {
+		Description string                       `json:"description"`
+		Processors  []map[string]json.RawMessage `json:"processors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse ingest pipelines: %w", err)
+	}
+
+	pipelines := make(map[string]IngestPipeline, len(response))
+	for id, p := range response {
+		pipeline := IngestPipeline{ID: id, Description: p.Description}
+		// Each processor is an object keyed by its type
+		for _, processor := range p.Processors {
+			for processorType, raw := range processor {
+				var config struct {
+					Tag string `json:"tag"`
+				}
+				json.Unmarshal(raw, &config)
+				pipeline.Processors = append(pipeline.Processors, IngestProcessor{Type: processorType, Tag: config.Tag})
+			}
+		}
+		pipelines[id] = pipeline
+	}
+	return pipelines, nil
+}
+
+// SimulatePipeline runs sample documents through an ingest pipeline without
+// indexing them
+func (o *OpenSearch) SimulatePipeline(ctx context.Context, id string, docs []json.RawMessage) ([]SimulatedDoc, error) {
+	type sampleDoc struct {
+		Source json.RawMessage `json:"_source"`
+	}
+	request := struct {
+		Docs []sampleDoc `json:"docs"`
+	}{}
+	for _, doc := range docs {
+		request.Docs = append(request.Docs, sampleDoc{Source: doc})
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sample documents: %w", err)
+	}
+
+	res, err := o.client.Ingest.Simulate(
+		bytes.NewReader(body),
+		o.client.Ingest.Simulate.WithPipelineID(id),
+		o.client.Ingest.Simulate.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("simulate pipeline request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("simulate pipeline API error: %s", res.Status())
+	}
+
+	var response struct {
+		Docs []*struct {
+			Doc *struct {
+				Source map[string]interface{} `json:"_source"`
+			} `json:"doc"`
+			Error *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"docs"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse pipeline simulation: %w", err)
+	}
+
+	results := make([]SimulatedDoc, 0, len(response.Docs))
+	for _, d := range response.Docs {
+		switch {
+		case d == nil || (d.Doc == nil && d.Error == nil):
+			// A drop processor leaves nothing to show
+			results = append(results, SimulatedDoc{Dropped: true})
+		case d.Error != nil:
+			results = append(results, SimulatedDoc{Error: fmt.Sprintf("%s: %s", d.Error.Type, d.Error.Reason)})
+		default:
+			results = append(results, SimulatedDoc{Source: d.Doc.Source})
+		}
+	}
+	return results, nil
+}
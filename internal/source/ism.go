@@ -0,0 +1,149 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ismPageSize is how many policies or managed indices are requested at
+// once; the ISM APIs default to 20
+const ismPageSize = 1000
+
+// ISMOverview is the cluster's ISM policies and managed indices. Available
+// is false when the ISM plugin isn't installed.
+type ISMOverview struct {
+	Available bool
+	Policies  []ISMPolicy
+	Managed   []ISMExplanation // Only indices with a policy
+}
+
+// ISMPolicy is an ISM policy definition, reduced to what's worth showing
+type ISMPolicy struct {
+	ID            string
+	Description   string
+	DefaultState  string
+	States        []string
+	IndexPatterns []string // From the policy's ISM templates
+	LastUpdated   int64    // Milliseconds since the epoch
+}
+
+// ISM lists the ISM policies and every managed index's state
+func (o *OpenSearch) ISM(ctx context.Context) (*ISMOverview, error) {
+	policies, err := o.ISMPolicies(ctx)
+	if errors.Is(err, ErrISMUnavailable) {
+		return &ISMOverview{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	explained, err := o.ISMExplain(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &ISMOverview{Available: true, Policies: policies}
+	for _, explanation := range explained {
+		if explanation.PolicyID != "" {
+			overview.Managed = append(overview.Managed, explanation)
+		}
+	}
+	return overview, nil
+}
+
+// ISMPolicies calls the ISM get policies API, falling back to the legacy
+// Open Distro path
+func (o *OpenSearch) ISMPolicies(ctx context.Context) ([]ISMPolicy, error) {
+	var lastErr error
+	for _, prefix := range []string{"/_plugins/_ism/policies", "/_opendistro/_ism/policies"} {
+		policies, err := o.ismPolicies(ctx, fmt.Sprintf("%s?size=%d", prefix, ismPageSize))
+		if !errors.Is(err, ErrISMUnavailable) {
+			return policies, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// ismPolicies decodes one get policies response
+func (o *OpenSearch) ismPolicies(ctx context.Context, path string) ([]ISMPolicy, error) {
+	res, err := o.get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("ISM policies request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(res.Body)
+		if res.StatusCode == http.StatusNotFound || strings.Contains(string(body), "no handler found") {
+			return nil, ErrISMUnavailable
+		}
+		return nil, fmt.Errorf("ISM policies API error: %s", res.Status)
+	}
+
+	var response struct {
+		Policies []struct {
+			ID     string `json:"_id"`
+			Policy struct {
+				Description     string `json:"description"`
+				DefaultState    string `json:"default_state"`
+				LastUpdatedTime int64  `json:"last_updated_time"`
+				States          []struct {
+					Name string `json:"name"`
+				} `json:"states"`
+				ISMTemplate json.RawMessage `json:"ism_template"`
+			} `json:"policy"`
+		} `json:"policies"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse ISM policies: %w", err)
+	}
+
+	policies := make([]ISMPolicy, 0, len(response.Policies))
+	for _, p := range response.Policies {
+		policy := ISMPolicy{
+			ID:            p.ID,
+			Description:   p.Policy.Description,
+			DefaultState:  p.Policy.DefaultState,
+			LastUpdated:   p.Policy.LastUpdatedTime,
+			IndexPatterns: ismTemplatePatterns(p.Policy.ISMTemplate),
+		}
+		for _, state := range p.Policy.States {
+			policy.States = append(policy.States, state.Name)
+		}
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].ID < policies[j].ID })
+
+	return policies, nil
+}
+
+// ismTemplatePatterns returns the index patterns of a policy's ISM
+// templates, which older versions give as a single object and newer ones
+// as a list
+func ismTemplatePatterns(raw json.RawMessage) []string {
+	type template struct {
+		IndexPatterns []string `json:"index_patterns"`
+	}
+
+	var templates []template
+	if err := json.Unmarshal(raw, &templates); err != nil {
+		var single template
+		if json.Unmarshal(raw, &single) != nil {
+			return nil
+		}
+		templates = []template{single}
+	}
+
+	var patterns []string
+	for _, t := range templates {
+		patterns = append(patterns, t.IndexPatterns...)
+	}
+	return patterns
+}
@@ -0,0 +1,94 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// NodeActivity holds one node's cumulative indexing, search and old-gen GC
+// counters with its current heap and CPU use, used to derive per-node rates
+type NodeActivity struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	IndexTotal        int64  `json:"index_total"`
+	QueryTotal        int64  `json:"query_total"`
+	HeapUsedInBytes   int64  `json:"heap_used_in_bytes"`
+	HeapMaxInBytes    int64  `json:"heap_max_in_bytes"`
+	OldGCCount        int64  `json:"old_gc_count"`
+	OldGCTimeInMillis int64  `json:"old_gc_time_in_millis"`
+	CPUPercent        int64  `json:"cpu_percent"`
+}
+
+// NodeActivity calls the nodes stats API for every node's indexing, search,
+// JVM and OS stats, sorted by node name
+func (o *OpenSearch) NodeActivity(ctx context.Context) ([]NodeActivity, error) {
+	res, err := o.client.Nodes.Stats(
+		o.client.Nodes.Stats.WithMetric("indices", "jvm", "os"),
+		o.client.Nodes.Stats.WithIndexMetric("indexing", "search"),
+		o.client.Nodes.Stats.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("node activity request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("node activity API error: %s", res.Status())
+	}
+
+	var response struct {
+		Nodes map[string]struct {
+			Name    string `json:"name"`
+			Indices struct {
+				Indexing struct {
+					IndexTotal int64 `json:"index_total"`
+				} `json:"indexing"`
+				Search struct {
+					QueryTotal int64 `json:"query_total"`
+				} `json:"search"`
+			} `json:"indices"`
+			JVM struct {
+				Mem struct {
+					HeapUsedInBytes int64 `json:"heap_used_in_bytes"`
+					HeapMaxInBytes  int64 `json:"heap_max_in_bytes"`
+				} `json:"mem"`
+				GC struct {
+					Collectors map[string]GCCollector `json:"collectors"`
+				} `json:"gc"`
+			} `json:"jvm"`
+			OS struct {
+				CPU struct {
+					Percent int64 `json:"percent"`
+				} `json:"cpu"`
+			} `json:"os"`
+		} `json:"nodes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse node activity: %w", err)
+	}
+
+	nodes := make([]NodeActivity, 0, len(response.Nodes))
+	for id, node := range response.Nodes {
+		old := node.JVM.GC.Collectors["old"]
+		nodes = append(nodes, NodeActivity{
+			ID:                id,
+			Name:              node.Name,
+			IndexTotal:        node.Indices.Indexing.IndexTotal,
+			QueryTotal:        node.Indices.Search.QueryTotal,
+			HeapUsedInBytes:   node.JVM.Mem.HeapUsedInBytes,
+			HeapMaxInBytes:    node.JVM.Mem.HeapMaxInBytes,
+			OldGCCount:        old.CollectionCount,
+			OldGCTimeInMillis: old.CollectionTimeInMillis,
+			CPUPercent:        node.OS.CPU.Percent,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Name != nodes[j].Name {
+			return nodes[i].Name < nodes[j].Name
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes, nil
+}
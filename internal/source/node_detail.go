@@ -0,0 +1,171 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// NodeDetail combines a single node's _nodes/<id>/stats and _nodes/<id>
+// responses for the node drill-down
+type NodeDetail struct {
+	ID         string
+	Stats      NodeStats
+	Version    string // OpenSearch version
+	JVMVersion string
+	JVMVMName  string
+}
+
+// NodeStats is one node's entry from the nodes stats API
+type NodeStats struct {
+	Name    string   `json:"name"`
+	Host    string   `json:"host"`
+	IP      string   `json:"ip"`
+	Roles   []string `json:"roles"`
+	Indices struct {
+		Docs struct {
+			Count int64 `json:"count"`
+		} `json:"docs"`
+		Indexing struct {
+			IndexTotal        int64 `json:"index_total"`
+			IndexTimeInMillis int64 `json:"index_time_in_millis"`
+			IndexCurrent      int64 `json:"index_current"`
+			IndexFailed       int64 `json:"index_failed"`
+		} `json:"indexing"`
+		Search struct {
+			QueryTotal        int64 `json:"query_total"`
+			QueryTimeInMillis int64 `json:"query_time_in_millis"`
+			QueryCurrent      int64 `json:"query_current"`
+			FetchTotal        int64 `json:"fetch_total"`
+			FetchTimeInMillis int64 `json:"fetch_time_in_millis"`
+		} `json:"search"`
+	} `json:"indices"`
+	JVM struct {
+		UptimeInMillis int64 `json:"uptime_in_millis"`
+		Mem            struct {
+			HeapUsedInBytes int64                    `json:"heap_used_in_bytes"`
+			HeapMaxInBytes  int64                    `json:"heap_max_in_bytes"`
+			HeapUsedPercent int64                    `json:"heap_used_percent"`
+			Pools           map[string]JVMMemoryPool `json:"pools"`
+		} `json:"mem"`
+		GC struct {
+			Collectors map[string]GCCollector `json:"collectors"`
+		} `json:"gc"`
+	} `json:"jvm"`
+	Process struct {
+		OpenFileDescriptors int64 `json:"open_file_descriptors"`
+		MaxFileDescriptors  int64 `json:"max_file_descriptors"`
+	} `json:"process"`
+	ThreadPool map[string]NodeThreadPool `json:"thread_pool"`
+	Breakers   map[string]CircuitBreaker `json:"breakers"`
+	Transport  struct {
+		ServerOpen    int64 `json:"server_open"`
+		RxCount       int64 `json:"rx_count"`
+		TxCount       int64 `json:"tx_count"`
+		RxSizeInBytes int64 `json:"rx_size_in_bytes"`
+		TxSizeInBytes int64 `json:"tx_size_in_bytes"`
+	} `json:"transport"`
+	HTTP struct {
+		CurrentOpen int64 `json:"current_open"`
+		TotalOpened int64 `json:"total_opened"`
+	} `json:"http"`
+}
+
+// JVMMemoryPool is a heap pool such as young, survivor or old
+type JVMMemoryPool struct {
+	UsedInBytes     int64 `json:"used_in_bytes"`
+	MaxInBytes      int64 `json:"max_in_bytes"`
+	PeakUsedInBytes int64 `json:"peak_used_in_bytes"`
+}
+
+// GCCollector holds cumulative garbage collection counters
+type GCCollector struct {
+	CollectionCount        int64 `json:"collection_count"`
+	CollectionTimeInMillis int64 `json:"collection_time_in_millis"`
+}
+
+// NodeThreadPool is one thread pool's stats on a single node
+type NodeThreadPool struct {
+	Threads   int64 `json:"threads"`
+	Queue     int64 `json:"queue"`
+	Active    int64 `json:"active"`
+	Rejected  int64 `json:"rejected"`
+	Largest   int64 `json:"largest"`
+	Completed int64 `json:"completed"`
+}
+
+// CircuitBreaker is one circuit breaker's stats on a single node
+type CircuitBreaker struct {
+	LimitSizeInBytes     int64   `json:"limit_size_in_bytes"`
+	EstimatedSizeInBytes int64   `json:"estimated_size_in_bytes"`
+	Overhead             float64 `json:"overhead"`
+	Tripped              int64   `json:"tripped"`
+}
+
+// NodeDetail calls the nodes stats and nodes info APIs for a single node.
+// node may be a node ID or name.
+func (o *OpenSearch) NodeDetail(ctx context.Context, node string) (*NodeDetail, error) {
+	res, err := o.client.Nodes.Stats(
+		o.client.Nodes.Stats.WithNodeID(node),
+		o.client.Nodes.Stats.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("node stats request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("node stats API error: %s", res.Status())
+	}
+
+	var statsResponse struct {
+		Nodes map[string]NodeStats `json:"nodes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&statsResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse node stats: %w", err)
+	}
+
+	detail := &NodeDetail{}
+	for id, stats := range statsResponse.Nodes {
+		detail.ID = id
+		detail.Stats = stats
+		break
+	}
+	if detail.ID == "" {
+		return nil, fmt.Errorf("node %q not found", node)
+	}
+
+	infoRes, err := o.client.Nodes.Info(
+		o.client.Nodes.Info.WithNodeID(detail.ID),
+		o.client.Nodes.Info.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("node info request failed: %w", err)
+	}
+	defer infoRes.Body.Close()
+
+	if infoRes.IsError() {
+		return nil, fmt.Errorf("node info API error: %s", infoRes.Status())
+	}
+
+	var infoResponse struct {
+		Nodes map[string]struct {
+			Version string `json:"version"`
+			JVM     struct {
+				Version string `json:"version"`
+				VMName  string `json:"vm_name"`
+			} `json:"jvm"`
+		} `json:"nodes"`
+	}
+	if err := json.NewDecoder(infoRes.Body).Decode(&infoResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse node info: %w", err)
+	}
+
+	if info, ok := infoResponse.Nodes[detail.ID]; ok {
+		detail.Version = info.Version
+		detail.JVMVersion = info.JVM.Version
+		detail.JVMVMName = info.JVM.VMName
+	}
+
+	return detail, nil
+}
@@ -0,0 +1,96 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// NodeMemory is one node's circuit breakers and the heap held by its caches
+// and segments
+type NodeMemory struct {
+	ID           string
+	Name         string
+	Breakers     map[string]CircuitBreaker
+	QueryCache   CacheMemory
+	RequestCache CacheMemory
+	Fielddata    CacheMemory
+	Segments     SegmentMemory
+}
+
+// CacheMemory is the heap a cache holds and its cumulative counters
+type CacheMemory struct {
+	MemorySizeInBytes int64 `json:"memory_size_in_bytes"`
+	Evictions         int64 `json:"evictions"`
+	HitCount          int64 `json:"hit_count"`
+	MissCount         int64 `json:"miss_count"`
+}
+
+// SegmentMemory is the heap held by a node's Lucene segments
+type SegmentMemory struct {
+	Count                     int64 `json:"count"`
+	MemoryInBytes             int64 `json:"memory_in_bytes"`
+	TermsMemoryInBytes        int64 `json:"terms_memory_in_bytes"`
+	StoredFieldsMemoryInBytes int64 `json:"stored_fields_memory_in_bytes"`
+	DocValuesMemoryInBytes    int64 `json:"doc_values_memory_in_bytes"`
+	PointsMemoryInBytes       int64 `json:"points_memory_in_bytes"`
+	NormsMemoryInBytes        int64 `json:"norms_memory_in_bytes"`
+	IndexWriterMemoryInBytes  int64 `json:"index_writer_memory_in_bytes"`
+	VersionMapMemoryInBytes   int64 `json:"version_map_memory_in_bytes"`
+	FixedBitSetMemoryInBytes  int64 `json:"fixed_bit_set_memory_in_bytes"`
+}
+
+// NodeMemory calls the nodes stats API for breakers, caches and segments,
+// sorted by node name
+func (o *OpenSearch) NodeMemory(ctx context.Context) ([]NodeMemory, error) {
+	res, err := o.client.Nodes.Stats(
+		o.client.Nodes.Stats.WithMetric("breaker", "indices"),
+		o.client.Nodes.Stats.WithIndexMetric("query_cache", "request_cache", "fielddata", "segments"),
+		o.client.Nodes.Stats.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("node memory request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("node memory API error: %s", res.Status())
+	}
+
+	var response struct {
+		Nodes map[string]struct {
+			Name     string                    `json:"name"`
+			Breakers map[string]CircuitBreaker `json:"breakers"`
+			Indices  struct {
+				QueryCache   CacheMemory   `json:"query_cache"`
+				RequestCache CacheMemory   `json:"request_cache"`
+				Fielddata    CacheMemory   `json:"fielddata"`
+				Segments     SegmentMemory `json:"segments"`
+			} `json:"indices"`
+		} `json:"nodes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse node memory: %w", err)
+	}
+
+	nodes := make([]NodeMemory, 0, len(response.Nodes))
+	for id, node := range response.Nodes {
+		nodes = append(nodes, NodeMemory{
+			ID:           id,
+			Name:         node.Name,
+			Breakers:     node.Breakers,
+			QueryCache:   node.Indices.QueryCache,
+			RequestCache: node.Indices.RequestCache,
+			Fielddata:    node.Indices.Fielddata,
+			Segments:     node.Indices.Segments,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Name != nodes[j].Name {
+			return nodes[i].Name < nodes[j].Name
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes, nil
+}
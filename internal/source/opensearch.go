@@ -0,0 +1,445 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	opensearch "github.com/opensearch-project/opensearch-go/v2"
+)
+
+// OpenSearch is the live ClusterSource backed by an OpenSearch client
+type OpenSearch struct {
+	client *opensearch.Client
+}
+
+var _ ClusterSource = (*OpenSearch)(nil)
+
+// NewOpenSearch returns a ClusterSource that queries a live cluster
+func NewOpenSearch(client *opensearch.Client) *OpenSearch {
+	return &OpenSearch{client: client}
+}
+
+// ClusterHealth calls the cluster health API
+func (o *OpenSearch) ClusterHealth(ctx context.Context) (*ClusterHealth, error) {
+	res, err := o.client.Cluster.Health(o.client.Cluster.Health.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("cluster health request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("cluster health API error: %s", res.Status())
+	}
+
+	var health ClusterHealth
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster health: %w", err)
+	}
+
+	return &health, nil
+}
+
+// ClusterStats calls the cluster stats API
+func (o *OpenSearch) ClusterStats(ctx context.Context) (*ClusterStats, error) {
+	res, err := o.client.Cluster.Stats(o.client.Cluster.Stats.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("cluster stats request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("cluster stats API error: %s", res.Status())
+	}
+
+	var stats ClusterStats
+	if err := json.NewDecoder(res.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// Nodes calls the CAT nodes API
+func (o *OpenSearch) Nodes(ctx context.Context) ([]NodeInfo, error) {
+	res, err := o.client.Cat.Nodes(
+		o.client.Cat.Nodes.WithContext(ctx),
+		o.client.Cat.Nodes.WithFormat("json"),
+		o.client.Cat.Nodes.WithH("ip", "heap.percent", "ram.percent", "cpu", "load_1m", "load_5m", "load_15m", "node.role", "master", "name", "disk.used_percent", "disk.used", "disk.avail", "disk.total"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("nodes request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("nodes API error: %s", res.Status())
+	}
+
+	var nodes []NodeInfo
+	if err := json.NewDecoder(res.Body).Decode(&nodes); err != nil {
+		return nil, fmt.Errorf("failed to parse nodes: %w", err)
+	}
+
+	return nodes, nil
+}
+
+// Indices calls the CAT indices API
+func (o *OpenSearch) Indices(ctx context.Context) ([]IndexInfo, error) {
+	res, err := o.client.Cat.Indices(
+		o.client.Cat.Indices.WithContext(ctx),
+		o.client.Cat.Indices.WithFormat("json"),
+		o.client.Cat.Indices.WithH("health", "status", "index", "uuid", "pri", "rep", "docs.count", "docs.deleted", "store.size", "pri.store.size"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("indices request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("indices API error: %s", res.Status())
+	}
+
+	var indices []IndexInfo
+	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+		return nil, fmt.Errorf("failed to parse indices: %w", err)
+	}
+
+	return indices, nil
+}
+
+// Shards calls the CAT shards API
+func (o *OpenSearch) Shards(ctx context.Context) ([]ShardInfo, error) {
+	res, err := o.client.Cat.Shards(
+		o.client.Cat.Shards.WithContext(ctx),
+		o.client.Cat.Shards.WithFormat("json"),
+		o.client.Cat.Shards.WithH("index", "shard", "prirep", "state", "docs", "store", "ip", "node"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("shards request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("shards API error: %s", res.Status())
+	}
+
+	var shards []ShardInfo
+	if err := json.NewDecoder(res.Body).Decode(&shards); err != nil {
+		return nil, fmt.Errorf("failed to parse shards: %w", err)
+	}
+
+	return shards, nil
+}
+
+// Allocation calls the CAT allocation API
+func (o *OpenSearch) Allocation(ctx context.Context) ([]AllocationInfo, error) {
+	res, err := o.client.Cat.Allocation(
+		o.client.Cat.Allocation.WithContext(ctx),
+		o.client.Cat.Allocation.WithFormat("json"),
+		o.client.Cat.Allocation.WithH("shards", "disk.indices", "disk.used", "disk.avail", "disk.total", "disk.percent", "host", "ip", "node"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("allocation request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("allocation API error: %s", res.Status())
+	}
+
+	var allocation []AllocationInfo
+	if err := json.NewDecoder(res.Body).Decode(&allocation); err != nil {
+		return nil, fmt.Errorf("failed to parse allocation: %w", err)
+	}
+
+	return allocation, nil
+}
+
+// ThreadPool calls the CAT thread_pool API
+func (o *OpenSearch) ThreadPool(ctx context.Context) ([]ThreadPoolInfo, error) {
+	res, err := o.client.Cat.ThreadPool(
+		o.client.Cat.ThreadPool.WithContext(ctx),
+		o.client.Cat.ThreadPool.WithFormat("json"),
+		o.client.Cat.ThreadPool.WithH("node_name", "name", "active", "queue", "rejected", "completed", "size"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("thread_pool request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("thread_pool API error: %s", res.Status())
+	}
+
+	var threadPool []ThreadPoolInfo
+	if err := json.NewDecoder(res.Body).Decode(&threadPool); err != nil {
+		return nil, fmt.Errorf("failed to parse thread_pool: %w", err)
+	}
+
+	return threadPool, nil
+}
+
+// Tasks calls the CAT tasks API
+func (o *OpenSearch) Tasks(ctx context.Context) ([]TaskInfo, error) {
+	res, err := o.client.Cat.Tasks(
+		o.client.Cat.Tasks.WithContext(ctx),
+		o.client.Cat.Tasks.WithFormat("json"),
+		o.client.Cat.Tasks.WithDetailed(true),
+		o.client.Cat.Tasks.WithH("action", "task_id", "parent_task_id", "type", "start_time", "timestamp", "running_time", "ip", "node", "description"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tasks request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("tasks API error: %s", res.Status())
+	}
+
+	var tasks []TaskInfo
+	if err := json.NewDecoder(res.Body).Decode(&tasks); err != nil {
+		return nil, fmt.Errorf("failed to parse tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// PendingTasks calls the CAT pending_tasks API
+func (o *OpenSearch) PendingTasks(ctx context.Context) ([]PendingTaskInfo, error) {
+	res, err := o.client.Cat.PendingTasks(
+		o.client.Cat.PendingTasks.WithContext(ctx),
+		o.client.Cat.PendingTasks.WithFormat("json"),
+		o.client.Cat.PendingTasks.WithH("insertOrder", "timeInQueue", "priority", "source"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pending_tasks request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("pending_tasks API error: %s", res.Status())
+	}
+
+	var pendingTasks []PendingTaskInfo
+	if err := json.NewDecoder(res.Body).Decode(&pendingTasks); err != nil {
+		return nil, fmt.Errorf("failed to parse pending_tasks: %w", err)
+	}
+
+	return pendingTasks, nil
+}
+
+// Recovery calls the CAT recovery API
+func (o *OpenSearch) Recovery(ctx context.Context) ([]RecoveryInfo, error) {
+	res, err := o.client.Cat.Recovery(
+		o.client.Cat.Recovery.WithContext(ctx),
+		o.client.Cat.Recovery.WithFormat("json"),
+		o.client.Cat.Recovery.WithActiveOnly(true),
+		o.client.Cat.Recovery.WithH("index", "shard", "time", "type", "stage", "source_node", "target_node", "files", "files_recovered", "files_percent", "bytes", "bytes_recovered", "bytes_percent"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("recovery request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("recovery API error: %s", res.Status())
+	}
+
+	var recovery []RecoveryInfo
+	if err := json.NewDecoder(res.Body).Decode(&recovery); err != nil {
+		return nil, fmt.Errorf("failed to parse recovery: %w", err)
+	}
+
+	return recovery, nil
+}
+
+// Segments calls the CAT segments API
+func (o *OpenSearch) Segments(ctx context.Context) ([]SegmentInfo, error) {
+	res, err := o.client.Cat.Segments(
+		o.client.Cat.Segments.WithContext(ctx),
+		o.client.Cat.Segments.WithFormat("json"),
+		o.client.Cat.Segments.WithH("index", "shard", "prirep", "ip", "segment", "generation", "docs.count", "docs.deleted", "size", "committed"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("segments request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("segments API error: %s", res.Status())
+	}
+
+	var segments []SegmentInfo
+	if err := json.NewDecoder(res.Body).Decode(&segments); err != nil {
+		return nil, fmt.Errorf("failed to parse segments: %w", err)
+	}
+
+	return segments, nil
+}
+
+// Fielddata calls the CAT fielddata API
+func (o *OpenSearch) Fielddata(ctx context.Context) ([]FielddataInfo, error) {
+	res, err := o.client.Cat.Fielddata(
+		o.client.Cat.Fielddata.WithContext(ctx),
+		o.client.Cat.Fielddata.WithFormat("json"),
+		o.client.Cat.Fielddata.WithH("id", "host", "ip", "node", "field", "size"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fielddata request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("fielddata API error: %s", res.Status())
+	}
+
+	var fielddata []FielddataInfo
+	if err := json.NewDecoder(res.Body).Decode(&fielddata); err != nil {
+		return nil, fmt.Errorf("failed to parse fielddata: %w", err)
+	}
+
+	return fielddata, nil
+}
+
+// Plugins calls the CAT plugins API
+func (o *OpenSearch) Plugins(ctx context.Context) ([]PluginInfo, error) {
+	res, err := o.client.Cat.Plugins(
+		o.client.Cat.Plugins.WithContext(ctx),
+		o.client.Cat.Plugins.WithFormat("json"),
+		o.client.Cat.Plugins.WithH("id", "name", "component", "version", "description"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("plugins request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("plugins API error: %s", res.Status())
+	}
+
+	var plugins []PluginInfo
+	if err := json.NewDecoder(res.Body).Decode(&plugins); err != nil {
+		return nil, fmt.Errorf("failed to parse plugins: %w", err)
+	}
+
+	return plugins, nil
+}
+
+// Templates calls the CAT templates API
+func (o *OpenSearch) Templates(ctx context.Context) ([]TemplateInfo, error) {
+	res, err := o.client.Cat.Templates(
+		o.client.Cat.Templates.WithContext(ctx),
+		o.client.Cat.Templates.WithFormat("json"),
+		o.client.Cat.Templates.WithH("name", "index_patterns", "order", "version"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("templates request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("templates API error: %s", res.Status())
+	}
+
+	var templates []TemplateInfo
+	if err := json.NewDecoder(res.Body).Decode(&templates); err != nil {
+		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// IndexMapping calls the get mapping API for a single index
+func (o *OpenSearch) IndexMapping(ctx context.Context, index string) (*IndexMapping, error) {
+	res, err := o.client.Indices.GetMapping(
+		o.client.Indices.GetMapping.WithIndex(index),
+		o.client.Indices.GetMapping.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("mapping request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("mapping API error: %s", res.Status())
+	}
+
+	// Parse the response
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping: %w", err)
+	}
+
+	// Extract the mapping for the specific index
+	// Response format: { "index_name": { "mappings": { ... } } }
+	indexData, ok := response[index].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected mapping response format")
+	}
+
+	mappings, ok := indexData["mappings"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no mappings found in response")
+	}
+
+	return &IndexMapping{
+		IndexName: index,
+		Mappings:  mappings,
+	}, nil
+}
+
+// ActivityStats calls the indices stats API for cluster-wide operation counters
+func (o *OpenSearch) ActivityStats(ctx context.Context) (*ActivityStats, error) {
+	res, err := o.client.Indices.Stats(
+		o.client.Indices.Stats.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cluster metrics request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("cluster metrics API error: %s", res.Status())
+	}
+
+	var statsResponse struct {
+		All struct {
+			Primaries struct {
+				Indexing struct {
+					IndexTotal        int64 `json:"index_total"`
+					IndexTimeInMillis int64 `json:"index_time_in_millis"`
+				} `json:"indexing"`
+				Search struct {
+					QueryTotal        int64 `json:"query_total"`
+					QueryTimeInMillis int64 `json:"query_time_in_millis"`
+					FetchTotal        int64 `json:"fetch_total"`
+					FetchTimeInMillis int64 `json:"fetch_time_in_millis"`
+				} `json:"search"`
+			} `json:"primaries"`
+			Total struct {
+				Search struct {
+					ScrollCurrent      int64 `json:"scroll_current"`
+					PointInTimeCurrent int64 `json:"point_in_time_current"`
+				} `json:"search"`
+			} `json:"total"`
+		} `json:"_all"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&statsResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster metrics: %w", err)
+	}
+
+	primaries := statsResponse.All.Primaries
+	return &ActivityStats{
+		IndexTotal:        primaries.Indexing.IndexTotal,
+		IndexTimeInMillis: primaries.Indexing.IndexTimeInMillis,
+		SearchTotal:       primaries.Search.QueryTotal,
+		QueryTimeInMillis: primaries.Search.QueryTimeInMillis,
+		FetchTotal:        primaries.Search.FetchTotal,
+		FetchTimeInMillis: primaries.Search.FetchTimeInMillis,
+		// Replicas hold search contexts too, so count them on all shards
+		ScrollCurrent: statsResponse.All.Total.Search.ScrollCurrent,
+		PITCurrent:    statsResponse.All.Total.Search.PointInTimeCurrent,
+	}, nil
+}
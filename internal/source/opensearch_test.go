@@ -0,0 +1,665 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	opensearch "github.com/opensearch-project/opensearch-go/v2"
+)
+
+// newTestSource serves canned responses keyed by URL path
+func newTestSource(t *testing.T, responses map[string]string) *OpenSearch {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := opensearch.NewClient(opensearch.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return NewOpenSearch(client)
+}
+
+// TestOpenSearch_TypedResponses tests that each API is decoded into its type
+func TestOpenSearch_TypedResponses(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_cluster/health": `{"cluster_name":"test","status":"green","number_of_nodes":3}`,
+		"/_cluster/stats":  `{"cluster_name":"test","indices":{"count":7}}`,
+		"/_cat/nodes":      `[{"name":"node-1","heap.percent":"42"}]`,
+		"/_cat/indices":    `[{"index":"logs","health":"yellow"}]`,
+		"/_cat/shards":     `[{"index":"logs","shard":"0","prirep":"p","state":"STARTED"}]`,
+		"/_cat/plugins":    `[{"name":"node-1","component":"opensearch-security"}]`,
+		"/logs/_mapping":   `{"logs":{"mappings":{"properties":{"msg":{"type":"text"}}}}}`,
+		"/_stats":          `{"_all":{"primaries":{"indexing":{"index_total":10,"index_time_in_millis":30},"search":{"query_total":20,"query_time_in_millis":40,"fetch_total":15,"fetch_time_in_millis":5}},"total":{"search":{"scroll_current":3,"point_in_time_current":2}}}}`,
+	})
+	ctx := context.Background()
+
+	health, err := src.ClusterHealth(ctx)
+	if err != nil || health.ClusterName != "test" || health.NumberOfNodes != 3 {
+		t.Errorf("ClusterHealth() = %+v, %v", health, err)
+	}
+
+	stats, err := src.ClusterStats(ctx)
+	if err != nil || stats.Indices.Count != 7 {
+		t.Errorf("ClusterStats() = %+v, %v", stats, err)
+	}
+
+	nodes, err := src.Nodes(ctx)
+	if err != nil || len(nodes) != 1 || nodes[0].HeapPercent != "42" {
+		t.Errorf("Nodes() = %+v, %v", nodes, err)
+	}
+
+	indices, err := src.Indices(ctx)
+	if err != nil || len(indices) != 1 || indices[0].Health != "yellow" {
+		t.Errorf("Indices() = %+v, %v", indices, err)
+	}
+
+	shards, err := src.Shards(ctx)
+	if err != nil || len(shards) != 1 || shards[0].State != "STARTED" {
+		t.Errorf("Shards() = %+v, %v", shards, err)
+	}
+
+	plugins, err := src.Plugins(ctx)
+	if err != nil || len(plugins) != 1 || plugins[0].Component != "opensearch-security" {
+		t.Errorf("Plugins() = %+v, %v", plugins, err)
+	}
+
+	mapping, err := src.IndexMapping(ctx, "logs")
+	if err != nil || mapping.IndexName != "logs" || mapping.Mappings["properties"] == nil {
+		t.Errorf("IndexMapping() = %+v, %v", mapping, err)
+	}
+
+	activity, err := src.ActivityStats(ctx)
+	if err != nil || activity.IndexTotal != 10 || activity.SearchTotal != 20 {
+		t.Errorf("ActivityStats() = %+v, %v", activity, err)
+	} else if activity.IndexTimeInMillis != 30 || activity.QueryTimeInMillis != 40 || activity.FetchTotal != 15 ||
+		activity.FetchTimeInMillis != 5 || activity.ScrollCurrent != 3 || activity.PITCurrent != 2 {
+		t.Errorf("ActivityStats() latencies and contexts = %+v", activity)
+	}
+}
+
+// TestOpenSearch_Errors tests API error statuses and malformed bodies
+func TestOpenSearch_Errors(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_cat/segments":  `not json`,
+		"/other/_mapping": `{"other":{}}`,
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		call     func() error
+		errorMsg string
+	}{
+		{"status", func() error { _, err := src.Templates(ctx); return err }, "templates API error: 404"},
+		{"parse", func() error { _, err := src.Segments(ctx); return err }, "failed to parse segments"},
+		{"mapping_missing_index", func() error { _, err := src.IndexMapping(ctx, "logs"); return err }, "mapping API error"},
+		{"mapping_no_mappings", func() error { _, err := src.IndexMapping(ctx, "other"); return err }, "no mappings found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("error = %v, want error containing %q", err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+// TestOpenSearch_ContextCancelled tests that requests honour ctx
+func TestOpenSearch_ContextCancelled(t *testing.T) {
+	src := newTestSource(t, map[string]string{"/_cluster/health": `{}`})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := src.ClusterHealth(ctx); err == nil {
+		t.Error("ClusterHealth() with a cancelled context should fail")
+	}
+}
+
+// TestOpenSearch_NodeDetail tests that node stats and info are combined
+func TestOpenSearch_NodeDetail(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_nodes/node-1/stats": `{"nodes":{"abc":{"name":"node-1","jvm":{"uptime_in_millis":60000,"gc":{"collectors":{"young":{"collection_count":5}}}},"breakers":{"parent":{"tripped":1}}}}}`,
+		"/_nodes/abc":          `{"nodes":{"abc":{"version":"2.11.0","jvm":{"version":"17.0.8"}}}}`,
+		"/_nodes/gone/stats":   `{"nodes":{}}`,
+	})
+	ctx := context.Background()
+
+	detail, err := src.NodeDetail(ctx, "node-1")
+	if err != nil {
+		t.Fatalf("NodeDetail() error = %v", err)
+	}
+	if detail.ID != "abc" || detail.Stats.Name != "node-1" {
+		t.Errorf("NodeDetail() = %+v, want node abc", detail)
+	}
+	if detail.Version != "2.11.0" || detail.JVMVersion != "17.0.8" {
+		t.Errorf("versions = %q/%q, want them from the nodes info API", detail.Version, detail.JVMVersion)
+	}
+	if detail.Stats.JVM.GC.Collectors["young"].CollectionCount != 5 || detail.Stats.Breakers["parent"].Tripped != 1 {
+		t.Errorf("stats not decoded: %+v", detail.Stats)
+	}
+
+	if _, err := src.NodeDetail(ctx, "gone"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing node error = %v, want not found", err)
+	}
+}
+
+// TestOpenSearch_IndexDetail tests the per-index settings, stats and alias APIs
+func TestOpenSearch_IndexDetail(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/logs/_settings": `{"logs":{"settings":{"index.number_of_shards":"3","index.query.default_field":["msg","host"]},"defaults":{"index.refresh_interval":"1s"}}}`,
+		"/logs/_stats":    `{"indices":{"logs":{"primaries":{"docs":{"count":10}},"total":{"docs":{"count":20},"query_cache":{"hit_count":4,"miss_count":1},"segments":{"count":7}}}}}`,
+		"/logs/_alias":    `{"logs":{"aliases":{"logs-write":{"is_write_index":true},"errors":{"filter":{"term":{"level":"error"}}}}}}`,
+	})
+	ctx := context.Background()
+
+	settings, err := src.IndexSettings(ctx, "logs", false)
+	if err != nil {
+		t.Fatalf("IndexSettings() error = %v", err)
+	}
+	if settings.Settings["index.number_of_shards"] != "3" || settings.Settings["index.query.default_field"] != "msg,host" {
+		t.Errorf("settings = %v", settings.Settings)
+	}
+	if settings.Defaults != nil {
+		t.Errorf("defaults should only be set when requested, got %v", settings.Defaults)
+	}
+	if settings, err := src.IndexSettings(ctx, "logs", true); err != nil || settings.Defaults["index.refresh_interval"] != "1s" {
+		t.Errorf("IndexSettings(defaults) = %+v, %v", settings, err)
+	}
+
+	stats, err := src.IndexStats(ctx, "logs")
+	if err != nil || stats.Primaries.Docs.Count != 10 || stats.Total.Docs.Count != 20 ||
+		stats.Total.QueryCache.HitCount != 4 || stats.Total.Segments.Count != 7 {
+		t.Errorf("IndexStats() = %+v, %v", stats, err)
+	}
+
+	aliases, err := src.IndexAliases(ctx, "logs")
+	if err != nil || len(aliases) != 2 {
+		t.Fatalf("IndexAliases() = %+v, %v", aliases, err)
+	}
+	if aliases[0].Name != "errors" || !aliases[0].Filter || !aliases[1].IsWriteIndex {
+		t.Errorf("aliases = %+v, want sorted with filter and write flags", aliases)
+	}
+
+	if _, err := src.IndexStats(ctx, "missing"); err == nil {
+		t.Error("IndexStats() for a missing index should fail")
+	}
+}
+
+// TestOpenSearch_ISMExplain tests the ISM explain API and its fallbacks
+func TestOpenSearch_ISMExplain(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_plugins/_ism/explain/logs": `{"logs":{"index":"logs","policy_id":"rollover","enabled":true,"state":{"name":"hot"},"action":{"name":"rollover","failed":true},"info":{"message":"Missing rollover_alias"}},"total_managed_indices":1}`,
+		"/_opendistro/_ism/explain":   `{"old":{"index.opendistro.index_state_management.policy_id":null},"total_managed_indices":0}`,
+	})
+	ctx := context.Background()
+
+	explained, err := src.ISMExplain(ctx, "logs")
+	if err != nil || len(explained) != 1 {
+		t.Fatalf("ISMExplain() = %+v, %v", explained, err)
+	}
+	if e := explained[0]; e.PolicyID != "rollover" || e.State.Name != "hot" || !e.Failed() || e.Info.Message == "" {
+		t.Errorf("explanation = %+v", e)
+	}
+
+	// The legacy Open Distro path is used when the plugins path is missing
+	explained, err = src.ISMExplain(ctx, "")
+	if err != nil || len(explained) != 1 || explained[0].Index != "old" || explained[0].PolicyID != "" {
+		t.Errorf("ISMExplain(all) = %+v, %v", explained, err)
+	}
+
+	if _, err := src.ISMExplain(ctx, "other"); !errors.Is(err, ErrISMUnavailable) {
+		t.Errorf("ISMExplain() without the plugin = %v, want ErrISMUnavailable", err)
+	}
+}
+
+// TestOpenSearch_ExplainUnassigned tests listing and explaining unassigned
+// shards
+func TestOpenSearch_ExplainUnassigned(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_cluster/state/routing_nodes": `{"routing_nodes":{"unassigned":[{"state":"UNASSIGNED","primary":true,"shard":0,"index":"logs"},{"state":"UNASSIGNED","primary":false,"shard":1,"index":"logs"}]}}`,
+		"/_cluster/allocation/explain":  `{"index":"logs","shard":0,"primary":true,"current_state":"unassigned","can_allocate":"no","node_allocation_decisions":[{"node_name":"node-1","node_decision":"no","deciders":[{"decider":"disk_threshold","decision":"NO","explanation":"low watermark"}]}]}`,
+	})
+	ctx := context.Background()
+
+	explanation, err := src.AllocationExplain(ctx, "logs", 0, true)
+	if err != nil || explanation.CanAllocate != "no" || len(explanation.NodeDecisions) != 1 {
+		t.Fatalf("AllocationExplain() = %+v, %v", explanation, err)
+	}
+	if d := explanation.NodeDecisions[0].Deciders; len(d) != 1 || d[0].Decider != "disk_threshold" {
+		t.Errorf("deciders = %+v", d)
+	}
+
+	report, err := src.ExplainUnassigned(ctx, 1)
+	if err != nil {
+		t.Fatalf("ExplainUnassigned() error = %v", err)
+	}
+	if report.Total != 2 || len(report.Explanations) != 1 {
+		t.Errorf("ExplainUnassigned(1) = total %d, %d explained; want 2, 1", report.Total, len(report.Explanations))
+	}
+}
+
+// TestOpenSearch_HotThreads tests parsing the hot threads text report
+func TestOpenSearch_HotThreads(t *testing.T) {
+	report := `::: {node-1}{abc}{def}{10.0.0.1}{10.0.0.1:9300}{dimr}
+   Hot threads at 2024-01-15T10:20:30.123Z, interval=500ms, busiestThreads=2, ignoreIdleThreads=true:
+
+   62.5% (312.4ms out of 500ms) cpu usage by thread 'opensearch[node-1][search][T#3]'
+     2/10 snapshots sharing following 2 elements
+       app//org.apache.lucene.util.PriorityQueue.pop(PriorityQueue.java:193)
+       java.base@17.0.8/java.lang.Thread.run(Thread.java:833)
+     8/10 snapshots sharing following 1 elements
+       app//org.apache.lucene.search.BooleanScorer.score(BooleanScorer.java:315)
+
+    0.0% (0s out of 500ms) cpu usage by thread 'opensearch[node-1][write][T#1]'
+     unique snapshot
+       app//org.apache.lucene.index.IndexWriter.updateDocuments(IndexWriter.java:1472)
+
+::: {node-2}{ghi}{jkl}{10.0.0.2}{10.0.0.2:9300}{dimr}
+   Hot threads at 2024-01-15T10:20:30.125Z, interval=500ms, busiestThreads=2, ignoreIdleThreads=true:
+`
+	src := newTestSource(t, map[string]string{"/_nodes/node-1/hot_threads": report})
+
+	nodes, err := src.HotThreads(context.Background(), HotThreadsOptions{Node: "node-1", Threads: 2, Type: "cpu"})
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("HotThreads() = %+v, %v", nodes, err)
+	}
+
+	node := nodes[0]
+	if node.Node != "node-1" || node.NodeID != "abc" || !strings.HasPrefix(node.Summary, "Hot threads at") {
+		t.Errorf("node = %+v", node)
+	}
+	if len(node.Threads) != 2 {
+		t.Fatalf("threads = %+v, want 2", node.Threads)
+	}
+
+	search := node.Threads[0]
+	if search.Name != "opensearch[node-1][search][T#3]" || search.Percent != 62.5 ||
+		search.Usage != "312.4ms out of 500ms" || search.Type != "cpu" {
+		t.Errorf("thread = %+v", search)
+	}
+	if len(search.Stacks) != 2 || len(search.Stacks[0].Frames) != 2 || search.Stacks[1].Snapshots != "8/10 snapshots sharing following 1 elements" {
+		t.Errorf("stacks = %+v", search.Stacks)
+	}
+	if write := node.Threads[1]; len(write.Stacks) != 1 || write.Stacks[0].Snapshots != "unique snapshot" {
+		t.Errorf("unique snapshot thread = %+v", write)
+	}
+
+	if nodes[1].Node != "node-2" || len(nodes[1].Threads) != 0 {
+		t.Errorf("idle node = %+v", nodes[1])
+	}
+
+	if _, err := src.HotThreads(context.Background(), HotThreadsOptions{Node: "missing"}); err == nil {
+		t.Error("HotThreads() should fail on an API error")
+	}
+}
+
+// TestOpenSearch_Snapshots tests listing repositories, their snapshots and
+// running snapshots
+func TestOpenSearch_Snapshots(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_snapshot":              `{"nightly":{"type":"s3"},"broken":{"type":"fs"}}`,
+		"/_snapshot/nightly/_all": `{"snapshots":[{"snapshot":"old","state":"SUCCESS","start_time_in_millis":1000,"end_time_in_millis":2000},{"snapshot":"new","state":"PARTIAL","start_time_in_millis":5000,"shards":{"total":3,"failed":1,"successful":2}}]}`,
+		"/_snapshot/_status":      `{"snapshots":[{"snapshot":"running","repository":"nightly","state":"STARTED","shards_stats":{"done":1,"total":3},"stats":{"processed":{"size_in_bytes":100},"total":{"size_in_bytes":400}}}]}`,
+	})
+
+	overview, err := src.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("Snapshots() error = %v", err)
+	}
+	if len(overview.Repositories) != 2 {
+		t.Fatalf("repositories = %+v, want 2", overview.Repositories)
+	}
+
+	// Repositories are sorted, and a broken one doesn't fail the rest
+	broken, nightly := overview.Repositories[0], overview.Repositories[1]
+	if broken.Name != "broken" || broken.Error == "" {
+		t.Errorf("broken repository = %+v, want a listing error", broken)
+	}
+	if nightly.Type != "s3" || len(nightly.Snapshots) != 2 || nightly.Snapshots[0].Snapshot != "new" {
+		t.Errorf("nightly = %+v, want two snapshots newest first", nightly)
+	}
+	if s := nightly.Snapshots[0]; s.Shards.Failed != 1 || !s.EndTime().IsZero() {
+		t.Errorf("running snapshot = %+v", s)
+	}
+	if s := nightly.Snapshots[1]; s.EndTime().UnixMilli() != 2000 {
+		t.Errorf("EndTime() = %v", s.EndTime())
+	}
+
+	if len(overview.InProgress) != 1 || overview.InProgress[0].Stats.Total.SizeInBytes != 400 {
+		t.Errorf("in progress = %+v", overview.InProgress)
+	}
+}
+
+// TestOpenSearch_ISM tests listing ISM policies and managed indices, and a
+// cluster without the plugin
+func TestOpenSearch_ISM(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_plugins/_ism/policies": `{"policies":[
+			{"_id":"rollover","policy":{"description":"Roll over","default_state":"hot","states":[{"name":"hot"},{"name":"delete"}],"ism_template":[{"index_patterns":["logs-*"],"priority":1}]}},
+			{"_id":"legacy","policy":{"default_state":"open","states":[{"name":"open"}],"ism_template":{"index_patterns":["old-*"]}}}
+		],"total_policies":2}`,
+		"/_plugins/_ism/explain": `{"logs-1":{"index":"logs-1","policy_id":"rollover","state":{"name":"hot"}},"plain":{"index.plugins.index_state_management.policy_id":null},"total_managed_indices":1}`,
+	})
+
+	overview, err := src.ISM(context.Background())
+	if err != nil {
+		t.Fatalf("ISM() error = %v", err)
+	}
+	if !overview.Available || len(overview.Policies) != 2 {
+		t.Fatalf("overview = %+v, want two policies", overview)
+	}
+
+	// Policies are sorted, and both ISM template shapes are understood
+	legacy, rollover := overview.Policies[0], overview.Policies[1]
+	if legacy.ID != "legacy" || len(legacy.IndexPatterns) != 1 || legacy.IndexPatterns[0] != "old-*" {
+		t.Errorf("legacy policy = %+v", legacy)
+	}
+	if rollover.DefaultState != "hot" || len(rollover.States) != 2 || rollover.IndexPatterns[0] != "logs-*" {
+		t.Errorf("rollover policy = %+v", rollover)
+	}
+
+	// Indices without a policy aren't managed
+	if len(overview.Managed) != 1 || overview.Managed[0].Index != "logs-1" {
+		t.Errorf("managed = %+v, want only logs-1", overview.Managed)
+	}
+
+	// Neither the plugin nor the Open Distro path answers
+	overview, err = newTestSource(t, nil).ISM(context.Background())
+	if err != nil || overview.Available {
+		t.Errorf("ISM() without the plugin = %+v, %v, want an unavailable overview", overview, err)
+	}
+}
+
+// TestOpenSearch_Aliases tests grouping CAT aliases rows and listing data
+// streams with their sizes
+func TestOpenSearch_Aliases(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_cat/aliases": `[
+			{"alias":"logs","index":"logs-2","filter":"-","is_write_index":"true"},
+			{"alias":"logs","index":"logs-1","filter":"-","is_write_index":"false"},
+			{"alias":"errors","index":"logs-1","filter":"*","is_write_index":"-"}
+		]`,
+		"/_data_stream":        `{"data_streams":[{"name":"metrics","timestamp_field":{"name":"@timestamp"},"indices":[{"index_name":".ds-metrics-000001"},{"index_name":".ds-metrics-000002"}],"generation":2,"status":"YELLOW","template":"metrics-tpl"}]}`,
+		"/_data_stream/_stats": `{"data_streams":[{"data_stream":"metrics","backing_indices":2,"store_size_bytes":2048}]}`,
+	})
+
+	overview, err := src.Aliases(context.Background())
+	if err != nil {
+		t.Fatalf("Aliases() error = %v", err)
+	}
+
+	if len(overview.Aliases) != 2 {
+		t.Fatalf("aliases = %+v, want 2", overview.Aliases)
+	}
+	errs, logs := overview.Aliases[0], overview.Aliases[1]
+	// A single-index alias writes to its index without is_write_index
+	if errs.Name != "errors" || !errs.Filtered || errs.WriteIndex != "logs-1" {
+		t.Errorf("errors alias = %+v", errs)
+	}
+	if logs.WriteIndex != "logs-2" || len(logs.Indices) != 2 || logs.Indices[0] != "logs-1" || logs.Filtered {
+		t.Errorf("logs alias = %+v", logs)
+	}
+
+	if len(overview.DataStreams) != 1 {
+		t.Fatalf("data streams = %+v, want 1", overview.DataStreams)
+	}
+	stream := overview.DataStreams[0]
+	if stream.Generation != 2 || stream.StoreSizeBytes != 2048 || stream.TimestampField != "@timestamp" ||
+		stream.Template != "metrics-tpl" || stream.WriteIndex() != ".ds-metrics-000002" {
+		t.Errorf("data stream = %+v", stream)
+	}
+
+	if _, err := newTestSource(t, nil).Aliases(context.Background()); err == nil {
+		t.Error("Aliases() should fail on an API error")
+	}
+}
+
+// TestOpenSearch_IndexTemplates tests listing composable and component
+// templates and simulating one
+func TestOpenSearch_IndexTemplates(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_index_template": `{"index_templates":[
+			{"name":"plain","index_template":{"index_patterns":["plain-*"],"priority":1}},
+			{"name":"logs","index_template":{"index_patterns":["logs-*"],"priority":10,"version":2,"composed_of":["base"],"data_stream":{},
+				"template":{"settings":{"index":{"number_of_shards":"2","routing":{"allocation":{"require":{"zone":"a"}}}}},"aliases":{"b":{},"a":{}}}}}
+		]}`,
+		"/_component_template": `{"component_templates":[{"name":"base","component_template":{"version":1,"template":{"mappings":{"properties":{"msg":{"type":"text"}}}}}}]}`,
+		"/_index_template/_simulate/logs": `{"template":{"settings":{"index":{"number_of_shards":"2"}},"mappings":{"properties":{"msg":{"type":"text"}}},"aliases":{}},
+			"overlapping":[{"name":"plain","index_patterns":["plain-*"]}]}`,
+	})
+	ctx := context.Background()
+
+	overview, err := src.IndexTemplates(ctx)
+	if err != nil {
+		t.Fatalf("IndexTemplates() error = %v", err)
+	}
+	if len(overview.IndexTemplates) != 2 || len(overview.ComponentTemplates) != 1 {
+		t.Fatalf("overview = %+v", overview)
+	}
+
+	logs := overview.IndexTemplates[0]
+	if logs.Name != "logs" || logs.Priority != 10 || logs.Version != 2 || !logs.DataStream || logs.ComposedOf[0] != "base" {
+		t.Errorf("logs template = %+v", logs)
+	}
+	// Nested settings are flattened and aliases sorted
+	if logs.Template.Settings["index.routing.allocation.require.zone"] != "a" || logs.Template.Settings["index.number_of_shards"] != "2" {
+		t.Errorf("settings = %v", logs.Template.Settings)
+	}
+	if len(logs.Template.Aliases) != 2 || logs.Template.Aliases[0] != "a" {
+		t.Errorf("aliases = %v", logs.Template.Aliases)
+	}
+	if overview.IndexTemplates[1].DataStream {
+		t.Error("a template without data_stream isn't a data stream template")
+	}
+	if base := overview.ComponentTemplates[0]; base.Version != 1 || base.Template.Mappings["properties"] == nil {
+		t.Errorf("component template = %+v", base)
+	}
+
+	simulated, err := src.SimulateIndexTemplate(ctx, "logs")
+	if err != nil {
+		t.Fatalf("SimulateIndexTemplate() error = %v", err)
+	}
+	if simulated.Template.Settings["index.number_of_shards"] != "2" || len(simulated.Overlapping) != 1 || simulated.Overlapping[0].Name != "plain" {
+		t.Errorf("simulated = %+v", simulated)
+	}
+
+	if _, err := src.SimulateIndexTemplate(ctx, "missing"); err == nil {
+		t.Error("SimulateIndexTemplate() should fail on an API error")
+	}
+}
+
+// TestOpenSearch_Ingest tests summing pipeline stats over nodes and
+// simulating a pipeline
+func TestOpenSearch_Ingest(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_ingest/pipeline": `{
+			"logs":{"description":"Parse logs","processors":[{"grok":{"field":"message"}},{"set":{"field":"env","value":"prod","tag":"env"}}]},
+			"unused":{"processors":[{"remove":{"field":"tmp"}}]}
+		}`,
+		"/_nodes/stats/ingest": `{"nodes":{
+			"n1":{"ingest":{"pipelines":{"logs":{"count":10,"time_in_millis":40,"current":1,"failed":2,"processors":[
+				{"grok":{"type":"grok","stats":{"count":10,"time_in_millis":30,"current":1,"failed":2}}},
+				{"set:env":{"type":"set","stats":{"count":8,"time_in_millis":5,"current":0,"failed":0}}}]}}}},
+			"n2":{"ingest":{"pipelines":{"logs":{"count":5,"time_in_millis":20,"current":0,"failed":0,"processors":[
+				{"grok":{"type":"grok","stats":{"count":5,"time_in_millis":15,"current":0,"failed":0}}},
+				{"set:env":{"type":"set","stats":{"count":5,"time_in_millis":2,"current":0,"failed":0}}}]}}}}
+		}}`,
+		"/_ingest/pipeline/logs/_simulate": `{"docs":[
+			{"doc":{"_index":"_index","_source":{"message":"hi","env":"prod"}}},
+			{"error":{"type":"illegal_argument_exception","reason":"field [message] not present"}},
+			null
+		]}`,
+	})
+	ctx := context.Background()
+
+	overview, err := src.Ingest(ctx)
+	if err != nil {
+		t.Fatalf("Ingest() error = %v", err)
+	}
+	if len(overview.Pipelines) != 2 {
+		t.Fatalf("pipelines = %+v", overview.Pipelines)
+	}
+
+	logs := overview.Pipelines[0]
+	want := IngestStats{Count: 15, TimeMillis: 60, Current: 1, Failed: 2}
+	if logs.ID != "logs" || logs.Description != "Parse logs" || logs.Stats != want {
+		t.Errorf("logs pipeline = %+v", logs)
+	}
+	if len(logs.Processors) != 2 || logs.Processors[0].Stats.TimeMillis != 45 || logs.Processors[1].Name() != "set:env" || logs.Processors[1].Stats.Count != 13 {
+		t.Errorf("logs processors = %+v", logs.Processors)
+	}
+	// A pipeline no node has run yet still shows with zero stats
+	if unused := overview.Pipelines[1]; unused.Stats.Count != 0 || len(unused.Processors) != 1 {
+		t.Errorf("unused pipeline = %+v", unused)
+	}
+
+	docs, err := src.SimulatePipeline(ctx, "logs", []json.RawMessage{json.RawMessage(`{"message":"hi"}`)})
+	if err != nil {
+		t.Fatalf("SimulatePipeline() error = %v", err)
+	}
+	if len(docs) != 3 || docs[0].Source["env"] != "prod" || !strings.Contains(docs[1].Error, "not present") || !docs[2].Dropped {
+		t.Errorf("simulated docs = %+v", docs)
+	}
+
+	if _, err := src.SimulatePipeline(ctx, "missing", []json.RawMessage{json.RawMessage(`{}`)}); err == nil {
+		t.Error("SimulatePipeline() should fail on an API error")
+	}
+}
+
+// TestOpenSearch_ClusterSettings tests that persistent, transient and
+// default settings are kept apart
+func TestOpenSearch_ClusterSettings(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_cluster/settings": `{
+			"persistent":{"cluster.routing.allocation.enable":"primaries"},
+			"transient":{"indices.recovery.max_bytes_per_sec":"200mb"},
+			"defaults":{"cluster.routing.allocation.enable":"all","cluster.routing.allocation.awareness.attributes":["zone","rack"]}
+		}`,
+	})
+
+	settings, err := src.ClusterSettings(context.Background())
+	if err != nil {
+		t.Fatalf("ClusterSettings() error = %v", err)
+	}
+	if settings.Persistent["cluster.routing.allocation.enable"] != "primaries" || settings.Defaults["cluster.routing.allocation.enable"] != "all" {
+		t.Errorf("settings = %+v", settings)
+	}
+	if settings.Transient["indices.recovery.max_bytes_per_sec"] != "200mb" {
+		t.Errorf("transient = %v", settings.Transient)
+	}
+	if settings.Defaults["cluster.routing.allocation.awareness.attributes"] != "zone,rack" {
+		t.Errorf("list settings should be comma separated, got %q", settings.Defaults["cluster.routing.allocation.awareness.attributes"])
+	}
+}
+
+// TestOpenSearch_NodeMemory tests that breakers, caches and segments are
+// read per node and sorted by name
+func TestOpenSearch_NodeMemory(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_nodes/stats/breaker,indices/query_cache,request_cache,fielddata,segments": `{"nodes":{
+			"b1":{"name":"node-2","breakers":{"parent":{"limit_size_in_bytes":1000,"estimated_size_in_bytes":900,"overhead":1.0,"tripped":3}},
+				"indices":{"fielddata":{"memory_size_in_bytes":50,"evictions":7},"segments":{"count":12,"memory_in_bytes":4096}}},
+			"a1":{"name":"node-1","breakers":{},"indices":{"query_cache":{"memory_size_in_bytes":10,"evictions":2,"hit_count":5,"miss_count":1}}}
+		}}`,
+	})
+
+	nodes, err := src.NodeMemory(context.Background())
+	if err != nil {
+		t.Fatalf("NodeMemory() error = %v", err)
+	}
+	if len(nodes) != 2 || nodes[0].Name != "node-1" || nodes[1].ID != "b1" {
+		t.Fatalf("nodes = %+v, want node-1 then node-2", nodes)
+	}
+	if nodes[0].QueryCache.Evictions != 2 || nodes[0].QueryCache.HitCount != 5 {
+		t.Errorf("query cache = %+v", nodes[0].QueryCache)
+	}
+	if breaker := nodes[1].Breakers["parent"]; breaker.Tripped != 3 || breaker.EstimatedSizeInBytes != 900 {
+		t.Errorf("parent breaker = %+v", breaker)
+	}
+	if nodes[1].Fielddata.Evictions != 7 || nodes[1].Segments.MemoryInBytes != 4096 {
+		t.Errorf("node-2 = %+v", nodes[1])
+	}
+}
+
+// TestOpenSearch_IndexActivity tests that indexing and search counters come
+// from primaries, matching the cluster activity stats
+func TestOpenSearch_IndexActivity(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_stats/indexing,search": `{"indices":{
+			"logs":{"primaries":{"indexing":{"index_total":100,"index_time_in_millis":50},"search":{"query_total":8,"query_time_in_millis":40}},
+				"total":{"indexing":{"index_total":200},"search":{"query_total":24,"query_time_in_millis":120}}},
+			"apps":{"primaries":{},"total":{"search":{"query_total":3}}}
+		}}`,
+	})
+
+	indices, err := src.IndexActivity(context.Background())
+	if err != nil {
+		t.Fatalf("IndexActivity() error = %v", err)
+	}
+	if len(indices) != 2 || indices[0].Index != "apps" {
+		t.Fatalf("indices = %+v, want apps then logs", indices)
+	}
+	want := IndexActivity{Index: "logs", IndexTotal: 100, IndexTimeInMillis: 50, QueryTotal: 8, QueryTimeInMillis: 40}
+	if indices[1] != want {
+		t.Errorf("logs = %+v, want %+v", indices[1], want)
+	}
+}
+
+// TestOpenSearch_IndexActivity_Named tests that naming indices only asks
+// for their stats
+func TestOpenSearch_IndexActivity_Named(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/logs,apps/_stats/indexing,search": `{"indices":{"logs":{"primaries":{"indexing":{"index_total":7}}}}}`,
+	})
+
+	indices, err := src.IndexActivity(context.Background(), "logs", "apps")
+	if err != nil {
+		t.Fatalf("IndexActivity() error = %v", err)
+	}
+	if len(indices) != 1 || indices[0].IndexTotal != 7 {
+		t.Errorf("indices = %+v, want logs from the named request", indices)
+	}
+}
+
+// TestOpenSearch_NodeActivity tests that node counters, heap, old-gen GC and
+// CPU are read per node
+func TestOpenSearch_NodeActivity(t *testing.T) {
+	src := newTestSource(t, map[string]string{
+		"/_nodes/stats/indices,jvm,os/indexing,search": `{"nodes":{
+			"n1":{"name":"node-1",
+				"indices":{"indexing":{"index_total":500},"search":{"query_total":40}},
+				"jvm":{"mem":{"heap_used_in_bytes":300,"heap_max_in_bytes":1000},
+					"gc":{"collectors":{"young":{"collection_count":90},"old":{"collection_count":3,"collection_time_in_millis":120}}}},
+				"os":{"cpu":{"percent":17}}}
+		}}`,
+	})
+
+	nodes, err := src.NodeActivity(context.Background())
+	if err != nil {
+		t.Fatalf("NodeActivity() error = %v", err)
+	}
+	want := NodeActivity{ID: "n1", Name: "node-1", IndexTotal: 500, QueryTotal: 40, HeapUsedInBytes: 300, HeapMaxInBytes: 1000,
+		OldGCCount: 3, OldGCTimeInMillis: 120, CPUPercent: 17}
+	if len(nodes) != 1 || nodes[0] != want {
+		t.Errorf("nodes = %+v, want [%+v]", nodes, want)
+	}
+}
@@ -0,0 +1,182 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SnapshotOverview is every snapshot repository with its snapshots, plus
+// the snapshots currently running
+type SnapshotOverview struct {
+	Repositories []SnapshotRepository
+	InProgress   []SnapshotStatus
+}
+
+// SnapshotRepository is a registered snapshot repository. Error is set when
+// its snapshots couldn't be listed, e.g. because the repository is broken.
+type SnapshotRepository struct {
+	Name      string
+	Type      string
+	Snapshots []SnapshotInfo // Newest first
+	Error     string
+}
+
+// SnapshotInfo is one snapshot from the get snapshot API
+type SnapshotInfo struct {
+	Snapshot          string   `json:"snapshot"`
+	UUID              string   `json:"uuid"`
+	State             string   `json:"state"` // IN_PROGRESS, SUCCESS, FAILED, PARTIAL
+	Indices           []string `json:"indices"`
+	StartTimeInMillis int64    `json:"start_time_in_millis"`
+	EndTimeInMillis   int64    `json:"end_time_in_millis"`
+	DurationInMillis  int64    `json:"duration_in_millis"`
+	Shards            struct {
+		Total      int `json:"total"`
+		Failed     int `json:"failed"`
+		Successful int `json:"successful"`
+	} `json:"shards"`
+}
+
+// StartTime returns when the snapshot started
+func (s SnapshotInfo) StartTime() time.Time {
+	return time.UnixMilli(s.StartTimeInMillis)
+}
+
+// EndTime returns when the snapshot finished, or the zero time while it's
+// running
+func (s SnapshotInfo) EndTime() time.Time {
+	if s.EndTimeInMillis == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(s.EndTimeInMillis)
+}
+
+// SnapshotStatus is a running snapshot's progress from the snapshot status
+// API
+type SnapshotStatus struct {
+	Snapshot    string `json:"snapshot"`
+	Repository  string `json:"repository"`
+	State       string `json:"state"`
+	ShardsStats struct {
+		Initializing int `json:"initializing"`
+		Started      int `json:"started"`
+		Finalizing   int `json:"finalizing"`
+		Done         int `json:"done"`
+		Failed       int `json:"failed"`
+		Total        int `json:"total"`
+	} `json:"shards_stats"`
+	Stats struct {
+		Processed struct {
+			FileCount   int64 `json:"file_count"`
+			SizeInBytes int64 `json:"size_in_bytes"`
+		} `json:"processed"`
+		Total struct {
+			FileCount   int64 `json:"file_count"`
+			SizeInBytes int64 `json:"size_in_bytes"`
+		} `json:"total"`
+		StartTimeInMillis int64 `json:"start_time_in_millis"`
+		TimeInMillis      int64 `json:"time_in_millis"`
+	} `json:"stats"`
+}
+
+// Snapshots lists the snapshot repositories, each repository's snapshots and
+// the snapshots in progress
+func (o *OpenSearch) Snapshots(ctx context.Context) (*SnapshotOverview, error) {
+	res, err := o.client.Snapshot.GetRepository(o.client.Snapshot.GetRepository.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("snapshot repositories request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("snapshot repositories API error: %s", res.Status())
+	}
+
+	var repos map[string]struct {
+		Type string `json:"type"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&repos); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot repositories: %w", err)
+	}
+
+	overview := &SnapshotOverview{}
+	for _, name := range sortedNames(repos) {
+		repo := SnapshotRepository{Name: name, Type: repos[name].Type}
+
+		// A broken repository shouldn't hide the others
+		snapshots, err := o.repositorySnapshots(ctx, name)
+		if err != nil {
+			repo.Error = err.Error()
+		}
+		repo.Snapshots = snapshots
+		overview.Repositories = append(overview.Repositories, repo)
+	}
+
+	overview.InProgress, err = o.snapshotStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return overview, nil
+}
+
+// repositorySnapshots lists a repository's snapshots, newest first
+func (o *OpenSearch) repositorySnapshots(ctx context.Context, repo string) ([]SnapshotInfo, error) {
+	res, err := o.client.Snapshot.Get(repo, []string{"_all"}, o.client.Snapshot.Get.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("snapshots request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("snapshots API error: %s", res.Status())
+	}
+
+	var response struct {
+		Snapshots []SnapshotInfo `json:"snapshots"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshots: %w", err)
+	}
+
+	snapshots := response.Snapshots
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].StartTimeInMillis > snapshots[j].StartTimeInMillis
+	})
+	return snapshots, nil
+}
+
+// snapshotStatus returns the snapshots currently running in any repository
+func (o *OpenSearch) snapshotStatus(ctx context.Context) ([]SnapshotStatus, error) {
+	res, err := o.client.Snapshot.Status(o.client.Snapshot.Status.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("snapshot status request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("snapshot status API error: %s", res.Status())
+	}
+
+	var response struct {
+		Snapshots []SnapshotStatus `json:"snapshots"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot status: %w", err)
+	}
+
+	return response.Snapshots, nil
+}
+
+// sortedNames returns a map's keys in order
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
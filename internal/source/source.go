@@ -0,0 +1,129 @@
+// Package source defines where ostop gets its cluster data from. The UI only
+// talks to a ClusterSource, so the live OpenSearch client can be swapped for
+// other backends such as a replayed diagnostic bundle or a caching layer.
+package source
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ClusterSource provides typed cluster data. Implementations must be safe
+// for concurrent use and should honour ctx cancellation.
+type ClusterSource interface {
+	// ClusterHealth returns the cluster status and shard counts
+	ClusterHealth(ctx context.Context) (*ClusterHealth, error)
+
+	// ClusterStats returns cluster-wide index, document and node counts
+	ClusterStats(ctx context.Context) (*ClusterStats, error)
+
+	// Nodes returns every node's roles and heap, CPU, load and disk use
+	Nodes(ctx context.Context) ([]NodeInfo, error)
+
+	// Indices returns every index's health, shard counts, documents and size
+	Indices(ctx context.Context) ([]IndexInfo, error)
+
+	// Shards returns every shard copy with its state and node
+	Shards(ctx context.Context) ([]ShardInfo, error)
+
+	// Allocation returns each node's shard count and disk use
+	Allocation(ctx context.Context) ([]AllocationInfo, error)
+
+	// ThreadPool returns the active, queued and rejected counts of every
+	// thread pool on every node
+	ThreadPool(ctx context.Context) ([]ThreadPoolInfo, error)
+
+	// Tasks returns the tasks currently running on the cluster
+	Tasks(ctx context.Context) ([]TaskInfo, error)
+
+	// PendingTasks returns the cluster-level changes waiting on the master
+	PendingTasks(ctx context.Context) ([]PendingTaskInfo, error)
+
+	// Recovery returns the shard recoveries in progress
+	Recovery(ctx context.Context) ([]RecoveryInfo, error)
+
+	// Segments returns the Lucene segments of every shard
+	Segments(ctx context.Context) ([]SegmentInfo, error)
+
+	// Fielddata returns the fielddata memory held per field on each node
+	Fielddata(ctx context.Context) ([]FielddataInfo, error)
+
+	// Plugins returns the plugins installed on each node
+	Plugins(ctx context.Context) ([]PluginInfo, error)
+
+	// Templates returns the index templates with their patterns and order
+	Templates(ctx context.Context) ([]TemplateInfo, error)
+
+	// IndexMapping returns the field mappings of a single index
+	IndexMapping(ctx context.Context, index string) (*IndexMapping, error)
+
+	// NodeDetail returns stats and build info for a single node, by ID or name
+	NodeDetail(ctx context.Context, node string) (*NodeDetail, error)
+
+	// IndexSettings returns an index's flat settings, optionally with defaults
+	IndexSettings(ctx context.Context, index string, includeDefaults bool) (*IndexSettings, error)
+
+	// IndexStats returns the stats of a single index
+	IndexStats(ctx context.Context, index string) (*IndexStats, error)
+
+	// IndexAliases returns the aliases pointing at an index
+	IndexAliases(ctx context.Context, index string) ([]IndexAlias, error)
+
+	// ISMExplain returns the ISM policy state of an index, or of every
+	// managed index when index is empty. It returns ErrISMUnavailable when
+	// the plugin isn't installed.
+	ISMExplain(ctx context.Context, index string) ([]ISMExplanation, error)
+
+	// ISM returns the ISM policies and every managed index's state. A
+	// cluster without the plugin gives an overview that isn't Available.
+	ISM(ctx context.Context) (*ISMOverview, error)
+
+	// AllocationExplain explains why a shard copy is or isn't allocated
+	AllocationExplain(ctx context.Context, index string, shard int, primary bool) (*AllocationExplanation, error)
+
+	// ExplainUnassigned explains up to limit of the unassigned shard copies
+	ExplainUnassigned(ctx context.Context, limit int) (*UnassignedReport, error)
+
+	// HotThreads samples the busiest threads of every node, or of one node
+	HotThreads(ctx context.Context, opts HotThreadsOptions) ([]NodeHotThreads, error)
+
+	// Snapshots returns the snapshot repositories, their snapshots and the
+	// snapshots in progress
+	Snapshots(ctx context.Context) (*SnapshotOverview, error)
+
+	// ClusterSettings returns the persistent, transient and default cluster
+	// settings
+	ClusterSettings(ctx context.Context) (*ClusterSettings, error)
+
+	// IndexTemplates returns the composable and component templates
+	IndexTemplates(ctx context.Context) (*TemplateOverview, error)
+
+	// SimulateIndexTemplate resolves an index template with its component
+	// templates
+	SimulateIndexTemplate(ctx context.Context, name string) (*SimulatedTemplate, error)
+
+	// Aliases returns every alias and data stream with their indices
+	Aliases(ctx context.Context) (*AliasOverview, error)
+
+	// Ingest returns the ingest pipelines with their stats summed over all
+	// nodes
+	Ingest(ctx context.Context) (*IngestOverview, error)
+
+	// SimulatePipeline runs sample documents through an ingest pipeline
+	SimulatePipeline(ctx context.Context, id string, docs []json.RawMessage) ([]SimulatedDoc, error)
+
+	// NodeMemory returns every node's circuit breakers and the heap held by
+	// its caches and segments
+	NodeMemory(ctx context.Context) ([]NodeMemory, error)
+
+	// NodeActivity returns every node's cumulative indexing, search and GC
+	// counters with its current heap and CPU use
+	NodeActivity(ctx context.Context) ([]NodeActivity, error)
+
+	// ActivityStats returns cluster-wide cumulative indexing and search counters
+	ActivityStats(ctx context.Context) (*ActivityStats, error)
+
+	// IndexActivity returns the cumulative indexing and search counters of
+	// the named indices, or of every index when none are named
+	IndexActivity(ctx context.Context, names ...string) ([]IndexActivity, error)
+}
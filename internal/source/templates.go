@@ -0,0 +1,204 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// TemplateOverview is the cluster's composable index templates and the
+// component templates they're built from
+type TemplateOverview struct {
+	IndexTemplates     []IndexTemplate
+	ComponentTemplates []ComponentTemplate
+}
+
+// TemplateBody is what a template applies to a new index
+type TemplateBody struct {
+	Settings map[string]string // Flat, e.g. "index.number_of_shards"
+	Mappings map[string]interface{}
+	Aliases  []string
+}
+
+// IndexTemplate is a composable index template from the _index_template API
+type IndexTemplate struct {
+	Name          string
+	IndexPatterns []string
+	Priority      int64
+	Version       int64
+	ComposedOf    []string
+	DataStream    bool
+	Template      TemplateBody
+}
+
+// ComponentTemplate is a reusable building block of index templates
+type ComponentTemplate struct {
+	Name     string
+	Version  int64
+	Template TemplateBody
+}
+
+// SimulatedTemplate is an index template resolved with its component
+// templates, as an index created from it would get it
+type SimulatedTemplate struct {
+	Template    TemplateBody
+	Overlapping []TemplateOverlap // Lower priority templates it overrides
+}
+
+// TemplateOverlap is another template matching some of the same indices
+type TemplateOverlap struct {
+	Name          string   `json:"name"`
+	IndexPatterns []string `json:"index_patterns"`
+}
+
+// templateBodyJSON is a template body as the template APIs return it
+type templateBodyJSON struct {
+	Settings map[string]interface{}     `json:"settings"`
+	Mappings map[string]interface{}     `json:"mappings"`
+	Aliases  map[string]json.RawMessage `json:"aliases"`
+}
+
+// body converts the API form to a TemplateBody with flat settings
+func (t templateBodyJSON) body() TemplateBody {
+	flat := make(map[string]interface{})
+	flattenNested(t.Settings, "", flat)
+	return TemplateBody{
+		Settings: flattenValues(flat),
+		Mappings: t.Mappings,
+		Aliases:  sortedNames(t.Aliases),
+	}
+}
+
+// flattenNested copies nested settings into flat, joining keys with dots
+func flattenNested(values map[string]interface{}, prefix string, flat map[string]interface{}) {
+	for key, value := range values {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenNested(nested, prefix+key+".", flat)
+			continue
+		}
+		flat[prefix+key] = value
+	}
+}
+
+// IndexTemplates lists the composable index templates and component
+// templates
+func (o *OpenSearch) IndexTemplates(ctx context.Context) (*TemplateOverview, error) {
+	res, err := o.client.Indices.GetIndexTemplate(o.client.Indices.GetIndexTemplate.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("index templates request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("index templates API error: %s", res.Status())
+	}
+
+	var response struct {
+		IndexTemplates []struct {
+			Name          string `json:"name"`
+			IndexTemplate struct {
+				IndexPatterns []string         `json:"index_patterns"`
+				Priority      int64            `json:"priority"`
+				Version       int64            `json:"version"`
+				ComposedOf    []string         `json:"composed_of"`
+				DataStream    *json.RawMessage `json:"data_stream"`
+				Template      templateBodyJSON `json:"template"`
+			} `json:"index_template"`
+		} `json:"index_templates"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse index templates: %w", err)
+	}
+
+	overview := &TemplateOverview{}
+	for _, t := range response.IndexTemplates {
+		overview.IndexTemplates = append(overview.IndexTemplates, IndexTemplate{
+			Name:          t.Name,
+			IndexPatterns: t.IndexTemplate.IndexPatterns,
+			Priority:      t.IndexTemplate.Priority,
+			Version:       t.IndexTemplate.Version,
+			ComposedOf:    t.IndexTemplate.ComposedOf,
+			DataStream:    t.IndexTemplate.DataStream != nil,
+			Template:      t.IndexTemplate.Template.body(),
+		})
+	}
+	sort.Slice(overview.IndexTemplates, func(i, j int) bool {
+		return overview.IndexTemplates[i].Name < overview.IndexTemplates[j].Name
+	})
+
+	overview.ComponentTemplates, err = o.componentTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return overview, nil
+}
+
+// componentTemplates lists the component templates
+func (o *OpenSearch) componentTemplates(ctx context.Context) ([]ComponentTemplate, error) {
+	res, err := o.client.Cluster.GetComponentTemplate(o.client.Cluster.GetComponentTemplate.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("component templates request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("component templates API error: %s", res.Status())
+	}
+
+	var response struct {
+		ComponentTemplates []struct {
+			Name              string `json:"name"`
+			ComponentTemplate struct {
+				Version  int64            `json:"version"`
+				Template templateBodyJSON `json:"template"`
+			} `json:"component_template"`
+		} `json:"component_templates"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse component templates: %w", err)
+	}
+
+	components := make([]ComponentTemplate, 0, len(response.ComponentTemplates))
+	for _, c := range response.ComponentTemplates {
+		components = append(components, ComponentTemplate{
+			Name:     c.Name,
+			Version:  c.ComponentTemplate.Version,
+			Template: c.ComponentTemplate.Template.body(),
+		})
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i].Name < components[j].Name })
+
+	return components, nil
+}
+
+// SimulateIndexTemplate resolves an index template with its component
+// templates through the simulate API
+func (o *OpenSearch) SimulateIndexTemplate(ctx context.Context, name string) (*SimulatedTemplate, error) {
+	res, err := o.client.Indices.SimulateTemplate(
+		o.client.Indices.SimulateTemplate.WithName(name),
+		o.client.Indices.SimulateTemplate.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("simulate template request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("simulate template API error: %s", res.Status())
+	}
+
+	var response struct {
+		Template    templateBodyJSON  `json:"template"`
+		Overlapping []TemplateOverlap `json:"overlapping"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse simulated template: %w", err)
+	}
+
+	return &SimulatedTemplate{
+		Template:    response.Template.body(),
+		Overlapping: response.Overlapping,
+	}, nil
+}
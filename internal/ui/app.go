@@ -6,16 +6,19 @@ import (
 	"log"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	opensearch "github.com/opensearch-project/opensearch-go/v2"
+	"github.com/vegasq/ostop/internal/source"
 )
 
 // App is the main Bubble Tea model
 type App struct {
-	client            *opensearch.Client
+	source            source.ClusterSource
 	endpoint          string
+	authMode          string
 	health            *ClusterHealth
 	stats             *ClusterStats
 	nodes             []NodeInfo
@@ -30,9 +33,35 @@ type App struct {
 	fielddata         []FielddataInfo
 	plugins           []PluginInfo
 	templates         []TemplateInfo
+	unassigned        *UnassignedReport
+	snapshots         *SnapshotOverview
+	snapshotMaxAge    time.Duration // Warn when the newest successful snapshot is older
+	ism               *ISMOverview
+	ismFailedOnly     bool // Index Management view lists only failed indices
+	aliases           *AliasOverview
+	selectedAlias     int // Cursor over the indices behind aliases and data streams
+	indexTemplates    *TemplateOverview
+	selectedTemplate  int    // Cursor over the Templates view's composable templates
+	templateDetail    string // Composable template shown in the template drill-down
+	templateSim       *SimulatedTemplate
+	templateSimErr    error
+	ingest            *IngestOverview
+	selectedPipeline  int            // Cursor over the Ingest view's pipelines
+	ingestDetail      string         // Pipeline shown in the simulate drill-down
+	ingestInput       textarea.Model // Sample document editor of the simulate drill-down
+	ingestResult      []SimulatedDoc
+	ingestSimErr      error
+	ingestSimRunning  bool
+	clusterSettings   *ClusterSettings
+	settingsFilter    textinput.Model // Filter of the Cluster Settings view
+	settingsFiltering bool            // Filter input has focus
+	settingsChanged   bool            // Cluster Settings view lists only settings changed from the default
 	loading           bool
 	err               error
 	lastRefresh       time.Time
+	sourceErrs        map[DataSource]error     // Last error per data source, cleared on success
+	sourceUpdated     map[DataSource]time.Time // Last successful fetch per data source
+	sourcePending     map[DataSource]bool      // Sources with a fetch in flight
 	currentView       View
 	activePanel       Panel
 	selectedItem      int
@@ -40,9 +69,30 @@ type App struct {
 	height            int
 	leftPanelWidth    int
 	selectedNode      int
+	selectedNodeName  string
+	nodeDetail        *NodeDetail
+	nodeDetailErr     error
 	selectedIndex     int
 	selectedIndexName string
+	indexFromAliases  bool // Index drill-down was opened from the Aliases view
 	indexMapping      *IndexMapping
+	indexTab          indexTab
+	indexDefaults     bool // Settings tab includes default values
+	indexSettings     *IndexSettings
+	indexStats        *IndexStats
+	indexAliases      []IndexAlias
+	indexPolicy       *ISMExplanation
+	indexTabLoaded    map[indexTab]bool
+	indexTabErrs      map[indexTab]error
+	selectedShard     int       // Cursor over the Shards view's unassigned and initializing shards
+	explainShard      ShardInfo // Shard copy shown in the allocation explain drill-down
+	shardExplain      *AllocationExplanation
+	shardExplainErr   error
+	hotThreadsOpts    HotThreadsOptions
+	hotThreads        *hotThreadsSample // Latest hot threads sample
+	hotThreadsPrev    *hotThreadsSample // Sample shown next to the latest after 's'
+	hotThreadsErr     error
+	hotThreadsLoading bool
 	viewport          viewport.Model
 	viewportReady     bool
 
@@ -50,27 +100,78 @@ type App struct {
 	metricsTimeSeries *MetricsTimeSeries
 	metricsEnabled    bool
 	lastMetricsUpdate time.Time
+	metricsInterval   time.Duration // Time between Live Metrics samples
 
 	// Thread Pool Monitor state
 	threadPoolTimeSeries *ThreadPoolTimeSeries
 	threadPoolEnabled    bool
 	lastThreadPoolUpdate time.Time
+
+	// Memory Pressure state
+	memory           []NodeMemory
+	memoryTimeSeries *MemoryTimeSeries // Breaker trip and cache eviction rates between refreshes
+
+	// Top Indices state
+	indexTimeSeries      *IndexTimeSeries
+	topIndicesEnabled    bool
+	lastTopIndicesUpdate time.Time
+	topIndicesRank       indexRanking
+	selectedTopIndex     int
+	pinnedIndices        []string                      // Indices graphed in Live Metrics, in the order they were pinned
+	pinnedSeries         map[string]*MetricsTimeSeries // Each pinned index's activity, sampled with Live Metrics
+
+	// Per-node metrics state, fed by every refresh of the Nodes and Node Graphs views
+	nodeTimeSeries *NodeTimeSeries
+
+	// Cluster switching state
+	clusterNames     []string
+	clusterName      string
+	connect          Connector
+	pickerOpen       bool
+	pickerSelected   int
+	pickerConnecting bool
+	pickerTarget     string // Cluster being connected to while pickerConnecting
+	pickerConnectID  int    // Incremented on every connect and cancel; results of older connects are dropped
+	pickerErr        error
+	pickerWarnings   []string // Warnings from the last successful connect, shown until the picker closes
+	connEpoch        int      // Incremented on every cluster switch; responses from older epochs are dropped
+
+	// Auto-refresh state
+	refreshInterval    time.Duration
+	refreshPaused      bool
+	nextRefresh        time.Time // Zero while a refresh is in flight
+	autoRefreshTicking bool
+
+	// Request cancellation state
+	requestTimeout time.Duration      // Bounds each cluster API call
+	fetchCtx       context.Context    // Parent of in-flight requests; cancelled by 'r' and cluster switches
+	cancelFetch    context.CancelFunc // Cancels fetchCtx
+	refreshGen     int                // Incremented by cancelInFlight; responses from older generations are dropped
 }
 
 // NewApp creates a new application instance
-func NewApp(client *opensearch.Client, endpoint string) *App {
+func NewApp(src source.ClusterSource, endpoint, authMode string) *App {
 	return &App{
-		client:               client,
+		source:               src,
 		endpoint:             endpoint,
+		authMode:             authMode,
 		loading:              true,
 		currentView:          ViewCluster,
 		activePanel:          PanelLeft,
 		selectedItem:         0,
 		leftPanelWidth:       28,
-		metricsTimeSeries:    NewMetricsTimeSeries(12),    // Last 60 seconds at 5-second intervals
+		metricsTimeSeries:    NewMetricsWindow(metricsRawPoints, defaultMetricsWindow),
+		metricsInterval:      defaultMetricsInterval,
 		metricsEnabled:       false,                       // Enabled when user navigates to Live Metrics view
 		threadPoolTimeSeries: NewThreadPoolTimeSeries(12), // Last 60 seconds at 5-second intervals
 		threadPoolEnabled:    false,                       // Enabled when user navigates to Thread Pool Monitor view
+		memoryTimeSeries:     NewMemoryTimeSeries(12),     // Last 12 refreshes
+		indexTimeSeries:      NewIndexTimeSeries(12),      // Last 60 seconds at 5-second intervals
+		nodeTimeSeries:       NewNodeTimeSeries(12),       // Last 12 refreshes
+		refreshInterval:      DefaultRefreshInterval,
+		requestTimeout:       DefaultRequestTimeout,
+		hotThreadsOpts:       defaultHotThreadsOptions,
+		snapshotMaxAge:       DefaultSnapshotMaxAge,
 	}
 }
 
@@ -79,33 +180,82 @@ func (a *App) Init() tea.Cmd {
 	return a.refresh()
 }
 
-// metricsTick creates a command that triggers after 5 seconds
-func metricsTick() tea.Cmd {
-	return tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+// metricsTick creates a command that triggers after the sample interval
+func metricsTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return metricsTickMsg{timestamp: t}
 	})
 }
 
 // refreshMetrics fetches cluster metrics in the background
 func (a *App) refreshMetrics() tea.Cmd {
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch := a.connEpoch
 	return func() tea.Msg {
-		ctx := context.Background()
-		snapshot, err := a.fetchClusterMetrics(ctx)
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
+
+		snapshot, err := f.fetchClusterMetrics(ctx)
 		return metricsRefreshMsg{
 			snapshot: snapshot,
-			err:      err,
+			err:      f.timeoutError(err),
+			epoch:    epoch,
 		}
 	}
 }
 
 // refreshThreadPoolMetrics fetches thread pool metrics in the background
 func (a *App) refreshThreadPoolMetrics() tea.Cmd {
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch := a.connEpoch
 	return func() tea.Msg {
-		ctx := context.Background()
-		snapshot, err := a.fetchThreadPoolMetrics(ctx)
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
+
+		snapshot, err := f.fetchThreadPoolMetrics(ctx)
 		return threadPoolRefreshMsg{
 			snapshot: snapshot,
-			err:      err,
+			err:      f.timeoutError(err),
+			epoch:    epoch,
+		}
+	}
+}
+
+// refreshTopIndices fetches per-index activity in the background
+func (a *App) refreshTopIndices() tea.Cmd {
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch := a.connEpoch
+	return func() tea.Msg {
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
+
+		snapshot, err := f.fetchIndexActivity(ctx)
+		return topIndicesRefreshMsg{
+			snapshot: snapshot,
+			err:      f.timeoutError(err),
+			epoch:    epoch,
+		}
+	}
+}
+
+// refreshPinnedIndices fetches the pinned indices' activity in the
+// background for Live Metrics. Returns nil when nothing is pinned
+func (a *App) refreshPinnedIndices() tea.Cmd {
+	if len(a.pinnedIndices) == 0 {
+		return nil
+	}
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch := a.connEpoch
+	pinned := append([]string(nil), a.pinnedIndices...)
+	return func() tea.Msg {
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
+
+		snapshot, err := f.fetchIndexActivity(ctx, pinned...)
+		return pinnedIndicesRefreshMsg{
+			snapshot: snapshot,
+			err:      f.timeoutError(err),
+			epoch:    epoch,
 		}
 	}
 }
@@ -138,7 +288,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		log.Println(a.width, a.height)
 
 		widthPaddingOffset := 6
-		heightPaddingOffset := 8
+		heightPaddingOffset := 9
 
 		a.viewport = viewport.New(a.width-a.leftPanelWidth-widthPaddingOffset, a.height-heightPaddingOffset)
 
@@ -151,14 +301,125 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
+		// The cluster picker captures all keys while open
+		if a.pickerOpen {
+			return a.handlePickerKey(msg)
+		}
+		// So does the sample document editor while it's being looked at
+		if a.currentView == ViewIngestSimulate && a.activePanel == PanelRight {
+			return a.handleIngestSimKey(msg)
+		}
+		// And the cluster settings filter while it's being typed
+		if a.currentView == ViewSettings && a.settingsFiltering {
+			return a.handleSettingsFilterKey(msg)
+		}
+
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return a, tea.Quit
 
+		case "c":
+			a.openClusterPicker()
+			return a, nil
+
 		case "r":
+			a.cancelInFlight()
 			a.loading = true
 			a.err = nil
-			return a, a.refresh()
+			a.nextRefresh = time.Time{} // Countdown restarts when the refresh lands
+
+			// Unlike auto-refresh, slow sources are refetched too
+			return a, a.refreshSources(sourcesFor(a.currentView))
+
+		case "p":
+			a.toggleRefreshPause()
+
+		case "+", "=":
+			a.stepRefreshInterval(1)
+
+		case "-", "_":
+			a.stepRefreshInterval(-1)
+
+		case "left", "h", "right", "l":
+			// Switch index drill-down tabs
+			if a.currentView == ViewIndexSchema {
+				step := 1
+				if msg.String() == "left" || msg.String() == "h" {
+					step = -1
+				}
+				return a, a.switchIndexTab(step)
+			}
+
+		case "d":
+			// Toggle default settings on the index drill-down's Settings tab
+			if a.currentView == ViewIndexSchema && a.indexTab == indexTabSettings {
+				a.indexDefaults = !a.indexDefaults
+				a.indexSettings = nil
+				delete(a.indexTabLoaded, indexTabSettings)
+				delete(a.indexTabErrs, indexTabSettings)
+				a.updateViewportContent()
+				return a, a.fetchIndexTab(indexTabSettings)
+			}
+
+		case "s", "t", "i", "[", "]", "n":
+			// Hot threads sampling options
+			if a.currentView == ViewHotThreads {
+				return a, a.hotThreadsKey(msg.String())
+			}
+			// Rank the Top Indices leaderboard by the next metric
+			if a.currentView == ViewTopIndices && msg.String() == "s" {
+				a.cycleTopIndicesRank()
+			}
+			// Sample Live Metrics at the next interval
+			if a.currentView == ViewLiveMetrics && msg.String() == "i" {
+				a.cycleMetricsInterval()
+			}
+
+		case "w":
+			// Show the next Live Metrics window
+			if a.currentView == ViewLiveMetrics {
+				a.cycleMetricsWindow()
+			}
+
+		case "P":
+			// Pin the selected index to Live Metrics
+			if a.currentView == ViewTopIndices && a.activePanel == PanelRight {
+				if index, ok := a.selectedTopIndexName(); ok {
+					a.togglePinnedIndex(index)
+				}
+			}
+
+		case "F":
+			// Toggle the Index Management view's failed-only filter
+			if a.currentView == ViewISM {
+				a.ismFailedOnly = !a.ismFailedOnly
+				a.updateViewportContent()
+			}
+
+		case "/":
+			// Filter the Cluster Settings view by key or value
+			if a.currentView == ViewSettings && a.activePanel == PanelRight {
+				a.startSettingsFilter()
+				return a, nil
+			}
+
+		case "C":
+			// Toggle the Cluster Settings view's changed-only filter
+			if a.currentView == ViewSettings {
+				a.settingsChanged = !a.settingsChanged
+				a.updateViewportContent()
+			}
+
+		case "H":
+			// Sample hot threads on the node being looked at
+			if a.currentView == ViewNodes && a.activePanel == PanelRight {
+				if node, ok := a.selectedNodeInfo(); ok {
+					return a, a.openHotThreads(node.Name)
+				}
+			}
+			if a.currentView == ViewNodeDetail {
+				return a, a.openHotThreads(a.selectedNodeName)
+			}
 
 		case "tab":
 			// Switch between panels
@@ -184,6 +445,42 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						// Scroll viewport to follow cursor (each index takes 5 lines)
 						a.viewport.LineUp(2)
 					}
+				} else if a.currentView == ViewNodes && len(a.nodes) > 0 {
+					if a.selectedNode > 0 {
+						a.selectedNode--
+						a.updateViewportContent()
+						a.viewport.LineUp(nodeBlockLines)
+					}
+				} else if a.currentView == ViewShards && len(a.problemShards()) > 0 {
+					if a.selectedShard > 0 {
+						a.selectedShard--
+						a.updateViewportContent()
+						a.viewport.LineUp(1)
+					}
+				} else if a.currentView == ViewTemplates && len(a.composableTemplates()) > 0 {
+					if a.selectedTemplate > 0 {
+						a.selectedTemplate--
+						a.updateViewportContent()
+						a.viewport.LineUp(1)
+					}
+				} else if a.currentView == ViewAliases && len(a.aliasTargets()) > 0 {
+					if a.selectedAlias > 0 {
+						a.selectedAlias--
+						a.updateViewportContent()
+						a.viewport.LineUp(1)
+					}
+				} else if a.currentView == ViewIngest && len(a.ingestPipelines()) > 0 {
+					if a.selectedPipeline > 0 {
+						a.selectedPipeline--
+						a.updateViewportContent()
+						a.viewport.LineUp(1)
+					}
+				} else if a.currentView == ViewTopIndices && len(a.topIndices()) > 0 {
+					if a.selectedTopIndex > 0 {
+						a.selectedTopIndex--
+						a.updateViewportContent()
+						a.viewport.LineUp(1)
+					}
 				} else {
 					// Scroll viewport up when in right panel
 					a.viewport.LineUp(1)
@@ -193,7 +490,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "down", "j":
 			if a.activePanel == PanelLeft {
 				// Navigate menu
-				if a.selectedItem < 15 { // Updated for ViewThreadPoolMonitor
+				if a.selectedItem < len(menuItems)-1 {
 					a.selectedItem++
 					return a, a.updateViewFromSelectionCmd()
 				}
@@ -206,6 +503,42 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						// Scroll viewport to follow cursor (each index takes 5 lines)
 						a.viewport.LineDown(2)
 					}
+				} else if a.currentView == ViewNodes && len(a.nodes) > 0 {
+					if a.selectedNode < len(a.nodes)-1 {
+						a.selectedNode++
+						a.updateViewportContent()
+						a.viewport.LineDown(nodeBlockLines)
+					}
+				} else if a.currentView == ViewShards && len(a.problemShards()) > 0 {
+					if a.selectedShard < len(a.problemShards())-1 {
+						a.selectedShard++
+						a.updateViewportContent()
+						a.viewport.LineDown(1)
+					}
+				} else if a.currentView == ViewTemplates && len(a.composableTemplates()) > 0 {
+					if a.selectedTemplate < len(a.composableTemplates())-1 {
+						a.selectedTemplate++
+						a.updateViewportContent()
+						a.viewport.LineDown(1)
+					}
+				} else if a.currentView == ViewAliases && len(a.aliasTargets()) > 0 {
+					if a.selectedAlias < len(a.aliasTargets())-1 {
+						a.selectedAlias++
+						a.updateViewportContent()
+						a.viewport.LineDown(1)
+					}
+				} else if a.currentView == ViewIngest && len(a.ingestPipelines()) > 0 {
+					if a.selectedPipeline < len(a.ingestPipelines())-1 {
+						a.selectedPipeline++
+						a.updateViewportContent()
+						a.viewport.LineDown(1)
+					}
+				} else if a.currentView == ViewTopIndices && len(a.topIndices()) > 0 {
+					if a.selectedTopIndex < len(a.topIndices())-1 {
+						a.selectedTopIndex++
+						a.updateViewportContent()
+						a.viewport.LineDown(1)
+					}
 				} else {
 					// Scroll viewport down when in right panel
 					a.viewport.LineDown(1)
@@ -242,53 +575,162 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// When in indices view, drill down to schema
 				if a.currentView == ViewIndices && len(a.indices) > 0 {
 					if a.selectedIndex >= 0 && a.selectedIndex < len(a.indices) {
+						a.clearIndexDetail()
 						a.selectedIndexName = a.indices[a.selectedIndex].Index
 						a.currentView = ViewIndexSchema
 						a.loading = true
 						return a, a.fetchIndexMapping()
 					}
 				}
+
+				// When in nodes view, drill down to node stats
+				if a.currentView == ViewNodes {
+					if node, ok := a.selectedNodeInfo(); ok {
+						a.selectedNodeName = node.Name
+						a.nodeDetail = nil
+						a.nodeDetailErr = nil
+						a.currentView = ViewNodeDetail
+						a.updateViewportContent()
+						if a.viewportReady {
+							a.viewport.GotoTop()
+						}
+						return a, a.fetchNodeDetail()
+					}
+				}
+
+				// When in shards view, explain the selected shard's allocation
+				if a.currentView == ViewShards {
+					if shard, ok := a.selectedProblemShard(); ok {
+						a.explainShard = shard
+						a.shardExplain = nil
+						a.shardExplainErr = nil
+						a.currentView = ViewShardExplain
+						a.updateViewportContent()
+						if a.viewportReady {
+							a.viewport.GotoTop()
+						}
+						return a, a.fetchShardExplain()
+					}
+				}
+
+				// When in templates view, resolve the selected composable
+				// template
+				if a.currentView == ViewTemplates {
+					if template, ok := a.selectedComposableTemplate(); ok {
+						return a, a.openTemplateDetail(template.Name)
+					}
+				}
+
+				// When in aliases view, open the selected backing or alias
+				// index in the index drill-down
+				if a.currentView == ViewAliases {
+					if index, ok := a.selectedAliasTarget(); ok {
+						a.clearIndexDetail()
+						a.selectedIndexName = index
+						a.indexFromAliases = true
+						a.currentView = ViewIndexSchema
+						a.loading = true
+						return a, a.fetchIndexMapping()
+					}
+				}
+
+				// When in ingest view, open the simulate editor for the
+				// selected pipeline
+				if a.currentView == ViewIngest {
+					if pipeline, ok := a.selectedIngestPipeline(); ok {
+						a.openIngestSimulation(pipeline.ID)
+						return a, nil
+					}
+				}
 			}
 
 		case "esc", "backspace":
-			// Return from schema view to indices view
+			// Return from schema view to the indices or aliases view it was
+			// opened from
 			if a.currentView == ViewIndexSchema {
 				a.currentView = ViewIndices
-				a.selectedIndexName = ""
-				a.indexMapping = nil
+				if a.indexFromAliases {
+					a.currentView = ViewAliases
+				}
+				a.clearIndexDetail()
 				a.updateViewportContent()
 				// Reset scroll position when returning to indices view
 				if a.viewportReady {
 					a.viewport.GotoTop()
 				}
 			}
+
+			// Return from allocation explain view to shards view
+			if a.currentView == ViewShardExplain {
+				a.currentView = ViewShards
+				a.explainShard = ShardInfo{}
+				a.shardExplain = nil
+				a.shardExplainErr = nil
+				a.updateViewportContent()
+				if a.viewportReady {
+					a.viewport.GotoTop()
+				}
+			}
+
+			// Return from template detail view to templates view
+			if a.currentView == ViewTemplateDetail {
+				a.currentView = ViewTemplates
+				a.clearTemplateDetail()
+				a.updateViewportContent()
+				if a.viewportReady {
+					a.viewport.GotoTop()
+				}
+			}
+
+			// Return from simulate view to ingest view
+			if a.currentView == ViewIngestSimulate {
+				a.currentView = ViewIngest
+				a.clearIngestSimulation()
+				a.updateViewportContent()
+				if a.viewportReady {
+					a.viewport.GotoTop()
+				}
+			}
+
+			// Return from node detail view to nodes view
+			if a.currentView == ViewNodeDetail {
+				a.currentView = ViewNodes
+				a.selectedNodeName = ""
+				a.nodeDetail = nil
+				a.nodeDetailErr = nil
+				a.updateViewportContent()
+				if a.viewportReady {
+					a.viewport.GotoTop()
+				}
+			}
 		}
 
 	case refreshMsg:
-		a.loading = false
-		a.err = msg.err
-		if msg.err == nil {
-			a.health = msg.health
-			a.stats = msg.stats
-			a.nodes = msg.nodes
-			a.indices = msg.indices
-			a.shards = msg.shards
-			a.allocation = msg.allocation
-			a.threadPool = msg.threadPool
-			a.tasks = msg.tasks
-			a.pendingTasks = msg.pendingTasks
-			a.recovery = msg.recovery
-			a.segments = msg.segments
-			a.fielddata = msg.fielddata
-			a.plugins = msg.plugins
-			a.templates = msg.templates
-			a.lastRefresh = time.Now()
-
-			// Update viewport content when data refreshes
-			a.updateViewportContent()
+		// Drop responses from a cluster we've since switched away from, or
+		// from a refresh that was cancelled
+		if msg.epoch != a.connEpoch || msg.gen != a.refreshGen {
+			break
 		}
+		a.applyResults(msg.results)
+		if !msg.partial {
+			a.loading = false
+			a.err = refreshError(msg.results)
+			if a.err == nil {
+				a.lastRefresh = time.Now()
+			}
+			cmd = a.scheduleRefresh()
+		}
+
+		// Update viewport content when data refreshes
+		a.updateViewportContent()
+
+	case autoRefreshTickMsg:
+		return a, a.handleAutoRefreshTick(msg.timestamp)
 
 	case mappingMsg:
+		if msg.epoch != a.connEpoch || msg.gen != a.refreshGen {
+			break
+		}
 		a.loading = false
 		a.err = msg.err
 		if msg.err == nil {
@@ -296,16 +738,90 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.updateViewportContent()
 		}
 
+	case indexTabMsg:
+		// Also drop responses for an index the user has since backed out of
+		if msg.epoch != a.connEpoch || msg.gen != a.refreshGen || msg.index != a.selectedIndexName {
+			break
+		}
+		a.applyIndexTab(msg)
+		a.updateViewportContent()
+
+	case shardExplainMsg:
+		// Also drop responses for a shard the user has since backed out of
+		if msg.epoch != a.connEpoch || msg.gen != a.refreshGen || !sameShardCopy(msg.shard, a.explainShard) {
+			break
+		}
+		a.shardExplainErr = msg.err
+		if msg.err == nil {
+			a.shardExplain = msg.explanation
+		}
+		a.updateViewportContent()
+
+	case templateSimMsg:
+		// Also drop simulations of a template the user has since backed out of
+		if msg.epoch != a.connEpoch || msg.gen != a.refreshGen || msg.name != a.templateDetail {
+			break
+		}
+		a.templateSimErr = msg.err
+		if msg.err == nil {
+			a.templateSim = msg.simulated
+		}
+		a.updateViewportContent()
+
+	case ingestSimMsg:
+		// Also drop simulations of a pipeline the user has since backed out of
+		if msg.epoch != a.connEpoch || msg.gen != a.refreshGen || msg.pipeline != a.ingestDetail {
+			break
+		}
+		a.ingestSimRunning = false
+		a.ingestSimErr = msg.err
+		a.ingestResult = nil
+		if msg.err == nil {
+			a.ingestResult = msg.docs
+		}
+		a.updateViewportContent()
+
+	case hotThreadsMsg:
+		// Also drop samples taken with options the user has since changed
+		if msg.epoch != a.connEpoch || msg.gen != a.refreshGen || msg.opts != a.hotThreadsOpts {
+			break
+		}
+		a.hotThreadsLoading = false
+		a.hotThreadsErr = msg.err
+		if msg.err == nil {
+			a.hotThreads = &hotThreadsSample{opts: msg.opts, nodes: msg.nodes, at: msg.at}
+		}
+		a.updateViewportContent()
+
+	case nodeDetailMsg:
+		// Also drop responses for a node the user has since backed out of
+		if msg.epoch != a.connEpoch || msg.gen != a.refreshGen || msg.node != a.selectedNodeName {
+			break
+		}
+		a.nodeDetailErr = msg.err
+		if msg.err == nil {
+			a.nodeDetail = msg.detail
+		}
+		a.updateViewportContent()
+
 	case metricsTickMsg:
 		// Only process tick if metrics are enabled (user is on Live Metrics view)
 		if a.metricsEnabled {
 			// Fetch new metrics and schedule next tick
-			return a, tea.Batch(a.refreshMetrics(), metricsTick())
+			cmds := []tea.Cmd{a.refreshMetrics(), metricsTick(a.metricsInterval)}
+			if len(a.pinnedIndices) > 0 {
+				// Pinned indices are graphed alongside the cluster
+				cmds = append(cmds, a.refreshPinnedIndices())
+			}
+			return a, tea.Batch(cmds...)
 		}
 		// If metrics disabled, don't schedule next tick
 		return a, nil
 
 	case metricsRefreshMsg:
+		if msg.epoch != a.connEpoch {
+			break
+		}
 		if msg.err != nil {
 			// Log error but don't stop ticker
 			log.Printf("Metrics fetch error: %v", msg.err)
@@ -321,6 +837,59 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case topIndicesTickMsg:
+		// Only process tick if the Top Indices view is open
+		if a.topIndicesEnabled {
+			// Fetch new index activity and schedule next tick
+			return a, tea.Batch(a.refreshTopIndices(), topIndicesTick())
+		}
+		// If disabled, don't schedule next tick
+		return a, nil
+
+	case topIndicesRefreshMsg:
+		if msg.epoch != a.connEpoch {
+			break
+		}
+		if msg.err != nil {
+			// Log error but don't stop ticker
+			log.Printf("Index activity fetch error: %v", msg.err)
+		} else if msg.snapshot != nil {
+			if a.indexTimeSeries.AddSnapshot(msg.snapshot) {
+				a.lastTopIndicesUpdate = time.Now()
+				if a.currentView == ViewTopIndices {
+					a.updateViewportContent()
+				}
+			}
+		}
+
+	case pinnedIndicesRefreshMsg:
+		if msg.epoch != a.connEpoch {
+			break
+		}
+		if msg.err != nil {
+			// Log error but don't stop ticker
+			log.Printf("Pinned index activity fetch error: %v", msg.err)
+		} else if msg.snapshot != nil {
+			if a.addPinnedSnapshot(msg.snapshot) && a.currentView == ViewLiveMetrics {
+				a.updateViewportContent()
+			}
+		}
+
+	case clusterSwitchMsg:
+		if !a.pickerConnecting || msg.connectID != a.pickerConnectID {
+			// The user cancelled this connect or started another
+			return a, nil
+		}
+		a.pickerConnecting = false
+		if msg.err != nil {
+			// Keep the picker open so the user can pick another cluster
+			a.pickerErr = msg.err
+			return a, nil
+		}
+		a.switchCluster(msg.conn)
+		a.updateViewportContent()
+		return a, a.refresh()
+
 	case threadPoolTickMsg:
 		// Only process tick if thread pool monitoring is enabled
 		if a.threadPoolEnabled {
@@ -331,6 +900,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case threadPoolRefreshMsg:
+		if msg.epoch != a.connEpoch {
+			break
+		}
 		if msg.err != nil {
 			// Log error but don't stop ticker
 			log.Printf("Thread pool metrics fetch error: %v", msg.err)
@@ -359,6 +931,10 @@ func (a *App) updateViewFromSelection() {
 	a.currentView = View(a.selectedItem)
 	a.selectedNode = 0
 	a.selectedIndex = 0
+	a.selectedShard = 0
+	a.selectedAlias = 0
+	a.selectedTemplate = 0
+	a.selectedPipeline = 0
 
 	// Enable/disable metrics based on view
 	wasEnabled := a.metricsEnabled
@@ -369,6 +945,10 @@ func (a *App) updateViewFromSelection() {
 	a.threadPoolEnabled = (a.currentView == ViewThreadPoolMonitor)
 	_ = wasThreadPoolEnabled
 
+	// Enable/disable per-index sampling based on view
+	a.topIndicesEnabled = (a.currentView == ViewTopIndices)
+	a.selectedTopIndex = 0
+
 	// Update viewport content
 	a.updateViewportContent()
 
@@ -387,16 +967,32 @@ func (a *App) updateViewFromSelectionCmd() tea.Cmd {
 	previousView := a.currentView
 	wasEnabled := a.metricsEnabled
 	wasThreadPoolEnabled := a.threadPoolEnabled
+	wasTopIndicesEnabled := a.topIndicesEnabled
 
 	// Update view state
 	a.updateViewFromSelection()
 
 	var cmds []tea.Cmd
 
+	// Load data for views that haven't been shown yet
+	if cmd := a.fetchViewData(); cmd != nil {
+		cmds = append(cmds, cmd)
+		a.updateViewportContent() // Show the loading marker
+	}
+
+	// Take the first hot threads sample
+	if a.currentView == ViewHotThreads && a.hotThreads == nil && !a.hotThreadsLoading {
+		cmds = append(cmds, a.fetchHotThreads())
+		a.updateViewportContent()
+	}
+
 	// Start metrics ticker if transitioning to Live Metrics view
 	if !wasEnabled && a.metricsEnabled {
 		// Start ticker and immediate first fetch
-		cmds = append(cmds, a.refreshMetrics(), metricsTick())
+		cmds = append(cmds, a.refreshMetrics(), metricsTick(a.metricsInterval))
+		if len(a.pinnedIndices) > 0 {
+			cmds = append(cmds, a.refreshPinnedIndices())
+		}
 	}
 
 	// Start thread pool ticker if transitioning to Thread Pool Monitor view
@@ -405,6 +1001,12 @@ func (a *App) updateViewFromSelectionCmd() tea.Cmd {
 		cmds = append(cmds, a.refreshThreadPoolMetrics(), threadPoolTick())
 	}
 
+	// Start per-index sampling if transitioning to Top Indices view
+	if !wasTopIndicesEnabled && a.topIndicesEnabled {
+		// Start ticker and immediate first fetch
+		cmds = append(cmds, a.refreshTopIndices(), topIndicesTick())
+	}
+
 	// Stop ticker if leaving views (handled by enabled flags in tick handlers)
 	_ = previousView
 
@@ -419,7 +1021,7 @@ func (a *App) updateViewportContent() {
 	if !a.viewportReady {
 		return
 	}
-	content := a.renderRightPanel()
+	content := a.renderSourceStatus(a.currentView) + a.renderRightPanel()
 	a.viewport.SetContent(content)
 }
 
@@ -433,10 +1035,23 @@ func (a *App) View() string {
 
 	// Title bar
 	title := titleStyle.Render("ostop - OpenSearch Cluster Monitor")
-	statusBar := statusBarStyle.Render(fmt.Sprintf("Endpoint: %s", a.endpoint))
+	status := fmt.Sprintf("Endpoint: %s", a.endpoint)
+	if a.clusterName != "" {
+		status = fmt.Sprintf("Cluster: %s | %s", a.clusterName, status)
+	}
+	if a.authMode != "" {
+		status += fmt.Sprintf(" | Auth: %s", a.authMode)
+	}
+	statusBar := statusBarStyle.Render(status)
 	b += lipgloss.JoinHorizontal(lipgloss.Top, title, statusBar)
 	b += "\n\n"
 
+	// Cluster picker overlays everything else
+	if a.pickerOpen {
+		b += a.renderClusterPicker()
+		return b
+	}
+
 	// Loading state
 	if a.loading {
 		b += "Loading cluster data...\n"
@@ -447,7 +1062,7 @@ func (a *App) View() string {
 	if a.err != nil {
 		b += errorStyle.Render(fmt.Sprintf("Error: %v", a.err))
 		b += "\n\n"
-		b += helpStyle.Render("Press 'r' to retry | 'q' to quit")
+		b += helpStyle.Render("Press 'r' to retry | 'c' to switch cluster | 'q' to quit")
 		return b
 	}
 
@@ -483,15 +1098,24 @@ func (a *App) View() string {
 	// Help footer with scroll info
 	helpText := "↑/↓: Navigate | Tab: Switch Panel | Enter: Select"
 	if a.currentView == ViewIndexSchema {
+		helpText += " | ←/→: Tabs"
+	}
+	if a.currentView == ViewNodes || a.currentView == ViewNodeDetail {
+		helpText += " | H: Hot Threads"
+	}
+	if a.currentView == ViewIndexSchema || a.currentView == ViewNodeDetail || a.currentView == ViewShardExplain || a.currentView == ViewTemplateDetail ||
+		a.currentView == ViewIngestSimulate {
 		helpText += " | Esc: Back"
 	}
-	helpText += " | r: Refresh | q: Quit"
+	helpText += " | r: Refresh | p: Pause | +/-: Interval | c: Clusters | q: Quit"
 	if a.activePanel == PanelRight && a.viewportReady {
 		scrollPercent := int(a.viewport.ScrollPercent() * 100)
 		if scrollPercent < 100 {
 			helpText += fmt.Sprintf(" | Scroll: %d%%", scrollPercent)
 		}
 	}
+	b += subtleStyle.Render(a.renderRefreshStatus(time.Now()))
+	b += "\n"
 	help := helpStyle.Render(helpText)
 	b += help
 
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultRefreshInterval is used when neither --refresh nor the config sets one
+const DefaultRefreshInterval = 10 * time.Second
+
+// refreshIntervals are the steps the +/- keys move through
+var refreshIntervals = []time.Duration{
+	1 * time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	15 * time.Second,
+	30 * time.Second,
+	1 * time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+}
+
+// WithRefreshInterval sets the auto-refresh interval; zero keeps the default
+func (a *App) WithRefreshInterval(d time.Duration) *App {
+	if d > 0 {
+		a.refreshInterval = d
+	}
+	return a
+}
+
+// autoRefreshTick fires every second to drive the auto-refresh countdown
+func autoRefreshTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return autoRefreshTickMsg{timestamp: t}
+	})
+}
+
+// scheduleRefresh starts the countdown to the next auto-refresh. Returns the
+// ticker command the first time it's called.
+func (a *App) scheduleRefresh() tea.Cmd {
+	a.nextRefresh = time.Now().Add(a.refreshInterval)
+	if a.autoRefreshTicking {
+		return nil
+	}
+	a.autoRefreshTicking = true
+	return autoRefreshTick()
+}
+
+// handleAutoRefreshTick refreshes the current view once the countdown runs out
+func (a *App) handleAutoRefreshTick(now time.Time) tea.Cmd {
+	if !a.autoRefreshDue(now) {
+		return autoRefreshTick()
+	}
+
+	// Zero means in flight; the countdown restarts when the refresh lands
+	a.nextRefresh = time.Time{}
+	return tea.Batch(autoRefreshTick(), a.refresh())
+}
+
+// autoRefreshDue reports whether the countdown has run out
+func (a *App) autoRefreshDue(now time.Time) bool {
+	if a.refreshPaused || a.loading || a.nextRefresh.IsZero() {
+		return false
+	}
+	return !now.Before(a.nextRefresh)
+}
+
+// stepRefreshInterval moves to the next longer (dir > 0) or shorter (dir < 0)
+// preset interval and restarts the countdown
+func (a *App) stepRefreshInterval(dir int) {
+	idx := -1
+	for i, d := range refreshIntervals {
+		if dir > 0 && d > a.refreshInterval {
+			idx = i
+			break
+		}
+		if dir < 0 && d < a.refreshInterval {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return
+	}
+
+	a.refreshInterval = refreshIntervals[idx]
+	if !a.nextRefresh.IsZero() {
+		a.nextRefresh = time.Now().Add(a.refreshInterval)
+	}
+}
+
+// toggleRefreshPause pauses or resumes auto-refresh
+func (a *App) toggleRefreshPause() {
+	a.refreshPaused = !a.refreshPaused
+}
+
+// renderRefreshStatus renders the auto-refresh countdown and data age
+func (a *App) renderRefreshStatus(now time.Time) string {
+	var status string
+	switch {
+	case a.refreshPaused:
+		status = fmt.Sprintf("Auto-refresh: paused (every %s)", a.refreshInterval)
+	case a.nextRefresh.IsZero():
+		status = fmt.Sprintf("Auto-refresh: every %s, refreshing...", a.refreshInterval)
+	default:
+		remaining := a.nextRefresh.Sub(now).Round(time.Second)
+		if remaining < 0 {
+			remaining = 0
+		}
+		status = fmt.Sprintf("Auto-refresh: every %s, next in %s", a.refreshInterval, remaining)
+	}
+
+	if !a.lastRefresh.IsZero() {
+		age := now.Sub(a.lastRefresh).Truncate(time.Second)
+		status += fmt.Sprintf(" | Last updated %s ago", age)
+	}
+
+	return status
+}
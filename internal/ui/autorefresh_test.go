@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAutoRefresh_ScheduledAfterRefresh(t *testing.T) {
+	app, err := SetupTestApp()
+	if err != nil {
+		t.Fatalf("Failed to setup test app: %v", err)
+	}
+
+	before := time.Now()
+	_, cmd := app.Update(ExecuteCommand(app.Init()))
+
+	if cmd == nil {
+		t.Error("first refresh should start the auto-refresh ticker")
+	}
+	if app.nextRefresh.Before(before.Add(DefaultRefreshInterval)) {
+		t.Errorf("nextRefresh = %v, want about %v from now", app.nextRefresh, DefaultRefreshInterval)
+	}
+
+	// The ticker is only started once
+	_, cmd = app.Update(ExecuteCommand(app.refresh()))
+	if cmd != nil {
+		t.Error("later refreshes should not start another ticker")
+	}
+}
+
+func TestAutoRefresh_TickTriggersRefreshWhenDue(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+
+	// Not due yet
+	app.handleAutoRefreshTick(time.Now())
+	if app.nextRefresh.IsZero() {
+		t.Error("tick before the countdown ends should not refresh")
+	}
+
+	// Due
+	app.sourcePending = nil
+	app.handleAutoRefreshTick(app.nextRefresh.Add(time.Millisecond))
+	if !app.nextRefresh.IsZero() {
+		t.Error("tick after the countdown ends should start a refresh")
+	}
+	if !app.sourcePending[SourceHealth] {
+		t.Error("auto-refresh should fetch the current view's sources")
+	}
+	if app.loading {
+		t.Error("auto-refresh should not replace the UI with the loading screen")
+	}
+}
+
+func TestAutoRefresh_Pause(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	SendKey(app, "p")
+	if !app.refreshPaused {
+		t.Fatal("'p' should pause auto-refresh")
+	}
+	if !strings.Contains(app.View(), "Auto-refresh: paused") {
+		t.Error("footer should show that auto-refresh is paused")
+	}
+
+	next := app.nextRefresh
+	app.handleAutoRefreshTick(next.Add(time.Hour))
+	if app.nextRefresh != next {
+		t.Error("paused auto-refresh should not refresh")
+	}
+
+	SendKey(app, "p")
+	if app.refreshPaused {
+		t.Error("'p' again should resume auto-refresh")
+	}
+}
+
+func TestAutoRefresh_IntervalKeys(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+
+	SendKey(app, "+")
+	if app.refreshInterval != 15*time.Second {
+		t.Errorf("'+' interval = %v, want 15s", app.refreshInterval)
+	}
+	if time.Until(app.nextRefresh) > 15*time.Second {
+		t.Error("changing the interval should restart the countdown")
+	}
+
+	SendKey(app, "-")
+	SendKey(app, "-")
+	if app.refreshInterval != 5*time.Second {
+		t.Errorf("'-' interval = %v, want 5s", app.refreshInterval)
+	}
+
+	// Clamped at the shortest preset
+	for i := 0; i < 10; i++ {
+		SendKey(app, "-")
+	}
+	if app.refreshInterval != refreshIntervals[0] {
+		t.Errorf("interval = %v, want clamp at %v", app.refreshInterval, refreshIntervals[0])
+	}
+
+	// Clamped at the longest preset
+	for i := 0; i < 20; i++ {
+		SendKey(app, "+")
+	}
+	if app.refreshInterval != refreshIntervals[len(refreshIntervals)-1] {
+		t.Errorf("interval = %v, want clamp at %v", app.refreshInterval, refreshIntervals[len(refreshIntervals)-1])
+	}
+}
+
+func TestAutoRefresh_ManualRefreshResetsCountdown(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+
+	app.nextRefresh = time.Now().Add(2 * time.Second)
+	_, cmd := SendKey(app, "r")
+	if !app.nextRefresh.IsZero() {
+		t.Error("'r' should stop the countdown until the refresh lands")
+	}
+
+	before := time.Now()
+	app.Update(ExecuteCommand(cmd))
+	if app.nextRefresh.Before(before.Add(app.refreshInterval)) {
+		t.Error("countdown should restart from a full interval after a manual refresh")
+	}
+}
+
+func TestAutoRefresh_WithRefreshInterval(t *testing.T) {
+	app := NewTestApp(nil, "http://localhost:9200").WithRefreshInterval(30 * time.Second)
+	if app.refreshInterval != 30*time.Second {
+		t.Errorf("refreshInterval = %v, want 30s", app.refreshInterval)
+	}
+
+	app.WithRefreshInterval(0)
+	if app.refreshInterval != 30*time.Second {
+		t.Error("zero interval should keep the current setting")
+	}
+}
+
+func TestRenderRefreshStatus(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		app  *App
+		want []string
+	}{
+		{
+			name: "countdown",
+			app:  &App{refreshInterval: 10 * time.Second, nextRefresh: now.Add(7 * time.Second), lastRefresh: now.Add(-3 * time.Second)},
+			want: []string{"every 10s, next in 7s", "Last updated 3s ago"},
+		},
+		{
+			name: "refreshing",
+			app:  &App{refreshInterval: 10 * time.Second},
+			want: []string{"refreshing..."},
+		},
+		{
+			name: "paused",
+			app:  &App{refreshInterval: time.Minute, refreshPaused: true, lastRefresh: now.Add(-90 * time.Second)},
+			want: []string{"paused (every 1m0s)", "Last updated 1m30s ago"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.app.renderRefreshStatus(now)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("renderRefreshStatus() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
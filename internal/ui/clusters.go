@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// Connection is an open connection to a named cluster profile
+type Connection struct {
+	Name            string
+	Endpoint        string
+	AuthMode        string
+	Source          source.ClusterSource
+	RefreshInterval time.Duration // Effective auto-refresh interval for the cluster
+	SnapshotMaxAge  time.Duration // Effective snapshot age warning threshold for the cluster
+	Warnings        []string      // Non-fatal connection problems, e.g. CAs skipped from the bundle
+}
+
+// Connector opens a connection to a named cluster profile
+type Connector func(name string) (*Connection, error)
+
+// WithClusters enables the in-app cluster picker for the given profile names
+func (a *App) WithClusters(names []string, current string, connect Connector) *App {
+	a.clusterNames = names
+	a.clusterName = current
+	a.connect = connect
+	return a
+}
+
+// openClusterPicker shows the cluster picker with the current cluster selected
+func (a *App) openClusterPicker() {
+	a.pickerOpen = true
+	a.pickerErr = nil
+	a.pickerSelected = 0
+	for i, name := range a.clusterNames {
+		if name == a.clusterName {
+			a.pickerSelected = i
+		}
+	}
+}
+
+// handlePickerKey handles key presses while the cluster picker is open
+func (a *App) handlePickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return a, tea.Quit
+
+	case "esc", "c":
+		a.pickerOpen = false
+		a.pickerWarnings = nil
+		if a.pickerConnecting {
+			// Drop the result of the connect in flight
+			a.pickerConnecting = false
+			a.pickerConnectID++
+		}
+
+	case "up", "k":
+		// The selection stays on the cluster being connected to
+		if a.pickerConnecting {
+			return a, nil
+		}
+		if a.pickerSelected > 0 {
+			a.pickerSelected--
+		}
+
+	case "down", "j":
+		if a.pickerConnecting {
+			return a, nil
+		}
+		if a.pickerSelected < len(a.clusterNames)-1 {
+			a.pickerSelected++
+		}
+
+	case "enter":
+		if len(a.clusterNames) == 0 || a.pickerConnecting {
+			return a, nil
+		}
+		name := a.clusterNames[a.pickerSelected]
+		if name == a.clusterName {
+			a.pickerOpen = false
+			return a, nil
+		}
+		a.pickerConnecting = true
+		a.pickerErr = nil
+		a.pickerWarnings = nil
+		return a, a.connectCluster(name)
+	}
+
+	return a, nil
+}
+
+// connectCluster opens a connection to another cluster in the background
+func (a *App) connectCluster(name string) tea.Cmd {
+	a.pickerConnectID++
+	a.pickerTarget = name
+	connect, id := a.connect, a.pickerConnectID
+	return func() tea.Msg {
+		conn, err := connect(name)
+		if err != nil {
+			return clusterSwitchMsg{err: fmt.Errorf("%s: %w", name, err), connectID: id}
+		}
+		return clusterSwitchMsg{conn: conn, connectID: id}
+	}
+}
+
+// switchCluster replaces the client and drops all data from the previous
+// cluster, including metric time series, so samples never mix
+func (a *App) switchCluster(conn *Connection) {
+	a.cancelInFlight()
+	a.connEpoch++
+	a.source = conn.Source
+	a.clusterName = conn.Name
+	a.endpoint = conn.Endpoint
+	a.authMode = conn.AuthMode
+	a.refreshInterval = conn.RefreshInterval
+	a.snapshotMaxAge = conn.SnapshotMaxAge
+	a.nextRefresh = time.Time{}
+
+	a.health = nil
+	a.stats = nil
+	a.nodes = nil
+	a.indices = nil
+	a.shards = nil
+	a.allocation = nil
+	a.threadPool = nil
+	a.tasks = nil
+	a.pendingTasks = nil
+	a.recovery = nil
+	a.segments = nil
+	a.fielddata = nil
+	a.plugins = nil
+	a.templates = nil
+	a.unassigned = nil
+	a.snapshots = nil
+	a.ism = nil
+	a.aliases = nil
+	a.indexTemplates = nil
+	a.ingest = nil
+	a.clusterSettings = nil
+	a.lastRefresh = time.Time{}
+	a.sourceErrs = nil
+	a.sourceUpdated = nil
+
+	a.metricsTimeSeries.Clear()
+	a.lastMetricsUpdate = time.Time{}
+	a.threadPoolTimeSeries.Clear()
+	a.lastThreadPoolUpdate = time.Time{}
+	a.memory = nil
+	a.memoryTimeSeries.Clear()
+	a.indexTimeSeries.Clear()
+	a.lastTopIndicesUpdate = time.Time{}
+	a.selectedTopIndex = 0
+	a.pinnedIndices = nil // Indices of the old cluster
+	a.pinnedSeries = nil
+	a.nodeTimeSeries.Clear()
+
+	// Drill-down state refers to the old cluster's indices and nodes
+	if a.currentView == ViewIndexSchema {
+		a.currentView = ViewIndices
+		if a.indexFromAliases {
+			a.currentView = ViewAliases
+		}
+	}
+	if a.currentView == ViewNodeDetail {
+		a.currentView = ViewNodes
+	}
+	if a.currentView == ViewShardExplain {
+		a.currentView = ViewShards
+	}
+	if a.currentView == ViewTemplateDetail {
+		a.currentView = ViewTemplates
+	}
+	if a.currentView == ViewIngestSimulate {
+		a.currentView = ViewIngest
+	}
+	a.clearIndexDetail()
+	a.selectedNodeName = ""
+	a.nodeDetail = nil
+	a.nodeDetailErr = nil
+	a.selectedIndex = 0
+	a.selectedNode = 0
+	a.selectedShard = 0
+	a.selectedAlias = 0
+	a.selectedTemplate = 0
+	a.clearTemplateDetail()
+	a.selectedPipeline = 0
+	a.clearIngestSimulation()
+	a.clearSettingsFilter()
+	a.explainShard = ShardInfo{}
+	a.shardExplain = nil
+	a.shardExplainErr = nil
+	a.clearHotThreads()
+	a.hotThreadsOpts.Node = ""
+
+	// Keep the picker open while there are warnings about the new connection
+	a.pickerOpen = len(conn.Warnings) > 0
+	a.pickerConnecting = false
+	a.pickerErr = nil
+	a.pickerWarnings = conn.Warnings
+	a.loading = true
+	a.err = nil
+}
+
+// renderClusterPicker renders the cluster selection list
+func (a *App) renderClusterPicker() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render("Switch Cluster"))
+	b.WriteString("\n")
+
+	if len(a.clusterNames) == 0 {
+		b.WriteString(labelStyle.Render("No cluster profiles configured."))
+		b.WriteString("\n")
+		b.WriteString(labelStyle.Render("Define clusters in ~/.config/ostop/config.yaml to switch between them."))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("Esc: Close"))
+		return b.String()
+	}
+
+	for i, name := range a.clusterNames {
+		line := name
+		if name == a.clusterName {
+			line += " (current)"
+		}
+		if i == a.pickerSelected {
+			b.WriteString(selectedMenuItemStyle.Render("▶ " + line))
+		} else {
+			b.WriteString(menuItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if a.pickerConnecting {
+		b.WriteString(statusYellow.Render(fmt.Sprintf("Connecting to %s...", a.pickerTarget)))
+		b.WriteString("\n\n")
+	} else if a.pickerErr != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", a.pickerErr)))
+		b.WriteString("\n\n")
+	} else if len(a.pickerWarnings) > 0 {
+		for _, warning := range a.pickerWarnings {
+			b.WriteString(statusYellow.Render("Warning: " + warning))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(helpStyle.Render("↑/↓: Select | Enter: Switch | Esc: Cancel"))
+	return b.String()
+}
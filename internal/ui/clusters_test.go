@@ -0,0 +1,364 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// newSwitchableTestApp returns an initialized app with two cluster profiles
+// whose connector hands out fixture-backed clients
+func newSwitchableTestApp(t *testing.T) (*App, *int) {
+	t.Helper()
+
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	connects := 0
+	app.WithClusters([]string{"local", "staging"}, "local", func(name string) (*Connection, error) {
+		connects++
+		if name == "broken" {
+			return nil, fmt.Errorf("connection refused")
+		}
+		client, err := NewMockClientWithFixtures()
+		if err != nil {
+			return nil, err
+		}
+		return &Connection{Name: name, Endpoint: "https://" + name + ":9200", AuthMode: "basic (admin)", Source: source.NewOpenSearch(client), RefreshInterval: 30 * time.Second, SnapshotMaxAge: 72 * time.Hour}, nil
+	})
+
+	return app, &connects
+}
+
+func TestClusterPicker_OpenAndClose(t *testing.T) {
+	app, _ := newSwitchableTestApp(t)
+
+	SendKey(app, "c")
+	if !app.pickerOpen {
+		t.Fatal("'c' should open the cluster picker")
+	}
+	if app.pickerSelected != 0 {
+		t.Errorf("picker should start on the current cluster, got %d", app.pickerSelected)
+	}
+
+	view := app.View()
+	if !strings.Contains(view, "Switch Cluster") || !strings.Contains(view, "local (current)") {
+		t.Error("View() should render the cluster picker")
+	}
+
+	SendKey(app, "esc")
+	if app.pickerOpen {
+		t.Error("Esc should close the cluster picker")
+	}
+}
+
+func TestClusterPicker_CapturesNavigationKeys(t *testing.T) {
+	app, _ := newSwitchableTestApp(t)
+	selectedItem := app.selectedItem
+
+	SendKey(app, "c")
+	SendKey(app, "down")
+
+	if app.pickerSelected != 1 {
+		t.Errorf("pickerSelected = %d, want 1", app.pickerSelected)
+	}
+	if app.selectedItem != selectedItem {
+		t.Error("menu selection should not change while the picker is open")
+	}
+
+	// Can't move past the last entry
+	SendKey(app, "down")
+	if app.pickerSelected != 1 {
+		t.Errorf("pickerSelected = %d, want 1 (clamped)", app.pickerSelected)
+	}
+}
+
+func TestClusterPicker_SelectCurrentClosesPicker(t *testing.T) {
+	app, connects := newSwitchableTestApp(t)
+
+	SendKey(app, "c")
+	_, cmd := SendKey(app, "enter")
+
+	if cmd != nil {
+		t.Error("selecting the current cluster should not reconnect")
+	}
+	if app.pickerOpen {
+		t.Error("picker should close after selecting the current cluster")
+	}
+	if *connects != 0 {
+		t.Errorf("connector called %d times, want 0", *connects)
+	}
+}
+
+func TestClusterPicker_SwitchResetsState(t *testing.T) {
+	app, connects := newSwitchableTestApp(t)
+
+	// Seed metric time series with data from the first cluster
+	now := time.Now()
+	app.metricsTimeSeries.AddSnapshot(&MetricsSnapshot{Timestamp: now, IndexTotal: 100, SearchTotal: 100})
+	app.metricsTimeSeries.AddSnapshot(&MetricsSnapshot{Timestamp: now.Add(5 * time.Second), IndexTotal: 200, SearchTotal: 200})
+	app.threadPoolTimeSeries.AddSnapshot(&ThreadPoolSnapshot{Timestamp: now, Pools: map[string]ThreadPoolStats{}})
+	app.threadPoolTimeSeries.AddSnapshot(&ThreadPoolSnapshot{Timestamp: now.Add(5 * time.Second), Pools: map[string]ThreadPoolStats{}})
+	if app.metricsTimeSeries.Size() == 0 || app.threadPoolTimeSeries.Size() == 0 {
+		t.Fatal("test setup should populate time series")
+	}
+	oldEpoch := app.connEpoch
+
+	SendKey(app, "c")
+	SendKey(app, "down")
+	_, cmd := SendKey(app, "enter")
+	if !app.pickerConnecting {
+		t.Error("picker should show connecting state")
+	}
+
+	msg := ExecuteCommand(cmd)
+	_, cmd = app.Update(msg)
+
+	if *connects != 1 {
+		t.Errorf("connector called %d times, want 1", *connects)
+	}
+	if app.clusterName != "staging" || app.endpoint != "https://staging:9200" || app.authMode != "basic (admin)" {
+		t.Errorf("connection not switched: %s %s %s", app.clusterName, app.endpoint, app.authMode)
+	}
+	if app.refreshInterval != 30*time.Second {
+		t.Errorf("refreshInterval = %v, want the profile's 30s", app.refreshInterval)
+	}
+	if app.snapshotMaxAge != 72*time.Hour {
+		t.Errorf("snapshotMaxAge = %v, want the profile's 72h", app.snapshotMaxAge)
+	}
+	if app.connEpoch != oldEpoch+1 {
+		t.Errorf("connEpoch = %d, want %d", app.connEpoch, oldEpoch+1)
+	}
+	if app.health != nil || app.nodes != nil || app.indices != nil {
+		t.Error("data from the previous cluster should be cleared")
+	}
+	if app.metricsTimeSeries.Size() != 0 || app.metricsTimeSeries.LastSnapshot != nil {
+		t.Error("metrics time series should be cleared on switch")
+	}
+	if app.threadPoolTimeSeries.Size() != 0 {
+		t.Error("thread pool time series should be cleared on switch")
+	}
+	if !app.loading || app.pickerOpen {
+		t.Error("app should be loading the new cluster with the picker closed")
+	}
+
+	// The returned command refreshes the new cluster
+	app.Update(ExecuteCommand(cmd))
+	if app.health == nil || app.loading {
+		t.Error("new cluster data should load after switching")
+	}
+	if !strings.Contains(app.View(), "Cluster: staging") {
+		t.Error("title bar should show the new cluster name")
+	}
+}
+
+func TestClusterPicker_ConnectErrorKeepsPickerOpen(t *testing.T) {
+	app, _ := newSwitchableTestApp(t)
+	app.clusterNames = []string{"local", "broken"}
+
+	SendKey(app, "c")
+	SendKey(app, "down")
+	_, cmd := SendKey(app, "enter")
+	app.Update(ExecuteCommand(cmd))
+
+	if !app.pickerOpen {
+		t.Error("picker should stay open after a failed connection")
+	}
+	if app.pickerErr == nil || !strings.Contains(app.pickerErr.Error(), "broken") {
+		t.Errorf("pickerErr = %v, want error naming the cluster", app.pickerErr)
+	}
+	if app.clusterName != "local" || app.health == nil {
+		t.Error("failed switch should keep the current cluster and its data")
+	}
+}
+
+func TestClusterPicker_NavigationIgnoredWhileConnecting(t *testing.T) {
+	app, _ := newSwitchableTestApp(t)
+
+	SendKey(app, "c")
+	SendKey(app, "down")
+	_, cmd := SendKey(app, "enter")
+	SendKey(app, "up")
+
+	if app.pickerSelected != 1 {
+		t.Errorf("pickerSelected = %d, want 1 while connecting", app.pickerSelected)
+	}
+	if !strings.Contains(app.View(), "Connecting to staging...") {
+		t.Error("picker should name the cluster being connected to")
+	}
+
+	app.Update(ExecuteCommand(cmd))
+	if app.clusterName != "staging" {
+		t.Errorf("clusterName = %q, want staging", app.clusterName)
+	}
+}
+
+func TestClusterPicker_SwitchWarningsShown(t *testing.T) {
+	app, _ := newSwitchableTestApp(t)
+	connect := app.connect
+	app.connect = func(name string) (*Connection, error) {
+		conn, err := connect(name)
+		if err != nil {
+			return nil, err
+		}
+		conn.Warnings = []string{"skipping CA: certificate \"old-root\" expired"}
+		return conn, nil
+	}
+
+	SendKey(app, "c")
+	SendKey(app, "down")
+	_, cmd := SendKey(app, "enter")
+	app.Update(ExecuteCommand(cmd))
+
+	if app.clusterName != "staging" {
+		t.Fatalf("clusterName = %q, want staging", app.clusterName)
+	}
+	if !app.pickerOpen {
+		t.Fatal("picker should stay open to show connection warnings")
+	}
+	if !strings.Contains(app.View(), "Warning: skipping CA") {
+		t.Error("picker should render the connection warnings")
+	}
+
+	SendKey(app, "esc")
+	if app.pickerOpen || app.pickerWarnings != nil {
+		t.Error("esc should close the picker and drop the warnings")
+	}
+}
+
+func TestClusterPicker_CancelledConnectDropped(t *testing.T) {
+	app, _ := newSwitchableTestApp(t)
+
+	SendKey(app, "c")
+	SendKey(app, "down")
+	_, cancelled := SendKey(app, "enter")
+	SendKey(app, "esc")
+	if app.pickerOpen || app.pickerConnecting {
+		t.Fatal("esc should close the picker and stop waiting for the connect")
+	}
+
+	// The cancelled connect finishing later must not switch clusters
+	app.Update(ExecuteCommand(cancelled))
+	if app.clusterName != "local" || app.health == nil {
+		t.Errorf("cancelled connect switched to %q", app.clusterName)
+	}
+
+	// Nor may it stand in for a connect started after the cancel
+	SendKey(app, "c")
+	SendKey(app, "down")
+	_, retried := SendKey(app, "enter")
+	app.Update(ExecuteCommand(cancelled))
+	if app.clusterName != "local" || !app.pickerConnecting {
+		t.Error("the cancelled connect's result should be ignored while another connect is pending")
+	}
+	app.Update(ExecuteCommand(retried))
+	if app.clusterName != "staging" {
+		t.Errorf("clusterName = %q, want staging once the pending connect lands", app.clusterName)
+	}
+}
+
+func TestClusterPicker_StaleResponsesDropped(t *testing.T) {
+	app, _ := newSwitchableTestApp(t)
+
+	// Start a refresh against the current cluster, then switch before it lands
+	staleRefresh := app.refresh()
+	staleMetrics := app.refreshMetrics()
+
+	SendKey(app, "c")
+	SendKey(app, "down")
+	_, cmd := SendKey(app, "enter")
+	app.Update(ExecuteCommand(cmd))
+
+	app.Update(ExecuteCommand(staleRefresh))
+	if app.health != nil || !app.loading {
+		t.Error("refresh started before the switch should be ignored")
+	}
+
+	app.Update(ExecuteCommand(staleMetrics))
+	app.Update(ExecuteCommand(app.refreshMetrics()))
+	if app.metricsTimeSeries.LastSnapshot == nil {
+		t.Fatal("metrics from the new cluster should be recorded")
+	}
+	if app.metricsTimeSeries.Size() != 0 {
+		t.Error("stale metrics snapshot should not have been paired with the new cluster's baseline")
+	}
+}
+
+func TestClusterPicker_NoProfiles(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if !strings.Contains(app.View(), "No cluster profiles configured") {
+		t.Error("picker should explain how to configure clusters when none exist")
+	}
+
+	_, cmd := SendKey(app, "enter")
+	if cmd != nil {
+		t.Error("enter with no profiles should do nothing")
+	}
+}
+
+// blockingSource holds cluster health calls until released, so a cluster
+// switch can land while a refresh is in flight
+type blockingSource struct {
+	*FakeSource
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingSource) ClusterHealth(ctx context.Context) (*ClusterHealth, error) {
+	b.started <- struct{}{}
+	<-b.release
+	return b.FakeSource.ClusterHealth(ctx)
+}
+
+// TestClusterPicker_SwitchDuringRefresh tests that a refresh in flight keeps
+// using the cluster it was started against. Run with -race to check that
+// switching doesn't race with the fetch goroutines.
+func TestClusterPicker_SwitchDuringRefresh(t *testing.T) {
+	old := &blockingSource{
+		FakeSource: &FakeSource{HealthData: &ClusterHealth{ClusterName: "old-cluster"}},
+		started:    make(chan struct{}, 1),
+		release:    make(chan struct{}),
+	}
+	app := NewApp(old, "fake://old", "none")
+	SendWindowSize(app, 120, 40)
+
+	cmd := app.refresh()
+	done := make(chan tea.Msg)
+	go func() { done <- cmd() }()
+	<-old.started
+
+	app.switchCluster(&Connection{
+		Name:   "new",
+		Source: &FakeSource{HealthData: &ClusterHealth{ClusterName: "new-cluster"}},
+	})
+	app.WithRequestTimeout(time.Minute)
+	close(old.release)
+
+	msg, ok := (<-done).(refreshMsg)
+	if !ok {
+		t.Fatal("refresh should return a refreshMsg")
+	}
+	health, _ := msg.results[SourceHealth].data.(*ClusterHealth)
+	if health == nil || health.ClusterName != "old-cluster" {
+		t.Errorf("in-flight health = %+v, want it from the cluster the refresh started on", health)
+	}
+
+	app.Update(msg)
+	if app.health != nil {
+		t.Error("a refresh from before the switch should be dropped")
+	}
+}
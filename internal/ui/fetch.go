@@ -3,527 +3,338 @@ package ui
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vegasq/ostop/internal/source"
 )
 
-// refresh fetches cluster data in the background
-func (a *App) refresh() tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Fetch cluster health
-		health, err := a.fetchClusterHealth(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch cluster stats
-		stats, err := a.fetchClusterStats(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch nodes
-		nodes, err := a.fetchNodes(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
+// DefaultRequestTimeout bounds each cluster API call unless --timeout is given
+const DefaultRequestTimeout = 10 * time.Second
 
-		// Fetch indices
-		indices, err := a.fetchIndices(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch shards
-		shards, err := a.fetchShards(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch allocation
-		allocation, err := a.fetchAllocation(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch thread pool
-		threadPool, err := a.fetchThreadPool(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch tasks
-		tasks, err := a.fetchTasks(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch pending tasks
-		pendingTasks, err := a.fetchPendingTasks(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch recovery
-		recovery, err := a.fetchRecovery(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch segments
-		segments, err := a.fetchSegments(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch fielddata
-		fielddata, err := a.fetchFielddata(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch plugins
-		plugins, err := a.fetchPlugins(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		// Fetch templates
-		templates, err := a.fetchTemplates(ctx)
-		if err != nil {
-			return refreshMsg{err: err}
-		}
-
-		return refreshMsg{
-			health:       health,
-			stats:        stats,
-			nodes:        nodes,
-			indices:      indices,
-			shards:       shards,
-			allocation:   allocation,
-			threadPool:   threadPool,
-			tasks:        tasks,
-			pendingTasks: pendingTasks,
-			recovery:     recovery,
-			segments:     segments,
-			fielddata:    fielddata,
-			plugins:      plugins,
-			templates:    templates,
-		}
+// WithRequestTimeout sets the per-request timeout; zero keeps the default
+func (a *App) WithRequestTimeout(d time.Duration) *App {
+	if d > 0 {
+		a.requestTimeout = d
 	}
+	return a
 }
 
-// fetchClusterHealth calls the cluster health API
-func (a *App) fetchClusterHealth(ctx context.Context) (*ClusterHealth, error) {
-	res, err := a.client.Cluster.Health()
-	if err != nil {
-		return nil, fmt.Errorf("cluster health request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("cluster health API error: %s", res.Status())
+// fetchContext returns the parent context for new requests. It stays valid
+// until cancelInFlight is called.
+func (a *App) fetchContext() context.Context {
+	if a.fetchCtx == nil {
+		a.fetchCtx, a.cancelFetch = context.WithCancel(context.Background())
 	}
-
-	var health ClusterHealth
-	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
-		return nil, fmt.Errorf("failed to parse cluster health: %w", err)
-	}
-
-	return &health, nil
+	return a.fetchCtx
 }
 
-// fetchClusterStats calls the cluster stats API
-func (a *App) fetchClusterStats(ctx context.Context) (*ClusterStats, error) {
-	res, err := a.client.Cluster.Stats()
-	if err != nil {
-		return nil, fmt.Errorf("cluster stats request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("cluster stats API error: %s", res.Status())
-	}
-
-	var stats ClusterStats
-	if err := json.NewDecoder(res.Body).Decode(&stats); err != nil {
-		return nil, fmt.Errorf("failed to parse cluster stats: %w", err)
-	}
-
-	return &stats, nil
+// cancelInFlight aborts every in-flight request and makes sure their results
+// are dropped when they arrive
+func (a *App) cancelInFlight() {
+	if a.cancelFetch != nil {
+		a.cancelFetch()
+	}
+	a.fetchCtx = nil
+	a.cancelFetch = nil
+	a.refreshGen++
+	a.sourcePending = nil
+	a.hotThreadsLoading = false
+	a.ingestSimRunning = false
 }
 
-// fetchNodes calls the CAT nodes API
-func (a *App) fetchNodes(ctx context.Context) ([]NodeInfo, error) {
-	res, err := a.client.Cat.Nodes(
-		a.client.Cat.Nodes.WithFormat("json"),
-		a.client.Cat.Nodes.WithH("ip", "heap.percent", "ram.percent", "cpu", "load_1m", "load_5m", "load_15m", "node.role", "master", "name", "disk.used_percent", "disk.used", "disk.avail", "disk.total"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("nodes request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("nodes API error: %s", res.Status())
-	}
-
-	var nodes []NodeInfo
-	if err := json.NewDecoder(res.Body).Decode(&nodes); err != nil {
-		return nil, fmt.Errorf("failed to parse nodes: %w", err)
-	}
-
-	return nodes, nil
+// fetcher holds what background requests need from the App. It's copied
+// when a Cmd is built, so a cluster switch or timeout change in Update never
+// races with a request already in flight, and that request keeps going to
+// the cluster its epoch belongs to.
+type fetcher struct {
+	source  source.ClusterSource
+	timeout time.Duration
 }
 
-// fetchIndices calls the CAT indices API
-func (a *App) fetchIndices(ctx context.Context) ([]IndexInfo, error) {
-	res, err := a.client.Cat.Indices(
-		a.client.Cat.Indices.WithFormat("json"),
-		a.client.Cat.Indices.WithH("health", "status", "index", "uuid", "pri", "rep", "docs.count", "docs.deleted", "store.size", "pri.store.size"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("indices request failed: %w", err)
-	}
-	defer res.Body.Close()
+// fetcher returns a copy of the current source and request timeout
+func (a *App) fetcher() fetcher {
+	return fetcher{source: a.source, timeout: a.requestTimeout}
+}
 
-	if res.IsError() {
-		return nil, fmt.Errorf("indices API error: %s", res.Status())
+// requestContext bounds a single request by the configured timeout
+func (f fetcher) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if f.timeout <= 0 {
+		return context.WithCancel(ctx)
 	}
+	return context.WithTimeout(ctx, f.timeout)
+}
 
-	var indices []IndexInfo
-	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
-		return nil, fmt.Errorf("failed to parse indices: %w", err)
+// timeoutError replaces a deadline error with a readable message
+func (f fetcher) timeoutError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("request timed out after %s", f.timeout)
 	}
+	return err
+}
 
-	return indices, nil
+// refresh fetches the current view's data (plus shared header data) in the
+// background. Slow sources are skipped until their data is due
+func (a *App) refresh() tea.Cmd {
+	return a.refreshSources(a.dueSources(a.currentView, time.Now()))
 }
 
-// fetchShards calls the CAT shards API
-func (a *App) fetchShards(ctx context.Context) ([]ShardInfo, error) {
-	res, err := a.client.Cat.Shards(
-		a.client.Cat.Shards.WithFormat("json"),
-		a.client.Cat.Shards.WithH("index", "shard", "prirep", "state", "docs", "store", "ip", "node"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("shards request failed: %w", err)
-	}
-	defer res.Body.Close()
+// refreshSources fetches the given sources, plus the open drill-down, in the
+// background
+func (a *App) refreshSources(sources []DataSource) tea.Cmd {
+	a.markPending(sources)
 
-	if res.IsError() {
-		return nil, fmt.Errorf("shards API error: %s", res.Status())
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch, gen := a.connEpoch, a.refreshGen
+	cmd := func() tea.Msg {
+		return refreshMsg{
+			results: f.fetchSources(ctx, sources),
+			epoch:   epoch,
+			gen:     gen,
+		}
 	}
 
-	var shards []ShardInfo
-	if err := json.NewDecoder(res.Body).Decode(&shards); err != nil {
-		return nil, fmt.Errorf("failed to parse shards: %w", err)
+	// Drill-down views aren't data sources; keep the open node or index tab
+	// fresh too
+	switch a.currentView {
+	case ViewNodeDetail:
+		return tea.Batch(cmd, a.fetchNodeDetail())
+	case ViewShardExplain:
+		return tea.Batch(cmd, a.fetchShardExplain())
+	case ViewTemplateDetail:
+		return tea.Batch(cmd, a.fetchTemplateSimulation())
+	case ViewIndexSchema:
+		if tabCmd := a.fetchIndexTab(a.indexTab); tabCmd != nil {
+			return tea.Batch(cmd, tabCmd)
+		}
+	case ViewHotThreads:
+		// Samples are only retaken on request; this recovers one cancelled
+		// by 'r' or never taken
+		if a.hotThreads == nil && !a.hotThreadsLoading {
+			return tea.Batch(cmd, a.fetchHotThreads())
+		}
 	}
-
-	return shards, nil
+	return cmd
 }
 
 // fetchIndexMapping fetches the mapping for a specific index
 func (a *App) fetchIndexMapping() tea.Cmd {
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch, gen := a.connEpoch, a.refreshGen
+	index := a.selectedIndexName
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
 
-		// Call the indices get mapping API
-		res, err := a.client.Indices.GetMapping(
-			a.client.Indices.GetMapping.WithIndex(a.selectedIndexName),
-			a.client.Indices.GetMapping.WithContext(ctx),
-		)
-		if err != nil {
-			return mappingMsg{err: fmt.Errorf("mapping request failed: %w", err)}
-		}
-		defer res.Body.Close()
+		msg := f.loadIndexMapping(ctx, index)
+		msg.err = f.timeoutError(msg.err)
+		msg.epoch = epoch
+		msg.gen = gen
+		return msg
+	}
+}
 
-		if res.IsError() {
-			return mappingMsg{err: fmt.Errorf("mapping API error: %s", res.Status())}
-		}
+// loadIndexMapping fetches the mapping for an index
+func (f fetcher) loadIndexMapping(ctx context.Context, index string) mappingMsg {
+	mapping, err := f.source.IndexMapping(ctx, index)
+	return mappingMsg{mapping: mapping, err: err}
+}
 
-		// Parse the response
-		var response map[string]interface{}
-		if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
-			return mappingMsg{err: fmt.Errorf("failed to parse mapping: %w", err)}
+// fetchIndexTab fetches the data behind an index drill-down tab. The Schema
+// tab is loaded on entry and the Shards tab uses the shards data source, so
+// neither has a fetch of its own.
+func (a *App) fetchIndexTab(tab indexTab) tea.Cmd {
+	index, defaults := a.selectedIndexName, a.indexDefaults
+	f := a.fetcher()
+
+	var load func(ctx context.Context) (interface{}, error)
+	switch tab {
+	case indexTabSettings:
+		load = func(ctx context.Context) (interface{}, error) {
+			return f.source.IndexSettings(ctx, index, defaults)
 		}
-
-		// Extract the mapping for the specific index
-		// Response format: { "index_name": { "mappings": { ... } } }
-		indexData, ok := response[a.selectedIndexName].(map[string]interface{})
-		if !ok {
-			return mappingMsg{err: fmt.Errorf("unexpected mapping response format")}
+	case indexTabStats:
+		load = func(ctx context.Context) (interface{}, error) {
+			return f.source.IndexStats(ctx, index)
 		}
-
-		mappings, ok := indexData["mappings"].(map[string]interface{})
-		if !ok {
-			return mappingMsg{err: fmt.Errorf("no mappings found in response")}
+	case indexTabAliases:
+		load = func(ctx context.Context) (interface{}, error) {
+			return f.source.IndexAliases(ctx, index)
 		}
-
-		mapping := &IndexMapping{
-			IndexName: a.selectedIndexName,
-			Mappings:  mappings,
+	case indexTabPolicy:
+		load = func(ctx context.Context) (interface{}, error) {
+			return f.source.ISMExplain(ctx, index)
 		}
-
-		return mappingMsg{mapping: mapping}
-	}
-}
-
-// fetchAllocation calls the CAT allocation API
-func (a *App) fetchAllocation(ctx context.Context) ([]AllocationInfo, error) {
-	res, err := a.client.Cat.Allocation(
-		a.client.Cat.Allocation.WithFormat("json"),
-		a.client.Cat.Allocation.WithH("shards", "disk.indices", "disk.used", "disk.avail", "disk.total", "disk.percent", "host", "ip", "node"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("allocation request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("allocation API error: %s", res.Status())
-	}
-
-	var allocation []AllocationInfo
-	if err := json.NewDecoder(res.Body).Decode(&allocation); err != nil {
-		return nil, fmt.Errorf("failed to parse allocation: %w", err)
-	}
-
-	return allocation, nil
-}
-
-// fetchThreadPool calls the CAT thread_pool API
-func (a *App) fetchThreadPool(ctx context.Context) ([]ThreadPoolInfo, error) {
-	res, err := a.client.Cat.ThreadPool(
-		a.client.Cat.ThreadPool.WithFormat("json"),
-		a.client.Cat.ThreadPool.WithH("node_name", "name", "active", "queue", "rejected", "completed", "size"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("thread_pool request failed: %w", err)
+	default:
+		return nil
 	}
-	defer res.Body.Close()
 
-	if res.IsError() {
-		return nil, fmt.Errorf("thread_pool API error: %s", res.Status())
-	}
-
-	var threadPool []ThreadPoolInfo
-	if err := json.NewDecoder(res.Body).Decode(&threadPool); err != nil {
-		return nil, fmt.Errorf("failed to parse thread_pool: %w", err)
-	}
-
-	return threadPool, nil
-}
-
-// fetchTasks calls the CAT tasks API
-func (a *App) fetchTasks(ctx context.Context) ([]TaskInfo, error) {
-	res, err := a.client.Cat.Tasks(
-		a.client.Cat.Tasks.WithFormat("json"),
-		a.client.Cat.Tasks.WithDetailed(true),
-		a.client.Cat.Tasks.WithH("action", "task_id", "parent_task_id", "type", "start_time", "timestamp", "running_time", "ip", "node", "description"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("tasks request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("tasks API error: %s", res.Status())
-	}
-
-	var tasks []TaskInfo
-	if err := json.NewDecoder(res.Body).Decode(&tasks); err != nil {
-		return nil, fmt.Errorf("failed to parse tasks: %w", err)
+	ctx := a.fetchContext()
+	epoch, gen := a.connEpoch, a.refreshGen
+	return func() tea.Msg {
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
+
+		data, err := load(ctx)
+		return indexTabMsg{
+			index:    index,
+			tab:      tab,
+			defaults: defaults,
+			data:     data,
+			err:      f.timeoutError(err),
+			epoch:    epoch,
+			gen:      gen,
+		}
 	}
-
-	return tasks, nil
 }
 
-// fetchPendingTasks calls the CAT pending_tasks API
-func (a *App) fetchPendingTasks(ctx context.Context) ([]PendingTaskInfo, error) {
-	res, err := a.client.Cat.PendingTasks(
-		a.client.Cat.PendingTasks.WithFormat("json"),
-		a.client.Cat.PendingTasks.WithH("insertOrder", "timeInQueue", "priority", "source"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("pending_tasks request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("pending_tasks API error: %s", res.Status())
-	}
+// fetchShardExplain asks the cluster why the selected shard copy is or isn't
+// allocated
+func (a *App) fetchShardExplain() tea.Cmd {
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch, gen := a.connEpoch, a.refreshGen
+	shard := a.explainShard
+	return func() tea.Msg {
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
 
-	var pendingTasks []PendingTaskInfo
-	if err := json.NewDecoder(res.Body).Decode(&pendingTasks); err != nil {
-		return nil, fmt.Errorf("failed to parse pending_tasks: %w", err)
+		msg := shardExplainMsg{shard: shard, epoch: epoch, gen: gen}
+		number, err := strconv.Atoi(shard.Shard)
+		if err != nil {
+			msg.err = fmt.Errorf("invalid shard number %q", shard.Shard)
+			return msg
+		}
+		msg.explanation, msg.err = f.source.AllocationExplain(ctx, shard.Index, number, shard.Prirep == "p")
+		msg.err = f.timeoutError(msg.err)
+		return msg
 	}
-
-	return pendingTasks, nil
 }
 
-// fetchRecovery calls the CAT recovery API
-func (a *App) fetchRecovery(ctx context.Context) ([]RecoveryInfo, error) {
-	res, err := a.client.Cat.Recovery(
-		a.client.Cat.Recovery.WithFormat("json"),
-		a.client.Cat.Recovery.WithActiveOnly(true),
-		a.client.Cat.Recovery.WithH("index", "shard", "time", "type", "stage", "source_node", "target_node", "files", "files_recovered", "files_percent", "bytes", "bytes_recovered", "bytes_percent"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("recovery request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("recovery API error: %s", res.Status())
-	}
+// fetchTemplateSimulation resolves the template shown in the template
+// drill-down
+func (a *App) fetchTemplateSimulation() tea.Cmd {
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch, gen := a.connEpoch, a.refreshGen
+	name := a.templateDetail
+	return func() tea.Msg {
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
 
-	var recovery []RecoveryInfo
-	if err := json.NewDecoder(res.Body).Decode(&recovery); err != nil {
-		return nil, fmt.Errorf("failed to parse recovery: %w", err)
+		simulated, err := f.source.SimulateIndexTemplate(ctx, name)
+		return templateSimMsg{name: name, simulated: simulated, err: f.timeoutError(err), epoch: epoch, gen: gen}
 	}
-
-	return recovery, nil
 }
 
-// fetchSegments calls the CAT segments API
-func (a *App) fetchSegments(ctx context.Context) ([]SegmentInfo, error) {
-	res, err := a.client.Cat.Segments(
-		a.client.Cat.Segments.WithFormat("json"),
-		a.client.Cat.Segments.WithH("index", "shard", "prirep", "ip", "segment", "generation", "docs.count", "docs.deleted", "size", "committed"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("segments request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("segments API error: %s", res.Status())
-	}
+// fetchIngestSimulation runs sample documents through the pipeline shown in
+// the simulate drill-down
+func (a *App) fetchIngestSimulation(docs []json.RawMessage) tea.Cmd {
+	a.ingestSimRunning = true
+	a.ingestSimErr = nil
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch, gen := a.connEpoch, a.refreshGen
+	pipeline := a.ingestDetail
+	return func() tea.Msg {
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
 
-	var segments []SegmentInfo
-	if err := json.NewDecoder(res.Body).Decode(&segments); err != nil {
-		return nil, fmt.Errorf("failed to parse segments: %w", err)
+		docs, err := f.source.SimulatePipeline(ctx, pipeline, docs)
+		return ingestSimMsg{pipeline: pipeline, docs: docs, err: f.timeoutError(err), epoch: epoch, gen: gen}
 	}
-
-	return segments, nil
 }
 
-// fetchFielddata calls the CAT fielddata API
-func (a *App) fetchFielddata(ctx context.Context) ([]FielddataInfo, error) {
-	res, err := a.client.Cat.Fielddata(
-		a.client.Cat.Fielddata.WithFormat("json"),
-		a.client.Cat.Fielddata.WithH("id", "host", "ip", "node", "field", "size"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("fielddata request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("fielddata API error: %s", res.Status())
-	}
-
-	var fielddata []FielddataInfo
-	if err := json.NewDecoder(res.Body).Decode(&fielddata); err != nil {
-		return nil, fmt.Errorf("failed to parse fielddata: %w", err)
+// fetchHotThreads samples hot threads with the current options. The request
+// takes at least the sampling interval to answer.
+func (a *App) fetchHotThreads() tea.Cmd {
+	a.hotThreadsLoading = true
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch, gen := a.connEpoch, a.refreshGen
+	opts := a.hotThreadsOpts
+	return func() tea.Msg {
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
+
+		nodes, err := f.source.HotThreads(ctx, opts)
+		return hotThreadsMsg{
+			opts:  opts,
+			nodes: nodes,
+			at:    time.Now(),
+			err:   f.timeoutError(err),
+			epoch: epoch,
+			gen:   gen,
+		}
 	}
-
-	return fielddata, nil
 }
 
-// fetchPlugins calls the CAT plugins API
-func (a *App) fetchPlugins(ctx context.Context) ([]PluginInfo, error) {
-	res, err := a.client.Cat.Plugins(
-		a.client.Cat.Plugins.WithFormat("json"),
-		a.client.Cat.Plugins.WithH("id", "name", "component", "version", "description"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("plugins request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("plugins API error: %s", res.Status())
-	}
-
-	var plugins []PluginInfo
-	if err := json.NewDecoder(res.Body).Decode(&plugins); err != nil {
-		return nil, fmt.Errorf("failed to parse plugins: %w", err)
+// fetchNodeDetail fetches stats and build info for the selected node
+func (a *App) fetchNodeDetail() tea.Cmd {
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch, gen := a.connEpoch, a.refreshGen
+	node := a.selectedNodeName
+	return func() tea.Msg {
+		ctx, cancel := f.requestContext(ctx)
+		defer cancel()
+
+		detail, err := f.source.NodeDetail(ctx, node)
+		return nodeDetailMsg{
+			node:   node,
+			detail: detail,
+			err:    f.timeoutError(err),
+			epoch:  epoch,
+			gen:    gen,
+		}
 	}
-
-	return plugins, nil
 }
 
-// fetchTemplates calls the CAT templates API
-func (a *App) fetchTemplates(ctx context.Context) ([]TemplateInfo, error) {
-	res, err := a.client.Cat.Templates(
-		a.client.Cat.Templates.WithFormat("json"),
-		a.client.Cat.Templates.WithH("name", "index_patterns", "order", "version"),
-	)
+// fetchClusterMetrics retrieves current cumulative indexing and search
+// metrics, and the requests every node's thread pools have rejected. The
+// rejections are optional: without thread pool stats the rest of the sample
+// is still kept
+func (f fetcher) fetchClusterMetrics(ctx context.Context) (*MetricsSnapshot, error) {
+	stats, err := f.source.ActivityStats(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("templates request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("templates API error: %s", res.Status())
+		return nil, err
 	}
 
-	var templates []TemplateInfo
-	if err := json.NewDecoder(res.Body).Decode(&templates); err != nil {
-		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	threadPools, err := f.source.ThreadPool(ctx)
+	var rejectedTotal int64
+	for _, tp := range threadPools {
+		var rejected int64
+		fmt.Sscanf(tp.Rejected, "%d", &rejected)
+		rejectedTotal += rejected
 	}
 
-	return templates, nil
+	return &MetricsSnapshot{
+		Timestamp:         time.Now(),
+		IndexTotal:        stats.IndexTotal,
+		SearchTotal:       stats.SearchTotal,
+		IndexTimeInMillis: stats.IndexTimeInMillis,
+		QueryTimeInMillis: stats.QueryTimeInMillis,
+		FetchTotal:        stats.FetchTotal,
+		FetchTimeInMillis: stats.FetchTimeInMillis,
+		RejectedTotal:     rejectedTotal,
+		RejectedUnknown:   err != nil,
+		ScrollCurrent:     stats.ScrollCurrent,
+		PITCurrent:        stats.PITCurrent,
+	}, nil
 }
 
-// fetchClusterMetrics retrieves current cumulative indexing and search metrics
-func (a *App) fetchClusterMetrics(ctx context.Context) (*MetricsSnapshot, error) {
-	// Use indices stats API to get cluster-wide metrics
-	res, err := a.client.Indices.Stats(
-		a.client.Indices.Stats.WithContext(ctx),
-	)
+// fetchIndexActivity retrieves the cumulative indexing and search counters
+// of the named indices, or of every index when none are named
+func (f fetcher) fetchIndexActivity(ctx context.Context, names ...string) (*IndexActivitySnapshot, error) {
+	indices, err := f.source.IndexActivity(ctx, names...)
 	if err != nil {
-		return nil, fmt.Errorf("cluster metrics request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		return nil, fmt.Errorf("cluster metrics API error: %s", res.Status())
-	}
-
-	var statsResponse struct {
-		All struct {
-			Primaries struct {
-				Indexing struct {
-					IndexTotal int64 `json:"index_total"`
-				} `json:"indexing"`
-				Search struct {
-					QueryTotal int64 `json:"query_total"`
-				} `json:"search"`
-			} `json:"primaries"`
-		} `json:"_all"`
-	}
-
-	if err := json.NewDecoder(res.Body).Decode(&statsResponse); err != nil {
-		return nil, fmt.Errorf("failed to parse cluster metrics: %w", err)
+		return nil, err
 	}
-
-	return &MetricsSnapshot{
-		Timestamp:   time.Now(),
-		IndexTotal:  statsResponse.All.Primaries.Indexing.IndexTotal,
-		SearchTotal: statsResponse.All.Primaries.Search.QueryTotal,
-	}, nil
+	return newIndexActivitySnapshot(indices, time.Now()), nil
 }
 
 // fetchThreadPoolMetrics fetches thread pool statistics and aggregates them
-func (a *App) fetchThreadPoolMetrics(ctx context.Context) (*ThreadPoolSnapshot, error) {
+func (f fetcher) fetchThreadPoolMetrics(ctx context.Context) (*ThreadPoolSnapshot, error) {
 	// Fetch all thread pool data
-	threadPools, err := a.fetchThreadPool(ctx)
+	threadPools, err := f.source.ThreadPool(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	opensearch "github.com/opensearch-project/opensearch-go/v2"
+)
+
+// hangingTransport never answers requests whose path contains hang, like a
+// cluster that stopped responding; other requests go to next
+type hangingTransport struct {
+	next http.RoundTripper
+	hang string
+}
+
+func (h *hangingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.Contains(req.URL.Path, h.hang) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+	return h.next.RoundTrip(req)
+}
+
+// newHangingTestApp returns an app whose requests to hang never complete
+func newHangingTestApp(t *testing.T, hang string) *App {
+	t.Helper()
+
+	transport := NewMockTransport()
+	if err := transport.LoadAllFixtures(); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	client, err := opensearch.NewClient(opensearch.Config{
+		Addresses: []string{"http://localhost:9200"},
+		Transport: &hangingTransport{next: transport, hang: hang},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	return NewTestApp(client, "http://localhost:9200")
+}
+
+func TestFetch_TimeoutIsPerSourceError(t *testing.T) {
+	app := newHangingTestApp(t, "/_cat/nodes").WithRequestTimeout(50 * time.Millisecond)
+	app.currentView = ViewNodes
+
+	start := time.Now()
+	app.Update(ExecuteCommand(app.refresh()))
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("refresh took %v, the timeout should stop a hung request", elapsed)
+	}
+
+	if app.loading {
+		t.Error("app should not stay in the loading state after a timeout")
+	}
+	if app.err != nil {
+		t.Errorf("a single timed out source should not fail the refresh: %v", app.err)
+	}
+	err := app.sourceErrs[SourceNodes]
+	if err == nil || !strings.Contains(err.Error(), "timed out after 50ms") {
+		t.Errorf("nodes error = %v, want a timeout error", err)
+	}
+	if app.health == nil {
+		t.Error("health should load while nodes times out")
+	}
+}
+
+func TestFetch_ManualRefreshCancelsInFlight(t *testing.T) {
+	app := newHangingTestApp(t, "/_cluster/health").WithRequestTimeout(time.Minute)
+
+	stale := app.refresh()
+	ctx := app.fetchContext()
+
+	_, cmd := SendKey(app, "r")
+	if ctx.Err() == nil {
+		t.Fatal("'r' should cancel in-flight requests")
+	}
+
+	// The cancelled refresh returns promptly and is ignored
+	start := time.Now()
+	app.Update(ExecuteCommand(stale))
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("cancelled refresh took %v to return", elapsed)
+	}
+	if app.err != nil || len(app.sourceErrs) != 0 {
+		t.Errorf("cancelled refresh results should be dropped: %v %v", app.err, app.sourceErrs)
+	}
+	if !app.loading {
+		t.Error("app should still be waiting for the new refresh")
+	}
+
+	if cmd == nil {
+		t.Error("'r' should start a new refresh")
+	}
+	if app.fetchContext().Err() != nil {
+		t.Error("the new refresh should get a fresh context")
+	}
+}
+
+func TestFetch_ClusterSwitchCancelsInFlight(t *testing.T) {
+	app, _ := newSwitchableTestApp(t)
+	ctx := app.fetchContext()
+
+	SendKey(app, "c")
+	SendKey(app, "down")
+	_, cmd := SendKey(app, "enter")
+	app.Update(ExecuteCommand(cmd))
+
+	if ctx.Err() == nil {
+		t.Error("switching clusters should cancel requests to the old cluster")
+	}
+}
+
+func TestFetch_MappingTimeout(t *testing.T) {
+	app := newHangingTestApp(t, "/_mapping").WithRequestTimeout(50 * time.Millisecond)
+	app.selectedIndexName = "logs"
+
+	app.Update(ExecuteCommand(app.fetchIndexMapping()))
+
+	if app.err == nil || !strings.Contains(app.err.Error(), "timed out") {
+		t.Errorf("err = %v, want a timeout error", app.err)
+	}
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxHotThreads caps the busiest threads requested per node
+const maxHotThreads = 20
+
+// hotThreadsTypes are the thread states hot threads can sample, in the order
+// 't' cycles through them
+var hotThreadsTypes = []string{"cpu", "wait", "block"}
+
+// hotThreadsIntervals are the sampling intervals 'i' cycles through
+var hotThreadsIntervals = []time.Duration{
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+}
+
+// defaultHotThreadsOptions match the API's own defaults
+var defaultHotThreadsOptions = HotThreadsOptions{
+	Threads:  3,
+	Interval: 500 * time.Millisecond,
+	Type:     "cpu",
+}
+
+// hotThreadsSample is one hot threads report and when it was taken
+type hotThreadsSample struct {
+	opts  HotThreadsOptions
+	nodes []NodeHotThreads
+	at    time.Time
+}
+
+// clearHotThreads drops both hot threads samples
+func (a *App) clearHotThreads() {
+	a.hotThreads = nil
+	a.hotThreadsPrev = nil
+	a.hotThreadsErr = nil
+	a.hotThreadsLoading = false
+}
+
+// openHotThreads switches to the Hot Threads view sampling one node
+func (a *App) openHotThreads(node string) tea.Cmd {
+	a.selectedNodeName = ""
+	a.nodeDetail = nil
+	a.nodeDetailErr = nil
+
+	a.clearHotThreads()
+	a.hotThreadsOpts.Node = node
+	a.selectedItem = int(ViewHotThreads)
+	return a.updateViewFromSelectionCmd()
+}
+
+// hotThreadsKey handles the Hot Threads view's sampling keys
+func (a *App) hotThreadsKey(key string) tea.Cmd {
+	opts := a.hotThreadsOpts
+	switch key {
+	case "s":
+		// Keep the current sample on screen next to the new one
+		if a.hotThreadsLoading {
+			return nil
+		}
+		if a.hotThreads != nil {
+			a.hotThreadsPrev = a.hotThreads
+			a.hotThreads = nil
+		}
+		a.hotThreadsErr = nil
+		a.updateViewportContent()
+		return a.fetchHotThreads()
+	case "t":
+		opts.Type = hotThreadsTypes[(indexOf(hotThreadsTypes, opts.Type)+1)%len(hotThreadsTypes)]
+	case "i":
+		next := 0
+		for i, interval := range hotThreadsIntervals {
+			if interval == opts.Interval {
+				next = (i + 1) % len(hotThreadsIntervals)
+			}
+		}
+		opts.Interval = hotThreadsIntervals[next]
+	case "]":
+		opts.Threads = min(opts.Threads+1, maxHotThreads)
+	case "[":
+		opts.Threads = max(opts.Threads-1, 1)
+	case "n":
+		targets := append([]string{""}, a.hotThreadsNodes()...)
+		opts.Node = targets[(indexOf(targets, opts.Node)+1)%len(targets)]
+	}
+
+	if opts == a.hotThreadsOpts {
+		return nil
+	}
+
+	// Samples taken with other options aren't comparable, so start over
+	a.clearHotThreads()
+	a.hotThreadsOpts = opts
+	a.updateViewportContent()
+	return a.fetchHotThreads()
+}
+
+// hotThreadsNodes returns the node names 'n' cycles through
+func (a *App) hotThreadsNodes() []string {
+	names := make([]string, 0, len(a.nodes))
+	for _, node := range a.nodes {
+		names = append(names, node.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// indexOf returns the position of s in list, or -1
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// clearIndexDetail forgets the index drill-down and all of its tabs
+func (a *App) clearIndexDetail() {
+	a.selectedIndexName = ""
+	a.indexFromAliases = false
+	a.indexMapping = nil
+	a.indexTab = indexTabSchema
+	a.indexDefaults = false
+	a.indexSettings = nil
+	a.indexStats = nil
+	a.indexAliases = nil
+	a.indexPolicy = nil
+	a.indexTabLoaded = nil
+	a.indexTabErrs = nil
+}
+
+// switchIndexTab moves step tabs along, wrapping around, and loads the new
+// tab's data the first time it is shown
+func (a *App) switchIndexTab(step int) tea.Cmd {
+	count := len(indexTabNames)
+	a.indexTab = indexTab((int(a.indexTab) + step + count) % count)
+
+	a.updateViewportContent()
+	if a.viewportReady {
+		a.viewport.GotoTop()
+	}
+
+	if a.indexTab == indexTabShards {
+		return a.fetchViewData()
+	}
+	if a.indexTabLoaded[a.indexTab] {
+		return nil
+	}
+	return a.fetchIndexTab(a.indexTab)
+}
+
+// applyIndexTab stores a tab's data, keeping the last good data when a
+// refresh fails
+func (a *App) applyIndexTab(msg indexTabMsg) {
+	// Settings requested before include_defaults was toggled
+	if msg.tab == indexTabSettings && msg.defaults != a.indexDefaults {
+		return
+	}
+
+	if a.indexTabErrs == nil {
+		a.indexTabErrs = make(map[indexTab]error)
+	}
+	if a.indexTabLoaded == nil {
+		a.indexTabLoaded = make(map[indexTab]bool)
+	}
+
+	// A cluster without the ISM plugin simply has no policies to show
+	if msg.tab == indexTabPolicy && errors.Is(msg.err, source.ErrISMUnavailable) {
+		a.indexPolicy = nil
+		a.indexTabLoaded[msg.tab] = true
+		a.indexTabErrs[msg.tab] = msg.err
+		return
+	}
+
+	if msg.err != nil {
+		a.indexTabErrs[msg.tab] = msg.err
+		return
+	}
+	delete(a.indexTabErrs, msg.tab)
+	a.indexTabLoaded[msg.tab] = true
+
+	switch data := msg.data.(type) {
+	case *IndexSettings:
+		a.indexSettings = data
+	case *IndexStats:
+		a.indexStats = data
+	case []IndexAlias:
+		a.indexAliases = data
+	case []ISMExplanation:
+		a.indexPolicy = nil
+		for i := range data {
+			if data[i].Index == msg.index {
+				a.indexPolicy = &data[i]
+			}
+		}
+	}
+}
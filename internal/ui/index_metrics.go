@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"time"
+)
+
+// newIndexActivitySnapshot keys every index's counters by name
+func newIndexActivitySnapshot(indices []IndexActivity, timestamp time.Time) *IndexActivitySnapshot {
+	snapshot := &IndexActivitySnapshot{
+		Timestamp: timestamp,
+		Indices:   make(map[string]IndexActivity, len(indices)),
+	}
+	for _, index := range indices {
+		snapshot.Indices[index.Index] = index
+	}
+	return snapshot
+}
+
+// IndexTimeSeries manages time-series data for per-index activity
+type IndexTimeSeries struct {
+	dataPoints    []IndexActivityDataPoint
+	maxDataPoints int
+	lastSnapshot  *IndexActivitySnapshot
+}
+
+// NewIndexTimeSeries creates a new per-index activity time series tracker
+func NewIndexTimeSeries(maxDataPoints int) *IndexTimeSeries {
+	return &IndexTimeSeries{
+		dataPoints:    make([]IndexActivityDataPoint, 0, maxDataPoints),
+		maxDataPoints: maxDataPoints,
+	}
+}
+
+// AddSnapshot adds a new snapshot and calculates every index's rates and
+// latencies. Returns true if a data point was added (false for the first
+// baseline snapshot)
+func (ts *IndexTimeSeries) AddSnapshot(snapshot *IndexActivitySnapshot) bool {
+	if ts.lastSnapshot == nil {
+		// First snapshot - use as baseline only
+		ts.lastSnapshot = snapshot
+		return false
+	}
+
+	// Calculate time delta
+	timeDelta := snapshot.Timestamp.Sub(ts.lastSnapshot.Timestamp).Seconds()
+	if timeDelta <= 0 {
+		// Skip if no time has passed
+		return false
+	}
+
+	dataPoint := IndexActivityDataPoint{
+		Timestamp: snapshot.Timestamp,
+		Indices:   make(map[string]IndexRates, len(snapshot.Indices)),
+	}
+	for name, current := range snapshot.Indices {
+		last, ok := ts.lastSnapshot.Indices[name]
+		if !ok {
+			// Index created since the last snapshot
+			continue
+		}
+		indexed := current.IndexTotal - last.IndexTotal
+		queries := current.QueryTotal - last.QueryTotal
+		if indexed < 0 || queries < 0 {
+			// Counter reset (index reopened or recreated) - set to 0
+			dataPoint.Indices[name] = IndexRates{}
+			continue
+		}
+		dataPoint.Indices[name] = IndexRates{
+			IndexRate:    float64(indexed) / timeDelta,
+			QueryRate:    float64(queries) / timeDelta,
+			IndexLatency: intervalLatency(current.IndexTimeInMillis-last.IndexTimeInMillis, indexed),
+			QueryLatency: intervalLatency(current.QueryTimeInMillis-last.QueryTimeInMillis, queries),
+		}
+	}
+
+	// Add to ring buffer
+	if len(ts.dataPoints) >= ts.maxDataPoints {
+		// Remove oldest
+		ts.dataPoints = ts.dataPoints[1:]
+	}
+	ts.dataPoints = append(ts.dataPoints, dataPoint)
+
+	// Update last snapshot
+	ts.lastSnapshot = snapshot
+	return true
+}
+
+// intervalLatency returns the average milliseconds per operation over an
+// interval, or 0 when nothing ran
+func intervalLatency(millis, count int64) float64 {
+	if count <= 0 || millis < 0 {
+		return 0
+	}
+	return float64(millis) / float64(count)
+}
+
+// Latest returns every index's rates from the newest data point
+func (ts *IndexTimeSeries) Latest() map[string]IndexRates {
+	if len(ts.dataPoints) == 0 {
+		return nil
+	}
+	return ts.dataPoints[len(ts.dataPoints)-1].Indices
+}
+
+// Series returns one metric of an index at every data point, oldest first
+func (ts *IndexTimeSeries) Series(index string, metric func(IndexRates) float64) []float64 {
+	values := make([]float64, 0, len(ts.dataPoints))
+	for _, dp := range ts.dataPoints {
+		values = append(values, metric(dp.Indices[index]))
+	}
+	return values
+}
+
+// indexMetricsSnapshot returns an index's counters as a Live Metrics
+// snapshot, so a pinned index is sampled and graphed like the cluster
+func indexMetricsSnapshot(index IndexActivity, timestamp time.Time) *MetricsSnapshot {
+	return &MetricsSnapshot{
+		Timestamp:         timestamp,
+		IndexTotal:        index.IndexTotal,
+		SearchTotal:       index.QueryTotal,
+		IndexTimeInMillis: index.IndexTimeInMillis,
+		QueryTimeInMillis: index.QueryTimeInMillis,
+	}
+}
+
+// Size returns the number of data points
+func (ts *IndexTimeSeries) Size() int {
+	return len(ts.dataPoints)
+}
+
+// Clear removes all data points
+func (ts *IndexTimeSeries) Clear() {
+	ts.dataPoints = make([]IndexActivityDataPoint, 0, ts.maxDataPoints)
+	ts.lastSnapshot = nil
+}
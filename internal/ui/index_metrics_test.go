@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIndexTimeSeries_AddSnapshot(t *testing.T) {
+	snapshot := func(seconds int64, indices ...IndexActivity) *IndexActivitySnapshot {
+		return newIndexActivitySnapshot(indices, time.Unix(seconds, 0))
+	}
+
+	ts := NewIndexTimeSeries(12)
+	if ts.AddSnapshot(snapshot(0,
+		IndexActivity{Index: "logs", IndexTotal: 1000, IndexTimeInMillis: 500, QueryTotal: 10, QueryTimeInMillis: 100},
+		IndexActivity{Index: "old", IndexTotal: 500})) {
+		t.Error("First snapshot should only be a baseline")
+	}
+	if ts.Latest() != nil {
+		t.Error("No rates should be known after the baseline")
+	}
+
+	if !ts.AddSnapshot(snapshot(5,
+		IndexActivity{Index: "logs", IndexTotal: 1500, IndexTimeInMillis: 1500, QueryTotal: 10, QueryTimeInMillis: 100},
+		IndexActivity{Index: "old", IndexTotal: 20},
+		IndexActivity{Index: "new", IndexTotal: 50})) {
+		t.Fatal("Second snapshot should add a data point")
+	}
+
+	latest := ts.Latest()
+	want := IndexRates{IndexRate: 100, QueryRate: 0, IndexLatency: 2, QueryLatency: 0}
+	if latest["logs"] != want {
+		t.Errorf("logs = %+v, want %+v", latest["logs"], want)
+	}
+	if latest["old"] != (IndexRates{}) {
+		t.Errorf("old = %+v, want zero rates after a counter reset", latest["old"])
+	}
+	if _, ok := latest["new"]; ok {
+		t.Error("An index without a baseline should have no rates yet")
+	}
+
+	if ts.AddSnapshot(snapshot(5)) {
+		t.Error("Snapshot with no time delta should be skipped")
+	}
+
+	if series := ts.Series("logs", func(r IndexRates) float64 { return r.IndexLatency }); len(series) != 1 || series[0] != 2 {
+		t.Errorf("Series() = %v, want [2]", series)
+	}
+
+	ts.Clear()
+	if ts.Size() != 0 || ts.AddSnapshot(snapshot(10)) {
+		t.Error("Clear() should drop the data points and the baseline")
+	}
+}
@@ -164,6 +164,7 @@ func TestIntegration_ComplexFlow_DrilldownErrorRecovery(t *testing.T) {
 	cmd := app.Init()
 	msg := ExecuteCommand(cmd)
 	app.Update(msg)
+	LoadAllSources(app)
 
 	SendWindowSize(app, 120, 40)
 
@@ -329,9 +330,19 @@ func TestIntegration_ComplexFlow_ErrorRecoveryLoop(t *testing.T) {
 	SendWindowSize(app, 120, 40)
 
 	// Cycle through: success, error, retry, success multiple times
-	endpoints := []string{"health", "stats", "nodes"}
-
-	for i, endpoint := range endpoints {
+	endpoints := []struct {
+		endpoint string
+		source   DataSource
+		view     View
+	}{
+		{"health", SourceHealth, ViewCluster},
+		{"stats", SourceStats, ViewCluster},
+		{"nodes", SourceNodes, ViewNodes},
+	}
+
+	for i, tt := range endpoints {
+		endpoint := tt.endpoint
+		app.currentView = tt.view
 		// Success
 		transport.ClearError(endpoint)
 		cmd := app.Init()
@@ -349,7 +360,7 @@ func TestIntegration_ComplexFlow_ErrorRecoveryLoop(t *testing.T) {
 		msg = ExecuteCommand(cmd)
 		app.Update(msg)
 
-		if app.err == nil {
+		if app.sourceErrs[tt.source] == nil {
 			t.Errorf("Iteration %d: Should have error", i)
 		}
 
@@ -360,8 +371,8 @@ func TestIntegration_ComplexFlow_ErrorRecoveryLoop(t *testing.T) {
 		msg = ExecuteCommand(cmd)
 		app.Update(msg)
 
-		if app.err != nil {
-			t.Errorf("Iteration %d: Retry should succeed: %v", i, app.err)
+		if app.err != nil || app.sourceErrs[tt.source] != nil {
+			t.Errorf("Iteration %d: Retry should succeed: %v", i, app.sourceErrs[tt.source])
 		}
 	}
 }
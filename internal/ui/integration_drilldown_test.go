@@ -1,9 +1,13 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vegasq/ostop/internal/source"
 )
 
 func TestIntegration_Drilldown_IndexSelection(t *testing.T) {
@@ -270,6 +274,7 @@ func TestIntegration_Drilldown_MappingError(t *testing.T) {
 	cmd := app.Init()
 	msg := ExecuteCommand(cmd)
 	app.Update(msg)
+	LoadAllSources(app)
 
 	SendWindowSize(app, 120, 40)
 
@@ -328,3 +333,710 @@ func TestIntegration_Drilldown_OnlyInIndicesView(t *testing.T) {
 		t.Error("View should not change when pressing Enter in non-indices view")
 	}
 }
+
+func TestIntegration_Drilldown_NodeSelection(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+
+	SendWindowSize(app, 120, 40)
+
+	app.currentView = ViewNodes
+	app.activePanel = PanelRight
+
+	if len(app.nodes) < 2 {
+		t.Fatal("Need at least two nodes in fixtures")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if app.selectedNode != 1 {
+		t.Errorf("selectedNode = %d, want 1", app.selectedNode)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyUp})
+	app.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if app.selectedNode != 0 {
+		t.Errorf("selectedNode should not go below 0, got %d", app.selectedNode)
+	}
+
+	app.selectedNode = len(app.nodes) - 1
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if app.selectedNode != len(app.nodes)-1 {
+		t.Errorf("selectedNode should not go above %d, got %d", len(app.nodes)-1, app.selectedNode)
+	}
+}
+
+func TestIntegration_Drilldown_NodeDetailLoaded(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+
+	SendWindowSize(app, 120, 40)
+
+	app.currentView = ViewNodes
+	app.activePanel = PanelRight
+	app.selectedNode = 0
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter should return a command to fetch node stats")
+	}
+	if app.currentView != ViewNodeDetail {
+		t.Fatalf("currentView = %v, want ViewNodeDetail", app.currentView)
+	}
+	if app.loading {
+		t.Error("node drill-down should show its own loading marker, not the loading screen")
+	}
+	if !strings.Contains(app.viewport.View(), "Loading node stats") {
+		t.Error("detail view should show that stats are loading")
+	}
+
+	selected, _ := app.selectedNodeInfo()
+	if app.selectedNodeName != selected.Name {
+		t.Errorf("selectedNodeName = %q, want %q", app.selectedNodeName, selected.Name)
+	}
+
+	app.Update(ExecuteCommand(cmd))
+	if app.nodeDetail == nil {
+		t.Fatal("node detail should be loaded")
+	}
+	if app.nodeDetail.Version != "2.11.0" {
+		t.Errorf("Version = %q, want 2.11.0 from the nodes info API", app.nodeDetail.Version)
+	}
+
+	// Esc returns to the node list
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentView != ViewNodes {
+		t.Errorf("currentView = %v, want ViewNodes after Esc", app.currentView)
+	}
+	if app.nodeDetail != nil || app.selectedNodeName != "" {
+		t.Error("Esc should clear the node detail")
+	}
+}
+
+func TestIntegration_Drilldown_NodeDetailStaleResponse(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+
+	app.currentView = ViewNodeDetail
+	app.selectedNodeName = "node-2"
+
+	// A response for a node the user already backed out of is dropped
+	app.Update(nodeDetailMsg{node: "node-1", detail: &NodeDetail{ID: "x"}, epoch: app.connEpoch, gen: app.refreshGen})
+	if app.nodeDetail != nil {
+		t.Error("detail for another node should be ignored")
+	}
+
+	app.Update(nodeDetailMsg{node: "node-2", err: fmt.Errorf("forbidden"), epoch: app.connEpoch, gen: app.refreshGen})
+	if app.err != nil {
+		t.Error("a failed node fetch should not replace the whole UI with an error")
+	}
+	if !strings.Contains(app.renderNodeDetailView(), "forbidden") {
+		t.Error("detail view should show the fetch error")
+	}
+}
+
+func TestIntegration_Drilldown_NodeDetailRefresh(t *testing.T) {
+	fake := &FakeSource{
+		HealthData:     &ClusterHealth{ClusterName: "fake", Status: "green"},
+		NodesData:      []NodeInfo{{Name: "node-1", NodeRole: "dim"}},
+		NodeDetailData: &NodeDetail{ID: "n1", Version: "2.11.0"},
+	}
+	app := NewApp(fake, "fake://", "none")
+	app.Update(ExecuteCommand(app.Init()))
+
+	app.currentView = ViewNodeDetail
+	app.selectedNodeName = "node-1"
+
+	// Refreshing on the detail view also refetches the node's stats
+	msg := ExecuteCommand(app.refresh())
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("refresh() on the node detail = %T, want a batch including the node fetch", msg)
+	}
+	for _, cmd := range batch {
+		app.Update(ExecuteCommand(cmd))
+	}
+	if app.nodeDetail == nil || app.nodeDetail.ID != "n1" {
+		t.Error("refresh should reload the node detail")
+	}
+}
+
+func TestIntegration_Drilldown_IndexTabs(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+
+	SendWindowSize(app, 120, 40)
+
+	app.currentView = ViewIndices
+	app.activePanel = PanelRight
+	app.selectedIndex = 0
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(ExecuteCommand(cmd))
+	if app.indexTab != indexTabSchema {
+		t.Fatalf("indexTab = %v, want the Schema tab on entry", app.indexTab)
+	}
+
+	// Right moves to Settings, which loads lazily
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if app.indexTab != indexTabSettings {
+		t.Fatalf("indexTab = %v, want Settings", app.indexTab)
+	}
+	if cmd == nil {
+		t.Fatal("showing the Settings tab should fetch settings")
+	}
+	if !strings.Contains(app.viewport.View(), "Loading settings") {
+		t.Error("Settings tab should show that it's loading")
+	}
+	app.Update(ExecuteCommand(cmd))
+	if app.indexSettings == nil || app.indexSettings.Settings["index.number_of_shards"] != "2" {
+		t.Fatalf("indexSettings = %+v, want the fixture settings", app.indexSettings)
+	}
+	if app.indexSettings.Defaults != nil {
+		t.Error("defaults should not be requested until toggled")
+	}
+
+	// d requests the settings again with include_defaults
+	_, cmd = SendKey(app, "d")
+	if !app.indexDefaults || cmd == nil {
+		t.Fatal("d should toggle defaults and refetch settings")
+	}
+	app.Update(ExecuteCommand(cmd))
+	if app.indexSettings == nil || app.indexSettings.Defaults["index.refresh_interval"] != "1s" {
+		t.Errorf("defaults = %+v, want them after toggling", app.indexSettings)
+	}
+
+	// Left wraps from Schema to Shards, which uses the shards source
+	app.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	app.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if app.indexTab != indexTabShards {
+		t.Fatalf("indexTab = %v, want Shards after wrapping left", app.indexTab)
+	}
+	if !strings.Contains(app.viewport.View(), "Shards (") {
+		t.Error("Shards tab should list the index's shards")
+	}
+
+	// Coming back to a loaded tab doesn't refetch
+	app.indexTab = indexTabStats
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyLeft}); cmd != nil {
+		t.Error("an already loaded tab should be kept fresh by refresh, not navigation")
+	}
+
+	// Esc resets the tab state for the next drill-down
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.indexTab != indexTabSchema || app.indexSettings != nil || app.indexDefaults {
+		t.Error("Esc should clear the index drill-down tabs")
+	}
+}
+
+func TestIntegration_Drilldown_IndexTabStaleResponse(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+
+	app.currentView = ViewIndexSchema
+	app.selectedIndexName = "logs"
+
+	app.Update(indexTabMsg{index: "other", tab: indexTabStats, data: &IndexStats{}, epoch: app.connEpoch, gen: app.refreshGen})
+	if app.indexStats != nil {
+		t.Error("stats for another index should be ignored")
+	}
+
+	// Settings requested before include_defaults was toggled are dropped
+	app.indexDefaults = true
+	app.Update(indexTabMsg{index: "logs", tab: indexTabSettings, data: &IndexSettings{}, epoch: app.connEpoch, gen: app.refreshGen})
+	if app.indexSettings != nil {
+		t.Error("settings without defaults should be ignored once defaults are requested")
+	}
+
+	app.Update(indexTabMsg{index: "logs", tab: indexTabAliases, err: fmt.Errorf("forbidden"), epoch: app.connEpoch, gen: app.refreshGen})
+	if app.err != nil {
+		t.Error("a failed tab fetch should not replace the whole UI with an error")
+	}
+	app.indexTab = indexTabAliases
+	if !strings.Contains(app.renderIndexSchemaView(), "forbidden") {
+		t.Error("the tab should show its fetch error")
+	}
+}
+
+func TestIntegration_Drilldown_IndexTabRefresh(t *testing.T) {
+	fake := &FakeSource{
+		HealthData:     &ClusterHealth{ClusterName: "fake", Status: "green"},
+		IndexStatsData: &IndexStats{},
+		ISMErr:         source.ErrISMUnavailable,
+	}
+	fake.IndexStatsData.Total.Docs.Count = 42
+	app := NewApp(fake, "fake://", "none")
+	app.Update(ExecuteCommand(app.Init()))
+
+	app.currentView = ViewIndexSchema
+	app.selectedIndexName = "logs"
+	app.indexTab = indexTabStats
+
+	// Refreshing on the Stats tab also refetches the index stats
+	msg := ExecuteCommand(app.refresh())
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("refresh() on the Stats tab = %T, want a batch including the stats fetch", msg)
+	}
+	for _, cmd := range batch {
+		app.Update(ExecuteCommand(cmd))
+	}
+	if app.indexStats == nil || app.indexStats.Total.Docs.Count != 42 {
+		t.Error("refresh should reload the index stats")
+	}
+
+	// A cluster without ISM shows a note rather than an error
+	app.Update(ExecuteCommand(app.fetchIndexTab(indexTabPolicy)))
+	app.indexTab = indexTabPolicy
+	result := app.renderIndexSchemaView()
+	if !strings.Contains(result, "not available") || strings.Contains(result, "Failed to load") {
+		t.Errorf("missing ISM plugin should degrade gracefully, got %q", result)
+	}
+}
+
+// newShardExplainApp returns an app whose cluster has one unassigned and one
+// initializing shard
+func newShardExplainApp(t *testing.T) (*App, *FakeSource) {
+	t.Helper()
+
+	fake := &FakeSource{
+		HealthData: &ClusterHealth{ClusterName: "fake", Status: "red"},
+		ShardsData: []ShardInfo{
+			{Index: "logs", Shard: "0", Prirep: "p", State: "STARTED", Node: "node-1"},
+			{Index: "logs", Shard: "0", Prirep: "r", State: "UNASSIGNED"},
+			{Index: "logs", Shard: "1", Prirep: "p", State: "INITIALIZING", Node: "node-2"},
+		},
+		ExplainData: &AllocationExplanation{Index: "logs", CurrentState: "unassigned", CanAllocate: "no"},
+	}
+	app := NewApp(fake, "fake://", "none")
+	app.Update(ExecuteCommand(app.Init()))
+	SendWindowSize(app, 120, 40)
+
+	app.currentView = ViewShards
+	app.activePanel = PanelRight
+	app.Update(ExecuteCommand(app.fetchViewData()))
+	if len(app.problemShards()) != 2 {
+		t.Fatalf("problemShards() = %d, want 2", len(app.problemShards()))
+	}
+	return app, fake
+}
+
+func TestIntegration_Drilldown_ShardExplain(t *testing.T) {
+	app, _ := newShardExplainApp(t)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if app.selectedShard != 1 {
+		t.Errorf("selectedShard = %d, want 1", app.selectedShard)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if app.selectedShard != 1 {
+		t.Errorf("selectedShard should stop at the last shard, got %d", app.selectedShard)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.currentView != ViewShardExplain {
+		t.Fatalf("currentView = %v, want ViewShardExplain", app.currentView)
+	}
+	if app.explainShard.State != "UNASSIGNED" || app.explainShard.Prirep != "r" {
+		t.Errorf("explainShard = %+v, want the unassigned replica", app.explainShard)
+	}
+	if cmd == nil {
+		t.Fatal("Enter should fetch the allocation explanation")
+	}
+	app.Update(ExecuteCommand(cmd))
+	if app.shardExplain == nil || app.shardExplain.CanAllocate != "no" {
+		t.Errorf("shardExplain = %+v", app.shardExplain)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentView != ViewShards {
+		t.Errorf("currentView = %v, want ViewShards after Esc", app.currentView)
+	}
+	if app.shardExplain != nil || app.explainShard.Index != "" {
+		t.Error("Esc should clear the allocation explanation")
+	}
+}
+
+func TestIntegration_Drilldown_ShardExplainStaleResponse(t *testing.T) {
+	app, _ := newShardExplainApp(t)
+
+	app.currentView = ViewShardExplain
+	app.explainShard = ShardInfo{Index: "logs", Shard: "1", Prirep: "p"}
+
+	// A response for a shard the user already backed out of is dropped
+	other := ShardInfo{Index: "logs", Shard: "0", Prirep: "r"}
+	app.Update(shardExplainMsg{shard: other, explanation: &AllocationExplanation{}, epoch: app.connEpoch, gen: app.refreshGen})
+	if app.shardExplain != nil {
+		t.Error("explanation for another shard should be ignored")
+	}
+
+	app.Update(shardExplainMsg{shard: app.explainShard, err: fmt.Errorf("forbidden"), epoch: app.connEpoch, gen: app.refreshGen})
+	if app.err != nil {
+		t.Error("a failed explain should not replace the whole UI with an error")
+	}
+	if !strings.Contains(app.renderShardExplainView(), "forbidden") {
+		t.Error("explain view should show the fetch error")
+	}
+}
+
+func TestIntegration_Drilldown_ShardExplainRefresh(t *testing.T) {
+	app, _ := newShardExplainApp(t)
+
+	app.currentView = ViewShardExplain
+	app.explainShard = ShardInfo{Index: "logs", Shard: "0", Prirep: "r"}
+
+	// Refreshing on the explain view also re-explains the shard
+	msg := ExecuteCommand(app.refresh())
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("refresh() on the explain view = %T, want a batch including the explain", msg)
+	}
+	for _, cmd := range batch {
+		app.Update(ExecuteCommand(cmd))
+	}
+	if app.shardExplain == nil {
+		t.Error("refresh should reload the allocation explanation")
+	}
+}
+
+func TestIntegration_Drilldown_UnassignedSummary(t *testing.T) {
+	app, fake := newShardExplainApp(t)
+
+	fake.UnassignedData = &UnassignedReport{
+		Total: 1,
+		Explanations: []AllocationExplanation{{
+			CanAllocate: "no",
+			NodeDecisions: []source.NodeAllocationDecision{{
+				Deciders: []source.AllocationDecider{{Decider: "max_retry", Decision: "NO", Explanation: "too many failures"}},
+			}},
+		}},
+	}
+	// Explanations were just fetched, so an auto-refresh reuses them
+	app.Update(ExecuteCommand(app.refresh()))
+	if strings.Contains(app.renderShardsView(), "max_retry") {
+		t.Error("an auto-refresh shouldn't explain the unassigned shards again within a minute")
+	}
+
+	// A manual refresh fetches them right away
+	_, cmd := SendKey(app, "r")
+	app.Update(ExecuteCommand(cmd))
+	content := app.renderShardsView()
+	if !strings.Contains(content, "Top blocking reasons") || !strings.Contains(content, "max_retry") {
+		t.Error("shards view should summarise the blocking deciders")
+	}
+	if !strings.Contains(content, "refreshed every 1m") {
+		t.Error("shards view should say how often explanations are refreshed")
+	}
+
+	// And an auto-refresh once they're a minute old
+	app.sourceUpdated[SourceUnassigned] = time.Now().Add(-time.Minute)
+	fake.UnassignedData = &UnassignedReport{}
+	app.Update(ExecuteCommand(app.refresh()))
+	if strings.Contains(app.renderShardsView(), "max_retry") {
+		t.Error("an auto-refresh should explain the unassigned shards again after a minute")
+	}
+}
+
+// newHotThreadsApp returns an app on the Hot Threads view backed by a fake
+// source
+func newHotThreadsApp(t *testing.T) (*App, *FakeSource) {
+	t.Helper()
+
+	fake := &FakeSource{
+		HealthData:     &ClusterHealth{ClusterName: "fake", Status: "green"},
+		NodesData:      []NodeInfo{{Name: "node-2", NodeRole: "dim"}, {Name: "node-1", NodeRole: "dim"}},
+		HotThreadsData: []NodeHotThreads{{Node: "node-1"}},
+	}
+	app := NewApp(fake, "fake://", "none")
+	app.Update(ExecuteCommand(app.Init()))
+	SendWindowSize(app, 120, 40)
+
+	app.activePanel = PanelLeft
+	app.selectedItem = int(ViewHotThreads)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.currentView != ViewHotThreads {
+		t.Fatalf("currentView = %v, want ViewHotThreads", app.currentView)
+	}
+	executeBatch(app, cmd)
+	return app, fake
+}
+
+// executeBatch runs a command and, for batches, each command in it
+func executeBatch(app *App, cmd tea.Cmd) {
+	msg := ExecuteCommand(cmd)
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			executeBatch(app, c)
+		}
+		return
+	}
+	if msg != nil {
+		app.Update(msg)
+	}
+}
+
+func TestIntegration_HotThreads_SampleAgain(t *testing.T) {
+	app, fake := newHotThreadsApp(t)
+
+	if len(fake.HotThreadsCalls) != 1 || fake.HotThreadsCalls[0] != defaultHotThreadsOptions {
+		t.Fatalf("entering the view should take one default sample, got %+v", fake.HotThreadsCalls)
+	}
+	if app.hotThreads == nil || app.hotThreadsLoading {
+		t.Fatal("first sample should be loaded")
+	}
+	first := app.hotThreads
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if app.hotThreadsPrev != first || app.hotThreads != nil || !app.hotThreadsLoading {
+		t.Error("'s' should keep the current sample as the previous one while sampling")
+	}
+	executeBatch(app, cmd)
+	if app.hotThreads == nil || app.hotThreadsPrev != first {
+		t.Error("the new sample should be shown next to the previous one")
+	}
+}
+
+func TestIntegration_HotThreads_Options(t *testing.T) {
+	app, fake := newHotThreadsApp(t)
+
+	keys := []struct {
+		key   string
+		check func(HotThreadsOptions) bool
+	}{
+		{"t", func(o HotThreadsOptions) bool { return o.Type == "wait" }},
+		{"i", func(o HotThreadsOptions) bool { return o.Interval == time.Second }},
+		{"]", func(o HotThreadsOptions) bool { return o.Threads == 4 }},
+		{"[", func(o HotThreadsOptions) bool { return o.Threads == 3 }},
+		{"n", func(o HotThreadsOptions) bool { return o.Node == "node-1" }},
+		{"n", func(o HotThreadsOptions) bool { return o.Node == "node-2" }},
+		{"n", func(o HotThreadsOptions) bool { return o.Node == "" }},
+	}
+	for _, k := range keys {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k.key)})
+		if app.hotThreadsPrev != nil {
+			t.Errorf("'%s' should drop the previous sample", k.key)
+		}
+		executeBatch(app, cmd)
+
+		last := fake.HotThreadsCalls[len(fake.HotThreadsCalls)-1]
+		if !k.check(last) || last != app.hotThreadsOpts {
+			t.Errorf("after '%s' sampled with %+v", k.key, last)
+		}
+	}
+}
+
+func TestIntegration_HotThreads_StaleSample(t *testing.T) {
+	app, _ := newHotThreadsApp(t)
+
+	app.hotThreads = nil
+	stale := app.hotThreadsOpts
+	stale.Type = "block"
+	app.Update(hotThreadsMsg{opts: stale, nodes: []NodeHotThreads{{Node: "x"}}, epoch: app.connEpoch, gen: app.refreshGen})
+	if app.hotThreads != nil {
+		t.Error("a sample taken with other options should be ignored")
+	}
+}
+
+func TestIntegration_HotThreads_FromNodes(t *testing.T) {
+	app, fake := newHotThreadsApp(t)
+
+	app.currentView = ViewNodes
+	app.selectedItem = int(ViewNodes)
+	app.activePanel = PanelRight
+	app.selectedNode = 0
+	node, _ := app.selectedNodeInfo()
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	executeBatch(app, cmd)
+	if app.currentView != ViewHotThreads || app.selectedItem != int(ViewHotThreads) {
+		t.Fatalf("'H' should open the Hot Threads view, got %v", app.currentView)
+	}
+	if last := fake.HotThreadsCalls[len(fake.HotThreadsCalls)-1]; last.Node != node.Name {
+		t.Errorf("sampled node %q, want %q", last.Node, node.Name)
+	}
+}
+
+func TestIntegration_Drilldown_FromAliases(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	app.activePanel = PanelLeft
+	app.selectedItem = int(ViewAliases)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	executeBatch(app, cmd)
+	if app.aliases == nil {
+		t.Fatal("aliases should load when the view opens")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if app.selectedAlias != 1 {
+		t.Errorf("selectedAlias = %d, want 1", app.selectedAlias)
+	}
+
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.currentView != ViewIndexSchema {
+		t.Fatalf("currentView = %v, want ViewIndexSchema", app.currentView)
+	}
+	if app.selectedIndexName != ".ds-logs-app-000002" {
+		t.Errorf("selectedIndexName = %q, want the write backing index", app.selectedIndexName)
+	}
+	if cmd == nil {
+		t.Fatal("Enter should fetch the backing index mapping")
+	}
+
+	// Esc goes back to the aliases view, cursor intact
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentView != ViewAliases {
+		t.Errorf("currentView = %v, want ViewAliases after Esc", app.currentView)
+	}
+	if app.selectedAlias != 1 || app.indexFromAliases {
+		t.Errorf("selectedAlias = %d, indexFromAliases = %v", app.selectedAlias, app.indexFromAliases)
+	}
+
+	// The indices view's drill-down still returns to the indices view
+	app.currentView = ViewIndices
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentView != ViewIndices {
+		t.Errorf("currentView = %v, want ViewIndices", app.currentView)
+	}
+}
+
+func TestIntegration_Drilldown_TemplateDetail(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	app.activePanel = PanelLeft
+	app.selectedItem = int(ViewTemplates)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	executeBatch(app, cmd)
+	if app.indexTemplates == nil {
+		t.Fatal("composable templates should load with the templates view")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if app.selectedTemplate != 1 {
+		t.Errorf("selectedTemplate should stop at the last template, got %d", app.selectedTemplate)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.currentView != ViewTemplateDetail || app.templateDetail != "logs-app" {
+		t.Fatalf("currentView = %v, templateDetail = %q", app.currentView, app.templateDetail)
+	}
+	if cmd == nil {
+		t.Fatal("Enter should simulate the template")
+	}
+	msg := ExecuteCommand(cmd)
+
+	// A simulation for a template the user has left is dropped
+	stale := msg.(templateSimMsg)
+	stale.name = "logs-app-debug"
+	app.Update(stale)
+	if app.templateSim != nil {
+		t.Error("stale simulation should be dropped")
+	}
+
+	app.Update(msg)
+	if app.templateSim == nil || len(app.templateSim.Template.Settings) != 3 {
+		t.Errorf("templateSim = %+v", app.templateSim)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentView != ViewTemplates {
+		t.Errorf("currentView = %v, want ViewTemplates after Esc", app.currentView)
+	}
+	if app.templateDetail != "" || app.templateSim != nil {
+		t.Error("Esc should clear the template drill-down")
+	}
+}
+
+func TestIntegration_Drilldown_IngestSimulate(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	app.activePanel = PanelLeft
+	app.selectedItem = int(ViewIngest)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	executeBatch(app, cmd)
+	if app.ingest == nil {
+		t.Fatal("ingest pipelines should load when the view opens")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if app.selectedPipeline != 1 {
+		t.Errorf("selectedPipeline should stop at the last pipeline, got %d", app.selectedPipeline)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.currentView != ViewIngestSimulate || app.ingestDetail != "logs-app" {
+		t.Fatalf("currentView = %v, ingestDetail = %q", app.currentView, app.ingestDetail)
+	}
+
+	// Running without a document explains what's missing
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if app.ingestSimErr == nil {
+		t.Error("running an empty sample should fail")
+	}
+
+	// The editor takes keys that are shortcuts elsewhere, like q and r
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(`{"q": "r"}`), Paste: true})
+	if cmd != nil {
+		if _, quit := ExecuteCommand(cmd).(tea.QuitMsg); quit {
+			t.Fatal("typing in the editor shouldn't quit")
+		}
+	}
+	if app.ingestInput.Value() != `{"q": "r"}` {
+		t.Fatalf("ingestInput = %q", app.ingestInput.Value())
+	}
+
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if cmd == nil || !app.ingestSimRunning {
+		t.Fatal("ctrl+r should run the simulation")
+	}
+	msg := ExecuteCommand(cmd)
+
+	// A simulation for a pipeline the user has left is dropped
+	stale := msg.(ingestSimMsg)
+	stale.pipeline = "metrics"
+	app.Update(stale)
+	if app.ingestResult != nil {
+		t.Error("stale simulation should be dropped")
+	}
+
+	app.Update(msg)
+	if app.ingestSimErr != nil || len(app.ingestResult) != 1 || app.ingestResult[0].Source["env"] != "prod" {
+		t.Errorf("ingestResult = %+v, err = %v", app.ingestResult, app.ingestSimErr)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentView != ViewIngest {
+		t.Errorf("currentView = %v, want ViewIngest after Esc", app.currentView)
+	}
+	if app.ingestDetail != "" || app.ingestResult != nil {
+		t.Error("Esc should clear the simulate drill-down")
+	}
+}
@@ -229,7 +229,7 @@ func TestIntegration_EdgeCases_NilClient(t *testing.T) {
 		}
 	}()
 
-	app := NewApp(nil, "http://localhost:9200")
+	app := NewApp(nil, "http://localhost:9200", "none")
 
 	// If we get here without panic, try to use it
 	if app != nil {
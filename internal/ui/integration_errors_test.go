@@ -2,31 +2,41 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-func TestIntegration_Errors_FailFastBehavior(t *testing.T) {
+func TestIntegration_Errors_IsolatedPerSource(t *testing.T) {
 	// Table of endpoints where errors can occur
 	endpoints := []struct {
 		name     string
 		endpoint string
+		source   DataSource
 	}{
-		{"health", "health"},
-		{"stats", "stats"},
-		{"nodes", "nodes"},
-		{"indices", "indices"},
-		{"shards", "shards"},
-		{"allocation", "allocation"},
-		{"threadpool", "threadpool"},
-		{"tasks", "tasks"},
-		{"pending_tasks", "pending_tasks"},
-		{"recovery", "recovery"},
-		{"segments", "segments"},
-		{"fielddata", "fielddata"},
-		{"plugins", "plugins"},
-		{"templates", "templates"},
+		{"health", "health", SourceHealth},
+		{"stats", "stats", SourceStats},
+		{"nodes", "nodes", SourceNodes},
+		{"indices", "indices", SourceIndices},
+		{"shards", "shards", SourceShards},
+		{"allocation", "allocation", SourceAllocation},
+		{"threadpool", "threadpool", SourceThreadPool},
+		{"tasks", "tasks", SourceTasks},
+		{"pending_tasks", "pending_tasks", SourcePendingTasks},
+		{"recovery", "recovery", SourceRecovery},
+		{"segments", "segments", SourceSegments},
+		{"fielddata", "fielddata", SourceFielddata},
+		{"plugins", "plugins", SourcePlugins},
+		{"templates", "templates", SourceTemplates},
+		{"snapshots", "snapshot_repos", SourceSnapshots},
+		{"ism", "ism_policies", SourceISM},
+		{"aliases", "cat_aliases", SourceAliases},
+		{"index_templates", "index_templates", SourceIndexTemplates},
+		{"ingest", "ingest_stats", SourceIngest},
+		{"cluster_settings", "cluster_settings", SourceClusterSettings},
+		{"node_memory", "node_memory", SourceMemory},
+		{"node_activity", "node_activity", SourceNodeActivity},
 	}
 
 	for _, tt := range endpoints {
@@ -41,14 +51,24 @@ func TestIntegration_Errors_FailFastBehavior(t *testing.T) {
 			cmd := app.Init()
 			msg := ExecuteCommand(cmd)
 			app.Update(msg)
+			LoadAllSources(app)
 
-			// Should fail fast with error
-			if app.err == nil {
-				t.Errorf("Expected error for %s endpoint", tt.endpoint)
+			// One failing source must not block the rest
+			if app.err != nil {
+				t.Errorf("Single %s failure should not fail the whole refresh: %v", tt.endpoint, app.err)
+			}
+			if app.sourceErrs[tt.source] == nil {
+				t.Errorf("Expected error recorded for %s source", tt.source)
+			}
+			if len(app.sourceErrs) != 1 {
+				t.Errorf("Expected only %s to fail, got %d failed sources", tt.source, len(app.sourceErrs))
 			}
 
-			if !app.loading {
-				// loading might still be true or false depending on implementation
+			if tt.source != SourceHealth && app.health == nil {
+				t.Error("health should load when another source fails")
+			}
+			if tt.source != SourceIndices && app.indices == nil {
+				t.Error("indices should load when another source fails")
 			}
 		})
 	}
@@ -76,8 +96,11 @@ func TestIntegration_Errors_RetryRecovery(t *testing.T) {
 	msg := ExecuteCommand(cmd)
 	app.Update(msg)
 
-	if app.err == nil {
-		t.Fatal("First refresh should fail")
+	if app.sourceErrs[SourceHealth] == nil {
+		t.Fatal("First refresh should fail for health")
+	}
+	if app.health != nil {
+		t.Error("health should not be set after a failed fetch")
 	}
 
 	// Clear error
@@ -97,6 +120,9 @@ func TestIntegration_Errors_RetryRecovery(t *testing.T) {
 	if app.health == nil {
 		t.Error("health should be loaded after successful retry")
 	}
+	if len(app.sourceErrs) != 0 {
+		t.Errorf("source errors should clear after successful retry: %v", app.sourceErrs)
+	}
 }
 
 func TestIntegration_Errors_MalformedJSON(t *testing.T) {
@@ -156,9 +182,12 @@ func TestIntegration_Errors_PartialDataRetained(t *testing.T) {
 	msg = ExecuteCommand(cmd)
 	app.Update(msg)
 
-	// Should have error
-	if app.err == nil {
-		t.Error("Second refresh should fail")
+	// Should have a per-source error but no global error
+	if app.sourceErrs[SourceHealth] == nil {
+		t.Error("Second refresh should record the health failure")
+	}
+	if app.err != nil {
+		t.Errorf("Partial failure should not set a global error: %v", app.err)
 	}
 
 	// Old data should be retained (not overwritten)
@@ -169,6 +198,14 @@ func TestIntegration_Errors_PartialDataRetained(t *testing.T) {
 	if app.health != firstHealth {
 		t.Error("health reference should be same as first refresh")
 	}
+
+	// The cluster view should flag its health data as stale
+	SendWindowSize(app, 120, 40)
+	app.currentView = ViewCluster
+	app.updateViewportContent()
+	if !strings.Contains(app.viewport.View(), "Stale health data") {
+		t.Error("Cluster view should show a stale marker for health")
+	}
 }
 
 func TestIntegration_Errors_MappingFetchError(t *testing.T) {
@@ -184,6 +221,7 @@ func TestIntegration_Errors_MappingFetchError(t *testing.T) {
 	cmd := app.Init()
 	msg := ExecuteCommand(cmd)
 	app.Update(msg)
+	LoadAllSources(app)
 
 	SendWindowSize(app, 120, 40)
 
@@ -242,12 +280,8 @@ func TestIntegration_Errors_ErrorDisplayInView(t *testing.T) {
 }
 
 func TestIntegration_Errors_ClearErrorOnRetry(t *testing.T) {
+	// No fixtures, so every source fails
 	transport := NewMockTransport()
-	if err := transport.LoadAllFixtures(); err != nil {
-		t.Fatalf("Failed to load fixtures: %v", err)
-	}
-
-	transport.SetError("health", fmt.Errorf("simulated error"))
 
 	client, err := NewMockClient(transport)
 	if err != nil {
@@ -327,7 +361,7 @@ func TestIntegration_Errors_MultipleSequentialErrors(t *testing.T) {
 	msg := ExecuteCommand(cmd)
 	app.Update(msg)
 
-	if app.err == nil {
+	if app.sourceErrs[SourceHealth] == nil {
 		t.Error("Should have first error")
 	}
 
@@ -340,9 +374,12 @@ func TestIntegration_Errors_MultipleSequentialErrors(t *testing.T) {
 	msg = ExecuteCommand(cmd)
 	app.Update(msg)
 
-	if app.err == nil {
+	if app.sourceErrs[SourceStats] == nil {
 		t.Error("Should have second error")
 	}
+	if app.sourceErrs[SourceHealth] != nil {
+		t.Error("First error should clear once health succeeds")
+	}
 
 	// Clear and succeed
 	transport.ClearError("stats")
@@ -352,7 +389,7 @@ func TestIntegration_Errors_MultipleSequentialErrors(t *testing.T) {
 	msg = ExecuteCommand(cmd)
 	app.Update(msg)
 
-	if app.err != nil {
-		t.Errorf("Final retry should succeed: %v", app.err)
+	if app.err != nil || len(app.sourceErrs) != 0 {
+		t.Errorf("Final retry should succeed: %v %v", app.err, app.sourceErrs)
 	}
 }
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -262,3 +263,36 @@ func TestIntegration_Metrics_ViewportContentUpdates(t *testing.T) {
 		t.Error("Viewport should have content when on Live Metrics view")
 	}
 }
+
+func TestIntegration_Metrics_WindowAndIntervalKeys(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	app.currentView = ViewLiveMetrics
+	app.activePanel = PanelRight
+	if app.metricsTimeSeries.Window != time.Minute || app.metricsInterval != 5*time.Second {
+		t.Fatalf("defaults = %s window, %s interval, want 1m and 5s", app.metricsTimeSeries.Window, app.metricsInterval)
+	}
+
+	SendKey(app, "w")
+	if app.metricsTimeSeries.Window != 5*time.Minute {
+		t.Errorf("window after 'w' = %s, want 5m", app.metricsTimeSeries.Window)
+	}
+	SendKey(app, "i")
+	if app.metricsInterval != 10*time.Second {
+		t.Errorf("interval after 'i' = %s, want 10s", app.metricsInterval)
+	}
+	if !strings.Contains(app.renderMetricsView(), "(Last 5m, sampled every 10s)") {
+		t.Error("header should show the chosen window and interval")
+	}
+
+	// Keys only apply to Live Metrics
+	app.currentView = ViewCluster
+	SendKey(app, "w")
+	if app.metricsTimeSeries.Window != 5*time.Minute {
+		t.Errorf("'w' outside Live Metrics changed the window to %s", app.metricsTimeSeries.Window)
+	}
+}
@@ -7,6 +7,7 @@ import (
 )
 
 func TestIntegration_Navigation_MenuUpDown(t *testing.T) {
+	lastItem := len(menuItems) - 1
 	tests := []struct {
 		name           string
 		initialItem    int
@@ -16,7 +17,8 @@ func TestIntegration_Navigation_MenuUpDown(t *testing.T) {
 	}{
 		{"down from 0", 0, tea.KeyDown, 1, true},
 		{"down from 5", 5, tea.KeyDown, 6, true},
-		{"down from 15", 15, tea.KeyDown, 15, false}, // At boundary
+		{"down from 15", 15, tea.KeyDown, 16, true},
+		{"down from last", lastItem, tea.KeyDown, lastItem, false}, // At boundary
 		{"up from 5", 5, tea.KeyUp, 4, true},
 		{"up from 1", 1, tea.KeyUp, 0, true},
 		{"up from 0", 0, tea.KeyUp, 0, false}, // At boundary
@@ -63,11 +65,12 @@ func TestIntegration_Navigation_Boundaries(t *testing.T) {
 		t.Errorf("Should not go below 0, got %d", app.selectedItem)
 	}
 
-	// Try to go above 15
-	app.selectedItem = 15
+	// Try to go past the last menu item
+	lastItem := len(menuItems) - 1
+	app.selectedItem = lastItem
 	app.Update(tea.KeyMsg{Type: tea.KeyDown})
-	if app.selectedItem != 15 {
-		t.Errorf("Should not go above 15, got %d", app.selectedItem)
+	if app.selectedItem != lastItem {
+		t.Errorf("Should not go above %d, got %d", lastItem, app.selectedItem)
 	}
 }
 
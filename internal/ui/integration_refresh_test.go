@@ -41,6 +41,17 @@ func TestIntegration_RefreshCycle_AllDataLoaded(t *testing.T) {
 		t.Errorf("unexpected error: %v", app.err)
 	}
 
+	// Only the Cluster Overview data is fetched on startup
+	if app.health == nil || app.stats == nil {
+		t.Error("cluster overview data not loaded")
+	}
+	if app.shards != nil || app.segments != nil {
+		t.Error("data for other views should load lazily")
+	}
+
+	// Visit every other view
+	LoadAllSources(app)
+
 	// Verify all 14 data fields are populated
 	if app.health == nil {
 		t.Error("health not loaded")
@@ -139,21 +150,22 @@ func TestIntegration_RefreshCycle_ErrorAtEachStage(t *testing.T) {
 	endpoints := []struct {
 		name     string
 		endpoint string
+		source   DataSource
 	}{
-		{"health", "health"},
-		{"stats", "stats"},
-		{"nodes", "nodes"},
-		{"indices", "indices"},
-		{"shards", "shards"},
-		{"allocation", "allocation"},
-		{"threadpool", "threadpool"},
-		{"tasks", "tasks"},
-		{"pending_tasks", "pending_tasks"},
-		{"recovery", "recovery"},
-		{"segments", "segments"},
-		{"fielddata", "fielddata"},
-		{"plugins", "plugins"},
-		{"templates", "templates"},
+		{"health", "health", SourceHealth},
+		{"stats", "stats", SourceStats},
+		{"nodes", "nodes", SourceNodes},
+		{"indices", "indices", SourceIndices},
+		{"shards", "shards", SourceShards},
+		{"allocation", "allocation", SourceAllocation},
+		{"threadpool", "threadpool", SourceThreadPool},
+		{"tasks", "tasks", SourceTasks},
+		{"pending_tasks", "pending_tasks", SourcePendingTasks},
+		{"recovery", "recovery", SourceRecovery},
+		{"segments", "segments", SourceSegments},
+		{"fielddata", "fielddata", SourceFielddata},
+		{"plugins", "plugins", SourcePlugins},
+		{"templates", "templates", SourceTemplates},
 	}
 
 	for _, tt := range endpoints {
@@ -169,15 +181,21 @@ func TestIntegration_RefreshCycle_ErrorAtEachStage(t *testing.T) {
 			cmd := app.Init()
 			msg := ExecuteCommand(cmd)
 			app.Update(msg)
+			LoadAllSources(app)
 
 			// Verify error state
 			if app.loading {
 				t.Error("loading should be false after error")
 			}
 
-			if app.err == nil {
+			if app.sourceErrs[tt.source] == nil {
 				t.Errorf("Expected error for %s endpoint", tt.endpoint)
 			}
+
+			// Other sources still refresh
+			if app.lastRefresh.IsZero() {
+				t.Error("lastRefresh should be set when other sources succeed")
+			}
 		})
 	}
 }
@@ -281,7 +299,7 @@ func TestIntegration_RefreshCycle_ErrorRecovery(t *testing.T) {
 	app.Update(msg)
 
 	// Should have error now
-	if app.err == nil {
+	if app.sourceErrs[SourceHealth] == nil {
 		t.Error("Expected error after injecting health error")
 	}
 
@@ -299,7 +317,7 @@ func TestIntegration_RefreshCycle_ErrorRecovery(t *testing.T) {
 	app.Update(msg)
 
 	// Should succeed again
-	if app.err != nil {
-		t.Errorf("Refresh should succeed after clearing error: %v", app.err)
+	if app.err != nil || app.sourceErrs[SourceHealth] != nil {
+		t.Errorf("Refresh should succeed after clearing error: %v", app.sourceErrs[SourceHealth])
 	}
 }
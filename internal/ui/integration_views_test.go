@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -227,12 +229,14 @@ func TestIntegration_Views_MetricsViewEnablesMetrics(t *testing.T) {
 }
 
 func TestIntegration_Views_ErrorStateDisplay(t *testing.T) {
-	client, err := NewMockClientWithError("health")
+	// No fixtures, so every source fails
+	client, err := NewMockClient(NewMockTransport())
 	if err != nil {
 		t.Fatalf("Failed to create mock client: %v", err)
 	}
 
 	app := NewTestApp(client, "http://localhost:9200")
+	SendWindowSize(app, 120, 40)
 
 	// Trigger refresh
 	cmd := app.Init()
@@ -248,7 +252,7 @@ func TestIntegration_Views_ErrorStateDisplay(t *testing.T) {
 	view := app.View()
 
 	// View should show error
-	if len(view) == 0 {
+	if !strings.Contains(view, "Error:") {
 		t.Error("View should display error message")
 	}
 }
@@ -270,3 +274,218 @@ func TestIntegration_Views_LoadingStateDisplay(t *testing.T) {
 		t.Error("View should display loading message")
 	}
 }
+
+func TestIntegration_Views_ISMFailedFilter(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	app.activePanel = PanelLeft
+	app.selectedItem = int(ViewISM)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	executeBatch(app, cmd)
+
+	if app.ism == nil || len(app.ism.Managed) != 1 {
+		t.Fatalf("ism = %+v, want the fixture's managed index", app.ism)
+	}
+	if app.plugins == nil {
+		t.Error("the ISM view should load plugins to detect the ISM plugin")
+	}
+
+	SendKey(app, "F")
+	if !app.ismFailedOnly {
+		t.Fatal("F should enable the failed-only filter")
+	}
+	if content := app.viewport.View(); !strings.Contains(content, "Failed Indices (1 of 1)") {
+		t.Errorf("viewport should show the filtered list, got %q", content)
+	}
+
+	SendKey(app, "F")
+	if app.ismFailedOnly {
+		t.Error("F again should show every managed index")
+	}
+}
+
+func TestIntegration_Views_ClusterSettingsFilter(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	app.activePanel = PanelLeft
+	app.selectedItem = int(ViewSettings)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	executeBatch(app, cmd)
+	if app.clusterSettings == nil {
+		t.Fatal("cluster settings should load when the view opens")
+	}
+
+	SendKey(app, "/")
+	if !app.settingsFiltering {
+		t.Fatal("/ should focus the filter")
+	}
+	// Keys that are shortcuts elsewhere are typed into the filter
+	for _, key := range "watermark" {
+		SendKey(app, string(key))
+	}
+	if app.settingsFilter.Value() != "watermark" {
+		t.Errorf("filter = %q, want watermark", app.settingsFilter.Value())
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.settingsFiltering {
+		t.Error("Enter should apply the filter")
+	}
+	if keys := app.clusterSettingKeys(); len(keys) != 2 {
+		t.Errorf("clusterSettingKeys() = %v, want the two watermark settings", keys)
+	}
+
+	SendKey(app, "C")
+	if !app.settingsChanged || len(app.clusterSettingKeys()) != 0 {
+		t.Errorf("C should hide the unchanged watermark settings, got %v", app.clusterSettingKeys())
+	}
+
+	SendKey(app, "/")
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.settingsFilter.Value() != "" || app.settingsFiltering {
+		t.Error("Esc should clear the filter")
+	}
+	// allocation.enable is overridden back to its default, so only the
+	// recovery rate is changed
+	if keys := app.clusterSettingKeys(); len(keys) != 1 || keys[0] != "indices.recovery.max_bytes_per_sec" {
+		t.Errorf("changed settings = %v, want the recovery rate", keys)
+	}
+}
+
+// TestIntegration_Views_MemoryRates tests that breaker trips and cache
+// evictions become rates from the second refresh of the Memory Pressure view
+func TestIntegration_Views_MemoryRates(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	app.activePanel = PanelLeft
+	app.selectedItem = int(ViewMemory)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	executeBatch(app, cmd)
+	if len(app.memory) != 2 {
+		t.Fatalf("memory = %d nodes, want 2 once the view opens", len(app.memory))
+	}
+	if app.memoryTimeSeries.Size() != 0 {
+		t.Error("the first fetch should only be a baseline")
+	}
+	if !strings.Contains(app.renderRightPanel(), "Memory Pressure") {
+		t.Error("right panel should render the Memory Pressure view")
+	}
+
+	// The refresh records its fetch time, so wait for a non-zero delta
+	time.Sleep(10 * time.Millisecond)
+	executeBatch(app, app.refresh())
+	if app.memoryTimeSeries.Size() != 1 {
+		t.Errorf("data points = %d after a second refresh, want 1", app.memoryTimeSeries.Size())
+	}
+	if rate, ok := app.memoryTimeSeries.Rate(memoryCounterKey("node-id-1", breakerTripCounter("parent"))); !ok || rate != 0 {
+		t.Errorf("parent breaker rate = %v, %v, want 0 for unchanged fixtures", rate, ok)
+	}
+}
+
+// TestIntegration_Views_TopIndicesPin tests that the Top Indices view
+// samples per-index activity and that pinning an index graphs it in Live
+// Metrics
+func TestIntegration_Views_TopIndicesPin(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	app.activePanel = PanelLeft
+	app.selectedItem = int(ViewTopIndices)
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.topIndicesEnabled {
+		t.Fatal("opening Top Indices should start sampling")
+	}
+
+	// Sample twice without waiting for the ticker; the fixtures don't
+	// change, so every rate is zero
+	app.Update(ExecuteCommand(app.refreshTopIndices()))
+	time.Sleep(10 * time.Millisecond)
+	app.Update(ExecuteCommand(app.refreshTopIndices()))
+	if len(app.topIndices()) != 2 {
+		t.Fatalf("topIndices() = %v, want both fixture indices", app.topIndices())
+	}
+
+	app.activePanel = PanelRight
+	SendKey(app, "s")
+	if app.topIndicesRank != rankByQueryRate {
+		t.Errorf("s should rank by query rate, got %s", app.topIndicesRank)
+	}
+	SendKey(app, "down")
+	SendKey(app, "P")
+	if len(app.pinnedIndices) != 1 || app.pinnedIndices[0] != "products" {
+		t.Fatalf("pinnedIndices = %v, want products", app.pinnedIndices)
+	}
+
+	app.selectedItem = int(ViewLiveMetrics)
+	app.updateViewFromSelectionCmd()
+	if app.topIndicesEnabled {
+		t.Error("leaving Top Indices should stop its sampling")
+	}
+	app.metricsTimeSeries.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(0, 0)})
+	app.metricsTimeSeries.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(5, 0)})
+	if !strings.Contains(app.renderRightPanel(), "Pinned Index: products") {
+		t.Error("Live Metrics should graph the pinned index")
+	}
+
+	// Pinned indices have their own series, fed only by Live Metrics
+	if len(app.pinnedSeries) != 0 {
+		t.Errorf("pinned series = %v before Live Metrics sampled, want none", app.pinnedSeries)
+	}
+	app.Update(ExecuteCommand(app.refreshPinnedIndices()))
+	time.Sleep(10 * time.Millisecond)
+	app.Update(ExecuteCommand(app.refreshPinnedIndices()))
+	if series, ok := app.pinnedSeries["products"]; !ok || series.Size() != 1 {
+		t.Errorf("pinned series = %v, want one products sample", app.pinnedSeries)
+	}
+}
+
+// TestIntegration_Views_NodeGraphs tests that the Node Graphs view samples
+// node activity on every refresh and overlays all nodes
+func TestIntegration_Views_NodeGraphs(t *testing.T) {
+	app, err := InitializeTestApp()
+	if err != nil {
+		t.Fatalf("Failed to initialize test app: %v", err)
+	}
+	SendWindowSize(app, 120, 40)
+
+	app.activePanel = PanelLeft
+	app.selectedItem = int(ViewNodeGraphs)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	executeBatch(app, cmd)
+	if app.nodeTimeSeries.Size() != 0 {
+		t.Error("the first fetch should only be a baseline")
+	}
+	if !strings.Contains(app.renderRightPanel(), "Collecting node metrics") {
+		t.Error("right panel should wait for a second refresh")
+	}
+
+	// The refresh records its fetch time, so wait for a non-zero delta
+	time.Sleep(10 * time.Millisecond)
+	executeBatch(app, app.refresh())
+	if app.nodeTimeSeries.Size() != 1 {
+		t.Fatalf("data points = %d after a second refresh, want 1", app.nodeTimeSeries.Size())
+	}
+	if rates, ok := app.nodeTimeSeries.Latest("node-2"); !ok || rates.CPUPercent != 71 {
+		t.Errorf("node-2 = %+v, %v, want 71%% CPU", rates, ok)
+	}
+	result := app.renderRightPanel()
+	for _, exp := range []string{"● node-1", "● node-2", "node-2 stands out"} {
+		if !strings.Contains(result, exp) {
+			t.Errorf("Expected Node Graphs to contain %q", exp)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"time"
+)
+
+// Counters of a node's memory snapshot that are turned into rates
+const (
+	counterQueryCacheEvictions   = "query_cache/evictions"
+	counterRequestCacheEvictions = "request_cache/evictions"
+	counterFielddataEvictions    = "fielddata/evictions"
+)
+
+// memoryCounterKey returns the key of a node's counter in a MemorySnapshot
+func memoryCounterKey(nodeID, counter string) string {
+	return nodeID + "/" + counter
+}
+
+// breakerTripCounter returns the counter name of a breaker's trips
+func breakerTripCounter(breaker string) string {
+	return "breaker/" + breaker + "/tripped"
+}
+
+// newMemorySnapshot collects the cumulative breaker trip and cache eviction
+// counters of every node
+func newMemorySnapshot(nodes []NodeMemory, timestamp time.Time) *MemorySnapshot {
+	snapshot := &MemorySnapshot{
+		Timestamp: timestamp,
+		Counters:  make(map[string]int64),
+	}
+	for _, node := range nodes {
+		for name, breaker := range node.Breakers {
+			snapshot.Counters[memoryCounterKey(node.ID, breakerTripCounter(name))] = breaker.Tripped
+		}
+		snapshot.Counters[memoryCounterKey(node.ID, counterQueryCacheEvictions)] = node.QueryCache.Evictions
+		snapshot.Counters[memoryCounterKey(node.ID, counterRequestCacheEvictions)] = node.RequestCache.Evictions
+		snapshot.Counters[memoryCounterKey(node.ID, counterFielddataEvictions)] = node.Fielddata.Evictions
+	}
+	return snapshot
+}
+
+// MemoryTimeSeries manages the rolling window of breaker trip and cache
+// eviction rates
+type MemoryTimeSeries struct {
+	dataPoints    []MemoryDataPoint
+	maxDataPoints int
+	lastSnapshot  *MemorySnapshot
+}
+
+// NewMemoryTimeSeries creates a new memory pressure time series tracker
+func NewMemoryTimeSeries(maxDataPoints int) *MemoryTimeSeries {
+	return &MemoryTimeSeries{
+		dataPoints:    make([]MemoryDataPoint, 0, maxDataPoints),
+		maxDataPoints: maxDataPoints,
+	}
+}
+
+// AddSnapshot adds a new snapshot and calculates the counter rates
+// Returns true if a data point was added (false for the first baseline snapshot)
+func (ts *MemoryTimeSeries) AddSnapshot(snapshot *MemorySnapshot) bool {
+	if ts.lastSnapshot == nil {
+		// First snapshot - use as baseline only
+		ts.lastSnapshot = snapshot
+		return false
+	}
+
+	// Calculate time delta
+	timeDelta := snapshot.Timestamp.Sub(ts.lastSnapshot.Timestamp).Seconds()
+	if timeDelta <= 0 {
+		// Skip if no time has passed
+		return false
+	}
+
+	dataPoint := MemoryDataPoint{
+		Timestamp: snapshot.Timestamp,
+		Rates:     make(map[string]float64),
+	}
+	for key, current := range snapshot.Counters {
+		last, ok := ts.lastSnapshot.Counters[key]
+		if !ok {
+			// Node joined or breaker appeared since the last snapshot
+			continue
+		}
+		delta := current - last
+		if delta < 0 {
+			// Counter reset (node restart) - set to 0
+			delta = 0
+		}
+		dataPoint.Rates[key] = float64(delta) / timeDelta
+	}
+
+	// Add to ring buffer
+	if len(ts.dataPoints) >= ts.maxDataPoints {
+		// Remove oldest
+		ts.dataPoints = ts.dataPoints[1:]
+	}
+	ts.dataPoints = append(ts.dataPoints, dataPoint)
+
+	// Update last snapshot
+	ts.lastSnapshot = snapshot
+	return true
+}
+
+// Rate returns the latest rate of a counter and whether one has been
+// calculated yet
+func (ts *MemoryTimeSeries) Rate(key string) (float64, bool) {
+	if len(ts.dataPoints) == 0 {
+		return 0, false
+	}
+	rate, ok := ts.dataPoints[len(ts.dataPoints)-1].Rates[key]
+	return rate, ok
+}
+
+// PeakRate returns the highest rate of a counter in the window
+func (ts *MemoryTimeSeries) PeakRate(key string) float64 {
+	peak := 0.0
+	for _, dp := range ts.dataPoints {
+		if rate := dp.Rates[key]; rate > peak {
+			peak = rate
+		}
+	}
+	return peak
+}
+
+// GetDataPoints returns all data points
+func (ts *MemoryTimeSeries) GetDataPoints() []MemoryDataPoint {
+	return ts.dataPoints
+}
+
+// Size returns the number of data points
+func (ts *MemoryTimeSeries) Size() int {
+	return len(ts.dataPoints)
+}
+
+// Clear removes all data points
+func (ts *MemoryTimeSeries) Clear() {
+	ts.dataPoints = make([]MemoryDataPoint, 0, ts.maxDataPoints)
+	ts.lastSnapshot = nil
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+func TestNewMemorySnapshot(t *testing.T) {
+	nodes := []NodeMemory{{
+		ID:         "n1",
+		Breakers:   map[string]source.CircuitBreaker{"parent": {Tripped: 4}},
+		QueryCache: CacheMemory{Evictions: 10},
+		Fielddata:  CacheMemory{Evictions: 2},
+	}}
+
+	snapshot := newMemorySnapshot(nodes, time.Unix(5, 0))
+
+	tests := map[string]int64{
+		"n1/breaker/parent/tripped":  4,
+		"n1/query_cache/evictions":   10,
+		"n1/request_cache/evictions": 0,
+		"n1/fielddata/evictions":     2,
+	}
+	for key, want := range tests {
+		if got, ok := snapshot.Counters[key]; !ok || got != want {
+			t.Errorf("Counters[%q] = %d, %v, want %d", key, got, ok, want)
+		}
+	}
+}
+
+func TestMemoryTimeSeries_AddSnapshot(t *testing.T) {
+	key := memoryCounterKey("n1", breakerTripCounter("parent"))
+	snapshot := func(seconds int64, tripped int64) *MemorySnapshot {
+		return &MemorySnapshot{Timestamp: time.Unix(seconds, 0), Counters: map[string]int64{key: tripped}}
+	}
+
+	ts := NewMemoryTimeSeries(2)
+	if ts.AddSnapshot(snapshot(0, 10)) {
+		t.Error("First snapshot should only be a baseline")
+	}
+	if _, ok := ts.Rate(key); ok {
+		t.Error("No rate should be known after the baseline")
+	}
+
+	if !ts.AddSnapshot(snapshot(5, 20)) {
+		t.Fatal("Second snapshot should add a data point")
+	}
+	if rate, ok := ts.Rate(key); !ok || rate != 2 {
+		t.Errorf("Rate() = %v, %v, want 2 trips/s", rate, ok)
+	}
+
+	if ts.AddSnapshot(snapshot(5, 30)) {
+		t.Error("Snapshot with no time delta should be skipped")
+	}
+
+	// Counter reset (node restart)
+	ts.AddSnapshot(snapshot(10, 3))
+	if rate, _ := ts.Rate(key); rate != 0 {
+		t.Errorf("Rate() after a counter reset = %v, want 0", rate)
+	}
+
+	ts.AddSnapshot(snapshot(15, 8))
+	if ts.Size() != 2 {
+		t.Errorf("Size() = %d, want the window of 2", ts.Size())
+	}
+	if peak := ts.PeakRate(key); peak != 1 {
+		t.Errorf("PeakRate() = %v, want 1 once the 2/s point left the window", peak)
+	}
+
+	ts.Clear()
+	if ts.Size() != 0 || ts.AddSnapshot(snapshot(20, 8)) {
+		t.Error("Clear() should drop the data points and the baseline")
+	}
+}
+
+func TestMemoryTimeSeries_NewCounter(t *testing.T) {
+	ts := NewMemoryTimeSeries(12)
+	ts.AddSnapshot(&MemorySnapshot{Timestamp: time.Unix(0, 0), Counters: map[string]int64{}})
+	ts.AddSnapshot(&MemorySnapshot{Timestamp: time.Unix(5, 0), Counters: map[string]int64{"n2/fielddata/evictions": 50}})
+
+	if _, ok := ts.Rate("n2/fielddata/evictions"); ok {
+		t.Error("A counter without a baseline should have no rate yet")
+	}
+}
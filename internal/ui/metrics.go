@@ -1,6 +1,13 @@
 package ui
 
-import "time"
+import (
+	"math"
+	"time"
+)
+
+// metricsBuckets caps how many downsampled buckets cover a window, so an
+// hour of samples takes no more memory than a minute
+const metricsBuckets = 60
 
 // NewMetricsTimeSeries creates a new MetricsTimeSeries with specified buffer size
 func NewMetricsTimeSeries(maxSize int) *MetricsTimeSeries {
@@ -17,6 +24,14 @@ func NewMetricsTimeSeries(maxSize int) *MetricsTimeSeries {
 	}
 }
 
+// NewMetricsWindow creates a MetricsTimeSeries that keeps maxSize data points
+// at full resolution and downsamples older ones until they leave the window
+func NewMetricsWindow(maxSize int, window time.Duration) *MetricsTimeSeries {
+	mts := NewMetricsTimeSeries(maxSize)
+	mts.Window = window
+	return mts
+}
+
 // AddSnapshot calculates rates from the snapshot and adds a new data point
 // to the time series buffer. Returns true if a data point was added, false otherwise.
 func (mts *MetricsTimeSeries) AddSnapshot(snapshot *MetricsSnapshot) bool {
@@ -54,11 +69,25 @@ func (mts *MetricsTimeSeries) AddSnapshot(snapshot *MetricsSnapshot) bool {
 		searchRate = 0
 	}
 
+	last := mts.LastSnapshot
+	rejectedUnknown := snapshot.RejectedUnknown || last.RejectedUnknown
+	rejectedRate := float64(snapshot.RejectedTotal-last.RejectedTotal) / timeDelta
+	if rejectedRate < 0 || rejectedUnknown {
+		rejectedRate = 0
+	}
+
 	// Create data point
 	dataPoint := MetricsDataPoint{
-		Timestamp:  snapshot.Timestamp,
-		InsertRate: insertRate,
-		SearchRate: searchRate,
+		Timestamp:       snapshot.Timestamp,
+		InsertRate:      insertRate,
+		SearchRate:      searchRate,
+		IndexLatency:    intervalLatency(snapshot.IndexTimeInMillis-last.IndexTimeInMillis, snapshot.IndexTotal-last.IndexTotal),
+		QueryLatency:    intervalLatency(snapshot.QueryTimeInMillis-last.QueryTimeInMillis, snapshot.SearchTotal-last.SearchTotal),
+		FetchLatency:    intervalLatency(snapshot.FetchTimeInMillis-last.FetchTimeInMillis, snapshot.FetchTotal-last.FetchTotal),
+		RejectedRate:    rejectedRate,
+		RejectedUnknown: rejectedUnknown,
+		ScrollCurrent:   float64(snapshot.ScrollCurrent),
+		PITCurrent:      float64(snapshot.PITCurrent),
 	}
 
 	// Add to ring buffer
@@ -71,9 +100,14 @@ func (mts *MetricsTimeSeries) AddSnapshot(snapshot *MetricsSnapshot) bool {
 		// Buffer not full yet, append
 		mts.DataPoints = append(mts.DataPoints, dataPoint)
 	} else {
-		// Buffer full, shift left and add at end (simple ring buffer)
+		// Buffer full: downsample the oldest into the window's buckets
+		// (or drop it without a window), shift left and add at end
+		if mts.Window > 0 {
+			mts.addToBucket(mts.DataPoints[0])
+		}
 		mts.DataPoints = append(mts.DataPoints[1:], dataPoint)
 	}
+	mts.trimToWindow(snapshot.Timestamp)
 
 	// Update last snapshot
 	mts.LastSnapshot = snapshot
@@ -81,7 +115,104 @@ func (mts *MetricsTimeSeries) AddSnapshot(snapshot *MetricsSnapshot) bool {
 	return true
 }
 
+// addToBucket merges a data point into the newest bucket, or starts a new
+// bucket once the newest spans its share of the window
+func (mts *MetricsTimeSeries) addToBucket(dp MetricsDataPoint) {
+	width := mts.Window / metricsBuckets
+	if n := len(mts.Buckets); n > 0 && dp.Timestamp.Sub(mts.Buckets[n-1].Start) < width {
+		bucket := &mts.Buckets[n-1]
+		count := float64(bucket.Count)
+		low, avg, high := bucket.Min.RejectedRate, bucket.Avg.RejectedRate, bucket.Max.RejectedRate
+		bucket.Min = combineDataPoints(bucket.Min, dp, math.Min)
+		bucket.Max = combineDataPoints(bucket.Max, dp, math.Max)
+		bucket.Avg = combineDataPoints(bucket.Avg, dp, func(avg, v float64) float64 {
+			return (avg*count + v) / (count + 1)
+		})
+		bucket.Count++
+		bucket.End = dp.Timestamp
+
+		// Rejection rates only aggregate the data points where they were
+		// known, as unknown ones carry a placeholder zero
+		switch {
+		case dp.RejectedUnknown:
+			// Keep the aggregates of the known data points
+		case bucket.RejectedCount == 0:
+			low, avg, high = dp.RejectedRate, dp.RejectedRate, dp.RejectedRate
+			bucket.RejectedCount++
+		default:
+			known := float64(bucket.RejectedCount)
+			low = math.Min(low, dp.RejectedRate)
+			high = math.Max(high, dp.RejectedRate)
+			avg = (avg*known + dp.RejectedRate) / (known + 1)
+			bucket.RejectedCount++
+		}
+		unknown := bucket.RejectedCount == 0
+		bucket.Min.RejectedRate, bucket.Min.RejectedUnknown = low, unknown
+		bucket.Avg.RejectedRate, bucket.Avg.RejectedUnknown = avg, unknown
+		bucket.Max.RejectedRate, bucket.Max.RejectedUnknown = high, unknown
+		return
+	}
+
+	bucket := MetricsBucket{
+		Start: dp.Timestamp,
+		End:   dp.Timestamp,
+		Count: 1,
+		Min:   dp,
+		Avg:   dp,
+		Max:   dp,
+	}
+	if !dp.RejectedUnknown {
+		bucket.RejectedCount = 1
+	}
+	mts.Buckets = append(mts.Buckets, bucket)
+}
+
+// combineDataPoints combines every metric of two data points, keeping the
+// timestamp of the newer one
+func combineDataPoints(a, b MetricsDataPoint, combine func(x, y float64) float64) MetricsDataPoint {
+	return MetricsDataPoint{
+		Timestamp:       b.Timestamp,
+		InsertRate:      combine(a.InsertRate, b.InsertRate),
+		SearchRate:      combine(a.SearchRate, b.SearchRate),
+		IndexLatency:    combine(a.IndexLatency, b.IndexLatency),
+		QueryLatency:    combine(a.QueryLatency, b.QueryLatency),
+		FetchLatency:    combine(a.FetchLatency, b.FetchLatency),
+		RejectedRate:    combine(a.RejectedRate, b.RejectedRate),
+		RejectedUnknown: a.RejectedUnknown || b.RejectedUnknown,
+		ScrollCurrent:   combine(a.ScrollCurrent, b.ScrollCurrent),
+		PITCurrent:      combine(a.PITCurrent, b.PITCurrent),
+	}
+}
+
+// trimToWindow drops buckets and data points that fell out of the window
+func (mts *MetricsTimeSeries) trimToWindow(now time.Time) {
+	if mts.Window <= 0 {
+		return
+	}
+	cutoff := now.Add(-mts.Window)
+	for len(mts.Buckets) > 0 && mts.Buckets[0].End.Before(cutoff) {
+		mts.Buckets = mts.Buckets[1:]
+	}
+	for len(mts.DataPoints) > 0 && mts.DataPoints[0].Timestamp.Before(cutoff) {
+		mts.DataPoints = mts.DataPoints[1:]
+	}
+}
+
+// SetWindow changes how much history is kept; data points already
+// downsampled stay in their buckets
+func (mts *MetricsTimeSeries) SetWindow(window time.Duration) {
+	mts.Window = window
+	if window <= 0 {
+		mts.Buckets = nil
+		return
+	}
+	if len(mts.DataPoints) > 0 {
+		mts.trimToWindow(mts.DataPoints[len(mts.DataPoints)-1].Timestamp)
+	}
+}
+
 // CalculateSummary computes aggregate statistics for a specific metric type
+// over the whole window, downsampled buckets included
 func (mts *MetricsTimeSeries) CalculateSummary(metricType string) MetricsSummary {
 	if len(mts.DataPoints) == 0 {
 		return MetricsSummary{
@@ -92,46 +223,79 @@ func (mts *MetricsTimeSeries) CalculateSummary(metricType string) MetricsSummary
 		}
 	}
 
-	// Extract values based on metric type
-	var values []float64
-	for _, dp := range mts.DataPoints {
-		var val float64
-		switch metricType {
-		case "insert":
-			val = dp.InsertRate
-		case "search":
-			val = dp.SearchRate
-		default:
-			// Unknown metric type
-			return MetricsSummary{}
-		}
-		values = append(values, val)
+	current, ok := mts.DataPoints[len(mts.DataPoints)-1].metric(metricType)
+	if !ok {
+		// Unknown metric type
+		return MetricsSummary{}
 	}
 
-	// Calculate statistics
-	var sum, min, max float64
-	min = values[0]
-	max = values[0]
-
-	for _, v := range values {
-		sum += v
-		if v < min {
-			min = v
+	// Buckets count with their own min and max, and their average weighted
+	// by the data points they hold. Rejection rates that couldn't be read are
+	// left out rather than counted as zero.
+	var sum float64
+	count := 0
+	low, peak := math.Inf(1), math.Inf(-1)
+	for _, bucket := range mts.Buckets {
+		weight := bucket.Count
+		if metricType == "rejected" {
+			weight = bucket.RejectedCount
 		}
-		if v > max {
-			max = v
+		if weight == 0 {
+			continue
 		}
+		bucketMin, _ := bucket.Min.metric(metricType)
+		bucketAvg, _ := bucket.Avg.metric(metricType)
+		bucketMax, _ := bucket.Max.metric(metricType)
+		sum += bucketAvg * float64(weight)
+		count += weight
+		low = math.Min(low, bucketMin)
+		peak = math.Max(peak, bucketMax)
+	}
+	for _, dp := range mts.DataPoints {
+		if metricType == "rejected" && dp.RejectedUnknown {
+			continue
+		}
+		v, _ := dp.metric(metricType)
+		sum += v
+		count++
+		low = math.Min(low, v)
+		peak = math.Max(peak, v)
+	}
+	if count == 0 {
+		return MetricsSummary{Current: current}
 	}
-
-	avg := sum / float64(len(values))
-	current := values[len(values)-1]
 
 	return MetricsSummary{
 		Current: current,
-		Average: avg,
-		Peak:    max,
-		Min:     min,
+		Average: sum / float64(count),
+		Peak:    peak,
+		Min:     low,
+	}
+}
+
+// metric returns the value of a metric type ("insert", "search",
+// "index_latency", "query_latency", "fetch_latency", "rejected", "scroll" or
+// "pit")
+func (dp MetricsDataPoint) metric(metricType string) (float64, bool) {
+	switch metricType {
+	case "insert":
+		return dp.InsertRate, true
+	case "search":
+		return dp.SearchRate, true
+	case "index_latency":
+		return dp.IndexLatency, true
+	case "query_latency":
+		return dp.QueryLatency, true
+	case "fetch_latency":
+		return dp.FetchLatency, true
+	case "rejected":
+		return dp.RejectedRate, true
+	case "scroll":
+		return dp.ScrollCurrent, true
+	case "pit":
+		return dp.PITCurrent, true
 	}
+	return 0, false
 }
 
 // GetDataPoints returns a copy of the current data points
@@ -141,9 +305,43 @@ func (mts *MetricsTimeSeries) GetDataPoints() []MetricsDataPoint {
 	return points
 }
 
-// Clear resets the time series, clearing all data points and snapshot
+// WindowBuckets returns buckets covering the whole window for graphing.
+// Once older data points have been downsampled, the newer ones are merged
+// into buckets of the same width so the buckets stay evenly spaced in time;
+// until then every data point is a bucket of its own
+func (mts *MetricsTimeSeries) WindowBuckets() []MetricsBucket {
+	if len(mts.Buckets) == 0 {
+		buckets := make([]MetricsBucket, 0, len(mts.DataPoints))
+		for _, dp := range mts.DataPoints {
+			buckets = append(buckets, MetricsBucket{Start: dp.Timestamp, End: dp.Timestamp, Count: 1, Min: dp, Avg: dp, Max: dp})
+		}
+		return buckets
+	}
+
+	merged := &MetricsTimeSeries{
+		Window:  mts.Window,
+		Buckets: append([]MetricsBucket(nil), mts.Buckets...),
+	}
+	for _, dp := range mts.DataPoints {
+		merged.addToBucket(dp)
+	}
+	return merged.Buckets
+}
+
+// WindowDataPoints returns the average of every bucket WindowBuckets returns
+func (mts *MetricsTimeSeries) WindowDataPoints() []MetricsDataPoint {
+	buckets := mts.WindowBuckets()
+	points := make([]MetricsDataPoint, 0, len(buckets))
+	for _, bucket := range buckets {
+		points = append(points, bucket.Avg)
+	}
+	return points
+}
+
+// Clear resets the time series, clearing all data points, buckets and snapshot
 func (mts *MetricsTimeSeries) Clear() {
 	mts.DataPoints = make([]MetricsDataPoint, 0, mts.MaxSize)
+	mts.Buckets = nil
 	mts.LastSnapshot = nil
 }
 
@@ -165,6 +363,9 @@ func (mts *MetricsTimeSeries) GetTimeRange() time.Duration {
 	}
 
 	oldest := mts.DataPoints[0].Timestamp
+	if len(mts.Buckets) > 0 {
+		oldest = mts.Buckets[0].Start
+	}
 	newest := mts.DataPoints[len(mts.DataPoints)-1].Timestamp
 
 	return newest.Sub(oldest)
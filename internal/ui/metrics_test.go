@@ -835,3 +835,193 @@ func TestMetricsTimeSeries_GetDataPoints_EmptyBuffer(t *testing.T) {
 		t.Errorf("GetDataPoints for empty buffer should return empty slice, got length %d", len(points))
 	}
 }
+
+func TestMetricsTimeSeries_AddSnapshot_LatencyAndContexts(t *testing.T) {
+	mts := NewMetricsTimeSeries(12)
+
+	mts.AddSnapshot(&MetricsSnapshot{
+		Timestamp:         time.Unix(0, 0),
+		IndexTotal:        1000,
+		IndexTimeInMillis: 2000,
+		SearchTotal:       500,
+		QueryTimeInMillis: 5000,
+		FetchTotal:        400,
+		FetchTimeInMillis: 400,
+		RejectedTotal:     10,
+		ScrollCurrent:     1,
+	})
+	mts.AddSnapshot(&MetricsSnapshot{
+		Timestamp:         time.Unix(10, 0),
+		IndexTotal:        1500,
+		IndexTimeInMillis: 3000,
+		SearchTotal:       600,
+		QueryTimeInMillis: 7500,
+		FetchTotal:        500,
+		FetchTimeInMillis: 450,
+		RejectedTotal:     60,
+		ScrollCurrent:     4,
+		PITCurrent:        2,
+	})
+
+	want := MetricsDataPoint{
+		Timestamp:     time.Unix(10, 0),
+		InsertRate:    50,
+		SearchRate:    10,
+		IndexLatency:  2,
+		QueryLatency:  25,
+		FetchLatency:  0.5,
+		RejectedRate:  5,
+		ScrollCurrent: 4,
+		PITCurrent:    2,
+	}
+	if got := mts.DataPoints[0]; got != want {
+		t.Errorf("data point = %+v, want %+v", got, want)
+	}
+
+	// A counter reset should report no latency or rejections rather than
+	// negative values
+	mts.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(20, 0), SearchTotal: 10, QueryTimeInMillis: 10})
+	got := mts.DataPoints[1]
+	if got.QueryLatency != 0 || got.IndexLatency != 0 || got.RejectedRate != 0 {
+		t.Errorf("data point after a reset = %+v, want zero latencies and rejections", got)
+	}
+
+	if summary := mts.CalculateSummary("query_latency"); summary.Peak != 25 {
+		t.Errorf("query_latency peak = %v, want 25", summary.Peak)
+	}
+	if summary := mts.CalculateSummary("scroll"); summary.Average != 2 {
+		t.Errorf("scroll average = %v, want 2", summary.Average)
+	}
+}
+
+func TestMetricsTimeSeries_RejectionsUnknown(t *testing.T) {
+	mts := NewMetricsTimeSeries(12)
+	mts.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(0, 0), IndexTotal: 0, RejectedTotal: 100})
+	mts.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(10, 0), IndexTotal: 100, RejectedUnknown: true})
+	mts.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(20, 0), IndexTotal: 200, RejectedTotal: 150})
+
+	// Neither interval touching the missing sample has a rejection rate,
+	// but the other metrics are kept
+	for i, dp := range mts.DataPoints {
+		if !dp.RejectedUnknown || dp.RejectedRate != 0 || dp.InsertRate != 10 {
+			t.Errorf("data point %d = %+v, want unknown rejections and the insert rate", i, dp)
+		}
+	}
+
+	mts.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(30, 0), IndexTotal: 300, RejectedTotal: 160})
+	if dp := mts.DataPoints[2]; dp.RejectedUnknown || dp.RejectedRate != 1 {
+		t.Errorf("data point after recovery = %+v, want 1 rejection/s", dp)
+	}
+}
+
+func TestMetricsTimeSeries_RejectionsUnknownDownsampled(t *testing.T) {
+	// 2 rejections/s, except the two intervals around an unreadable sample
+	mts := NewMetricsWindow(2, time.Hour)
+	for i := 0; i <= 62; i++ {
+		mts.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(int64(i), 0), RejectedTotal: int64(2 * i), RejectedUnknown: i == 10})
+	}
+
+	if len(mts.Buckets) != 1 {
+		t.Fatalf("Buckets = %d, want 1", len(mts.Buckets))
+	}
+	bucket := mts.Buckets[0]
+	if bucket.Count != 60 || bucket.RejectedCount != 58 {
+		t.Errorf("bucket counts = %d/%d, want 60 data points, 58 with known rejections", bucket.Count, bucket.RejectedCount)
+	}
+	if bucket.Min.RejectedRate != 2 || bucket.Avg.RejectedRate != 2 || bucket.Max.RejectedRate != 2 || bucket.Avg.RejectedUnknown {
+		t.Errorf("bucket rejections = %v/%v/%v, want 2 without the unknown zeros", bucket.Min.RejectedRate, bucket.Avg.RejectedRate, bucket.Max.RejectedRate)
+	}
+	if summary := mts.CalculateSummary("rejected"); summary.Min != 2 || summary.Average != 2 || summary.Peak != 2 {
+		t.Errorf("summary = %+v, want min, average and peak 2", summary)
+	}
+
+	// With no known rejection rate at all the summary stays empty
+	unknown := NewMetricsWindow(2, time.Hour)
+	for i := 0; i <= 5; i++ {
+		unknown.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(int64(i), 0), RejectedUnknown: true})
+	}
+	if summary := unknown.CalculateSummary("rejected"); summary != (MetricsSummary{}) {
+		t.Errorf("summary = %+v, want zeros when rejections were never known", summary)
+	}
+	if summary := unknown.CalculateSummary("insert"); summary.Peak != 0 || unknown.Buckets[0].RejectedCount != 0 {
+		t.Errorf("other metrics should still summarise, got %+v", summary)
+	}
+}
+
+func TestMetricsTimeSeries_Downsampling(t *testing.T) {
+	// Two full-resolution points, older ones folded into 1-minute buckets
+	mts := NewMetricsWindow(2, time.Hour)
+
+	// One sample a second: 10 docs/s, except a 100 docs/s spike in the
+	// first minute
+	var total int64
+	for i := 0; i <= 150; i++ {
+		rate := int64(10)
+		if i == 30 {
+			rate = 100
+		}
+		total += rate
+		mts.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(int64(i), 0), IndexTotal: total})
+	}
+
+	if mts.Size() != 2 {
+		t.Errorf("Size() = %d, want 2 full-resolution data points", mts.Size())
+	}
+	if len(mts.Buckets) != 3 {
+		t.Fatalf("Buckets = %d, want 3 one-minute buckets for 148 older samples", len(mts.Buckets))
+	}
+	first := mts.Buckets[0]
+	if first.Count != 60 || first.Max.InsertRate != 100 || first.Min.InsertRate != 10 {
+		t.Errorf("first bucket = count %d, min %v, max %v, want 60, 10, 100", first.Count, first.Min.InsertRate, first.Max.InsertRate)
+	}
+	if first.Avg.InsertRate != 11.5 {
+		t.Errorf("first bucket average = %v, want 11.5", first.Avg.InsertRate)
+	}
+
+	// The spike survives downsampling in the summary
+	summary := mts.CalculateSummary("insert")
+	if summary.Peak != 100 || summary.Min != 10 || summary.Current != 10 {
+		t.Errorf("summary = %+v, want peak 100, min 10, current 10", summary)
+	}
+	if got := summary.Average; got < 10.5 || got > 10.7 {
+		t.Errorf("average = %v, want the spike spread over 150 samples", got)
+	}
+	if mts.GetTimeRange() != 149*time.Second {
+		t.Errorf("GetTimeRange() = %s, want 2m29s from the oldest bucket", mts.GetTimeRange())
+	}
+
+	// Graph points are evenly spaced buckets once downsampling started
+	points := mts.WindowDataPoints()
+	if len(points) != 3 {
+		t.Errorf("WindowDataPoints() = %d points, want the last data points merged into the third bucket", len(points))
+	}
+	if buckets := mts.WindowBuckets(); len(buckets) != 3 || buckets[0].Max.InsertRate != 100 || buckets[2].Count != 30 {
+		t.Errorf("WindowBuckets() = %+v, want the spike as the first bucket's max and the data points in the last", buckets)
+	}
+
+	// Shrinking the window drops buckets that end before it
+	mts.SetWindow(time.Minute)
+	if len(mts.Buckets) != 2 || mts.Buckets[0].Start != time.Unix(61, 0) {
+		t.Errorf("Buckets after SetWindow(1m) = %d, want the two ending inside the window", len(mts.Buckets))
+	}
+
+	mts.Clear()
+	if len(mts.Buckets) != 0 || mts.Size() != 0 {
+		t.Error("Clear() should drop the buckets too")
+	}
+}
+
+func TestMetricsTimeSeries_WindowDropsOldData(t *testing.T) {
+	mts := NewMetricsWindow(100, 10*time.Second)
+	for i := 0; i <= 30; i++ {
+		mts.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(int64(i), 0)})
+	}
+
+	// Data points older than the window go even while the buffer has room
+	if mts.Size() != 11 || mts.DataPoints[0].Timestamp != time.Unix(20, 0) {
+		t.Errorf("Size() = %d from %v, want 11 from 20s", mts.Size(), mts.DataPoints[0].Timestamp.Unix())
+	}
+	if len(mts.Buckets) != 0 {
+		t.Errorf("Buckets = %d, want none while the buffer has room", len(mts.Buckets))
+	}
+}
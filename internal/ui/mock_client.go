@@ -67,6 +67,12 @@ func (m *MockTransport) matchEndpoint(path string) string {
 	switch {
 	case strings.Contains(path, "/_cluster/health"):
 		return "health"
+	case strings.Contains(path, "/_cluster/allocation/explain"):
+		return "allocation_explain"
+	case strings.Contains(path, "/_cluster/state"):
+		return "cluster_state"
+	case strings.Contains(path, "/_cluster/settings"):
+		return "cluster_settings"
 	case strings.Contains(path, "/_cluster/stats"):
 		return "stats"
 	case strings.Contains(path, "/_cat/nodes"):
@@ -91,10 +97,56 @@ func (m *MockTransport) matchEndpoint(path string) string {
 		return "fielddata"
 	case strings.Contains(path, "/_cat/plugins"):
 		return "plugins"
+	case strings.Contains(path, "/_cat/aliases"):
+		return "cat_aliases"
+	case path == "/_data_stream/_stats":
+		return "data_stream_stats"
+	case strings.HasPrefix(path, "/_data_stream"):
+		return "data_streams"
+	case strings.HasPrefix(path, "/_index_template/_simulate"):
+		return "template_simulate"
+	case strings.HasPrefix(path, "/_index_template"):
+		return "index_templates"
+	case strings.HasPrefix(path, "/_component_template"):
+		return "components"
+	case strings.HasPrefix(path, "/_ingest/pipeline/") && strings.HasSuffix(path, "/_simulate"):
+		return "ingest_simulate"
+	case strings.HasPrefix(path, "/_ingest/pipeline"):
+		return "ingest_pipelines"
+	case path == "/_nodes/stats/ingest":
+		return "ingest_stats"
+	case strings.HasPrefix(path, "/_nodes/stats/breaker"):
+		return "node_memory"
+	case strings.HasPrefix(path, "/_nodes/stats/indices,jvm,os"):
+		return "node_activity"
 	case strings.Contains(path, "/_cat/templates"):
 		return "templates"
 	case strings.Contains(path, "/_mapping"):
 		return "mapping"
+	case strings.HasSuffix(path, "/_settings"):
+		return "index_settings"
+	case strings.HasSuffix(path, "/_alias"):
+		return "index_aliases"
+	case strings.Contains(path, "/_ism/explain"):
+		return "ism_explain"
+	case strings.Contains(path, "/_ism/policies"):
+		return "ism_policies"
+	case path == "/_snapshot":
+		return "snapshot_repos"
+	case path == "/_snapshot/_status":
+		return "snapshot_status"
+	case strings.HasPrefix(path, "/_snapshot/"):
+		return "snapshots"
+	case strings.HasSuffix(path, "/hot_threads"):
+		return "hot_threads"
+	case strings.HasPrefix(path, "/_nodes/") && strings.HasSuffix(path, "/stats"):
+		return "node_stats"
+	case strings.HasPrefix(path, "/_nodes/"):
+		return "node_info"
+	case strings.HasPrefix(path, "/_stats/"), strings.HasSuffix(path, "/_stats/indexing,search"):
+		return "index_activity"
+	case strings.HasSuffix(path, "/_stats") && path != "/_stats":
+		return "index_stats"
 	case strings.Contains(path, "/_stats"):
 		return "metrics"
 	default:
@@ -142,22 +194,48 @@ func (m *MockTransport) Reset() {
 // LoadAllFixtures loads all standard test fixtures into the transport
 func (m *MockTransport) LoadAllFixtures() error {
 	fixtureMap := map[string]string{
-		"health":        "cluster_health.json",
-		"stats":         "cluster_stats.json",
-		"nodes":         "nodes.json",
-		"indices":       "indices.json",
-		"shards":        "shards.json",
-		"allocation":    "allocation.json",
-		"threadpool":    "threadpool.json",
-		"tasks":         "tasks.json",
-		"pending_tasks": "pending_tasks.json",
-		"recovery":      "recovery.json",
-		"segments":      "segments.json",
-		"fielddata":     "fielddata.json",
-		"plugins":       "plugins.json",
-		"templates":     "templates.json",
-		"mapping":       "index_mapping.json",
-		"metrics":       "cluster_metrics.json",
+		"health":             "cluster_health.json",
+		"stats":              "cluster_stats.json",
+		"nodes":              "nodes.json",
+		"indices":            "indices.json",
+		"shards":             "shards.json",
+		"allocation":         "allocation.json",
+		"threadpool":         "threadpool.json",
+		"tasks":              "tasks.json",
+		"pending_tasks":      "pending_tasks.json",
+		"recovery":           "recovery.json",
+		"segments":           "segments.json",
+		"fielddata":          "fielddata.json",
+		"plugins":            "plugins.json",
+		"templates":          "templates.json",
+		"mapping":            "index_mapping.json",
+		"metrics":            "cluster_metrics.json",
+		"node_stats":         "node_stats.json",
+		"node_info":          "node_info.json",
+		"index_settings":     "index_settings.json",
+		"index_stats":        "index_stats.json",
+		"index_aliases":      "index_aliases.json",
+		"ism_explain":        "ism_explain.json",
+		"allocation_explain": "allocation_explain.json",
+		"cluster_state":      "cluster_state.json",
+		"hot_threads":        "hot_threads.txt",
+		"snapshot_repos":     "snapshot_repos.json",
+		"snapshots":          "snapshots.json",
+		"snapshot_status":    "snapshot_status.json",
+		"ism_policies":       "ism_policies.json",
+		"cat_aliases":        "cat_aliases.json",
+		"data_streams":       "data_streams.json",
+		"data_stream_stats":  "data_stream_stats.json",
+		"index_templates":    "index_templates.json",
+		"components":         "component_templates.json",
+		"template_simulate":  "template_simulate.json",
+		"ingest_pipelines":   "ingest_pipelines.json",
+		"ingest_stats":       "ingest_stats.json",
+		"ingest_simulate":    "ingest_simulate.json",
+		"cluster_settings":   "cluster_settings.json",
+		"node_memory":        "node_memory.json",
+		"index_activity":     "index_activity.json",
+		"node_activity":      "node_activity.json",
 	}
 
 	for endpoint, filename := range fixtureMap {
@@ -122,6 +122,31 @@ func TestMockTransport_MatchEndpoint(t *testing.T) {
 		{"/_cat/templates", "templates"},
 		{"/myindex/_mapping", "mapping"},
 		{"/_stats", "metrics"},
+		{"/_stats/indexing,search", "index_activity"},
+		{"/_nodes/stats/indices,jvm,os/indexing,search", "node_activity"},
+		{"/myindex/_stats", "index_stats"},
+		{"/myindex/_settings", "index_settings"},
+		{"/myindex/_alias", "index_aliases"},
+		{"/_plugins/_ism/explain/myindex", "ism_explain"},
+		{"/_plugins/_ism/policies", "ism_policies"},
+		{"/_cat/aliases", "cat_aliases"},
+		{"/_data_stream", "data_streams"},
+		{"/_data_stream/_stats", "data_stream_stats"},
+		{"/_index_template", "index_templates"},
+		{"/_index_template/_simulate/logs-app", "template_simulate"},
+		{"/_component_template", "components"},
+		{"/_ingest/pipeline", "ingest_pipelines"},
+		{"/_ingest/pipeline/logs-app/_simulate", "ingest_simulate"},
+		{"/_nodes/stats/ingest", "ingest_stats"},
+		{"/_cluster/settings", "cluster_settings"},
+		{"/_nodes/stats/breaker,indices/query_cache,request_cache,fielddata,segments", "node_memory"},
+		{"/_cluster/allocation/explain", "allocation_explain"},
+		{"/_cluster/state/routing_nodes", "cluster_state"},
+		{"/_nodes/hot_threads", "hot_threads"},
+		{"/_nodes/node-1/hot_threads", "hot_threads"},
+		{"/_snapshot", "snapshot_repos"},
+		{"/_snapshot/_status", "snapshot_status"},
+		{"/_snapshot/backups/_all", "snapshots"},
 		{"/unknown/path", "unknown"},
 	}
 
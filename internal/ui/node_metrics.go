@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"time"
+)
+
+// newNodeActivitySnapshot keys every node's counters and gauges by name
+func newNodeActivitySnapshot(nodes []NodeActivity, timestamp time.Time) *NodeActivitySnapshot {
+	snapshot := &NodeActivitySnapshot{
+		Timestamp: timestamp,
+		Nodes:     make(map[string]NodeActivity, len(nodes)),
+	}
+	for _, node := range nodes {
+		snapshot.Nodes[node.Name] = node
+	}
+	return snapshot
+}
+
+// NodeTimeSeries manages time-series data for per-node metrics
+type NodeTimeSeries struct {
+	dataPoints    []NodeMetricsDataPoint
+	maxDataPoints int
+	lastSnapshot  *NodeActivitySnapshot
+}
+
+// NewNodeTimeSeries creates a new per-node metrics time series tracker
+func NewNodeTimeSeries(maxDataPoints int) *NodeTimeSeries {
+	return &NodeTimeSeries{
+		dataPoints:    make([]NodeMetricsDataPoint, 0, maxDataPoints),
+		maxDataPoints: maxDataPoints,
+	}
+}
+
+// AddSnapshot adds a new snapshot and calculates every node's rates.
+// Returns true if a data point was added (false for the first baseline
+// snapshot)
+func (ts *NodeTimeSeries) AddSnapshot(snapshot *NodeActivitySnapshot) bool {
+	if ts.lastSnapshot == nil {
+		// First snapshot - use as baseline only
+		ts.lastSnapshot = snapshot
+		return false
+	}
+
+	// Calculate time delta
+	timeDelta := snapshot.Timestamp.Sub(ts.lastSnapshot.Timestamp).Seconds()
+	if timeDelta <= 0 {
+		// Skip if no time has passed
+		return false
+	}
+
+	dataPoint := NodeMetricsDataPoint{
+		Timestamp: snapshot.Timestamp,
+		Nodes:     make(map[string]NodeRates, len(snapshot.Nodes)),
+	}
+	for name, current := range snapshot.Nodes {
+		last, ok := ts.lastSnapshot.Nodes[name]
+		if !ok {
+			// Node joined since the last snapshot
+			continue
+		}
+		dataPoint.Nodes[name] = NodeRates{
+			IndexRate:       counterRate(current.IndexTotal, last.IndexTotal, timeDelta),
+			SearchRate:      counterRate(current.QueryTotal, last.QueryTotal, timeDelta),
+			HeapUsedPercent: percentOf(current.HeapUsedInBytes, current.HeapMaxInBytes),
+			OldGCRate:       counterRate(current.OldGCCount, last.OldGCCount, timeDelta),
+			OldGCTimeRate:   counterRate(current.OldGCTimeInMillis, last.OldGCTimeInMillis, timeDelta),
+			CPUPercent:      float64(current.CPUPercent),
+		}
+	}
+
+	// Add to ring buffer
+	if len(ts.dataPoints) >= ts.maxDataPoints {
+		// Remove oldest
+		ts.dataPoints = ts.dataPoints[1:]
+	}
+	ts.dataPoints = append(ts.dataPoints, dataPoint)
+
+	// Update last snapshot
+	ts.lastSnapshot = snapshot
+	return true
+}
+
+// counterRate returns how fast a cumulative counter grew per second, or 0
+// after a counter reset (node restart)
+func counterRate(current, last int64, seconds float64) float64 {
+	if current < last {
+		return 0
+	}
+	return float64(current-last) / seconds
+}
+
+// Latest returns a node's metrics from the newest data point
+func (ts *NodeTimeSeries) Latest(node string) (NodeRates, bool) {
+	if len(ts.dataPoints) == 0 {
+		return NodeRates{}, false
+	}
+	rates, ok := ts.dataPoints[len(ts.dataPoints)-1].Nodes[node]
+	return rates, ok
+}
+
+// Nodes returns the names of the nodes with metrics in the window, sorted
+func (ts *NodeTimeSeries) Nodes() []string {
+	seen := make(map[string]bool)
+	for _, dp := range ts.dataPoints {
+		for name := range dp.Nodes {
+			seen[name] = true
+		}
+	}
+	return sortedKeys(seen)
+}
+
+// Series returns one metric of a node at every data point, oldest first
+func (ts *NodeTimeSeries) Series(node string, metric func(NodeRates) float64) []float64 {
+	values := make([]float64, 0, len(ts.dataPoints))
+	for _, dp := range ts.dataPoints {
+		values = append(values, metric(dp.Nodes[node]))
+	}
+	return values
+}
+
+// Size returns the number of data points
+func (ts *NodeTimeSeries) Size() int {
+	return len(ts.dataPoints)
+}
+
+// Clear removes all data points
+func (ts *NodeTimeSeries) Clear() {
+	ts.dataPoints = make([]NodeMetricsDataPoint, 0, ts.maxDataPoints)
+	ts.lastSnapshot = nil
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNodeTimeSeries_AddSnapshot(t *testing.T) {
+	snapshot := func(seconds int64, nodes ...NodeActivity) *NodeActivitySnapshot {
+		return newNodeActivitySnapshot(nodes, time.Unix(seconds, 0))
+	}
+
+	ts := NewNodeTimeSeries(12)
+	if ts.AddSnapshot(snapshot(0,
+		NodeActivity{Name: "node-1", IndexTotal: 1000, QueryTotal: 100, OldGCCount: 2, OldGCTimeInMillis: 100},
+		NodeActivity{Name: "node-2", IndexTotal: 5000})) {
+		t.Error("First snapshot should only be a baseline")
+	}
+	if _, ok := ts.Latest("node-1"); ok {
+		t.Error("No rates should be known after the baseline")
+	}
+
+	if !ts.AddSnapshot(snapshot(10,
+		NodeActivity{Name: "node-1", IndexTotal: 2000, QueryTotal: 150, HeapUsedInBytes: 512, HeapMaxInBytes: 1024,
+			OldGCCount: 3, OldGCTimeInMillis: 600, CPUPercent: 40},
+		NodeActivity{Name: "node-2", IndexTotal: 10},
+		NodeActivity{Name: "node-3", IndexTotal: 50})) {
+		t.Fatal("Second snapshot should add a data point")
+	}
+
+	want := NodeRates{IndexRate: 100, SearchRate: 5, HeapUsedPercent: 50, OldGCRate: 0.1, OldGCTimeRate: 50, CPUPercent: 40}
+	if got, _ := ts.Latest("node-1"); got != want {
+		t.Errorf("node-1 = %+v, want %+v", got, want)
+	}
+	if got, _ := ts.Latest("node-2"); got.IndexRate != 0 {
+		t.Errorf("node-2 index rate = %v, want 0 after a counter reset", got.IndexRate)
+	}
+	if _, ok := ts.Latest("node-3"); ok {
+		t.Error("A node without a baseline should have no rates yet")
+	}
+	if nodes := ts.Nodes(); strings.Join(nodes, ",") != "node-1,node-2" {
+		t.Errorf("Nodes() = %v, want node-1 and node-2", nodes)
+	}
+
+	if ts.AddSnapshot(snapshot(10)) {
+		t.Error("Snapshot with no time delta should be skipped")
+	}
+	if series := ts.Series("node-1", nodeIndexRate); len(series) != 1 || series[0] != 100 {
+		t.Errorf("Series() = %v, want [100]", series)
+	}
+
+	ts.Clear()
+	if ts.Size() != 0 || ts.AddSnapshot(snapshot(20)) {
+		t.Error("Clear() should drop the data points and the baseline")
+	}
+}
+
+func TestNodeTimeSeries_RingBuffer(t *testing.T) {
+	ts := NewNodeTimeSeries(3)
+	for i := int64(0); i <= 5; i++ {
+		ts.AddSnapshot(newNodeActivitySnapshot([]NodeActivity{{Name: "node-1", IndexTotal: i * i * 10}}, time.Unix(i, 0)))
+	}
+
+	if ts.Size() != 3 {
+		t.Fatalf("Size() = %d, want 3", ts.Size())
+	}
+	// Only the last three of the five intervals are kept, oldest first
+	series := ts.Series("node-1", nodeIndexRate)
+	want := []float64{50, 70, 90}
+	for i := range want {
+		if series[i] != want[i] {
+			t.Errorf("Series() = %v, want %v", series, want)
+			break
+		}
+	}
+}
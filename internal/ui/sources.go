@@ -0,0 +1,453 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// refreshWorkers bounds how many cluster APIs are called at once during a
+// refresh, so a large refresh doesn't flood the cluster with requests
+const refreshWorkers = 4
+
+// unassignedExplainLimit caps the unassigned shards explained per refresh
+// when summarising why shards can't be allocated
+const unassignedExplainLimit = 20
+
+// allSources lists every data source polled by the refresh loop
+var allSources = []DataSource{
+	SourceHealth,
+	SourceStats,
+	SourceNodes,
+	SourceIndices,
+	SourceShards,
+	SourceAllocation,
+	SourceThreadPool,
+	SourceTasks,
+	SourcePendingTasks,
+	SourceRecovery,
+	SourceSegments,
+	SourceFielddata,
+	SourcePlugins,
+	SourceTemplates,
+	SourceUnassigned,
+	SourceSnapshots,
+	SourceISM,
+	SourceAliases,
+	SourceIndexTemplates,
+	SourceIngest,
+	SourceClusterSettings,
+	SourceMemory,
+	SourceNodeActivity,
+}
+
+// slowSources cost several API calls each, so auto-refreshes only refetch
+// them once their data is older than the interval
+var slowSources = map[DataSource]time.Duration{
+	SourceUnassigned: time.Minute, // One allocation explain call per sampled shard
+}
+
+// sharedSources are fetched on every refresh regardless of the current view
+// since they're small and the cluster overview depends on them
+var sharedSources = []DataSource{SourceHealth}
+
+// viewSources lists the data sources each view renders. Only the current
+// view's sources are polled; the rest load when the user navigates there.
+var viewSources = map[View][]DataSource{
+	ViewCluster:      {SourceHealth, SourceStats},
+	ViewNodes:        {SourceNodes, SourceNodeActivity}, // Activity feeds the per-node sparklines
+	ViewIndices:      {SourceIndices},
+	ViewShards:       {SourceShards, SourceNodes, SourceUnassigned},
+	ViewResources:    {SourceNodes},
+	ViewAllocation:   {SourceAllocation},
+	ViewThreadPool:   {SourceThreadPool},
+	ViewTasks:        {SourceTasks},
+	ViewPendingTasks: {SourcePendingTasks},
+	ViewRecovery:     {SourceRecovery},
+	ViewSegments:     {SourceSegments},
+	ViewFielddata:    {SourceFielddata},
+	ViewPlugins:      {SourcePlugins},
+	ViewTemplates:    {SourceTemplates, SourceIndexTemplates},
+	ViewHotThreads:   {SourceNodes}, // Node names to sample
+	ViewSnapshots:    {SourceSnapshots},
+	ViewISM:          {SourceISM, SourcePlugins}, // Plugins tell whether ISM is installed
+	ViewAliases:      {SourceAliases},
+	ViewIngest:       {SourceIngest},
+	ViewSettings:     {SourceClusterSettings},
+	ViewMemory:       {SourceMemory},
+	ViewNodeGraphs:   {SourceNodeActivity},
+	ViewIndexSchema:  {SourceShards}, // Shards tab of the index drill-down
+}
+
+// sourcesFor returns the data sources to poll while a view is shown
+func sourcesFor(view View) []DataSource {
+	sources := append([]DataSource(nil), sharedSources...)
+	for _, source := range viewSources[view] {
+		if !containsSource(sources, source) {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// dueSources returns the sources to poll while a view is shown, leaving out
+// slow sources fetched within their interval
+func (a *App) dueSources(view View, now time.Time) []DataSource {
+	var due []DataSource
+	for _, source := range sourcesFor(view) {
+		if interval, slow := slowSources[source]; slow {
+			if updated, ok := a.sourceUpdated[source]; ok && now.Sub(updated) < interval {
+				continue
+			}
+		}
+		due = append(due, source)
+	}
+	return due
+}
+
+// containsSource reports whether source is in sources
+func containsSource(sources []DataSource, source DataSource) bool {
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// missingSources returns the sources a view needs that have never been
+// fetched and aren't already being fetched
+func (a *App) missingSources(view View) []DataSource {
+	var missing []DataSource
+	for _, source := range sourcesFor(view) {
+		if _, ok := a.sourceUpdated[source]; ok {
+			continue
+		}
+		if _, ok := a.sourceErrs[source]; ok {
+			continue
+		}
+		if a.sourcePending[source] {
+			continue
+		}
+		missing = append(missing, source)
+	}
+	return missing
+}
+
+// markPending records that sources are being fetched so navigation doesn't
+// request them twice
+func (a *App) markPending(sources []DataSource) {
+	if a.sourcePending == nil {
+		a.sourcePending = make(map[DataSource]bool)
+	}
+	for _, source := range sources {
+		a.sourcePending[source] = true
+	}
+}
+
+// fetchViewData loads data for the current view if it hasn't been fetched
+// yet, e.g. right after the user navigates to it
+func (a *App) fetchViewData() tea.Cmd {
+	sources := a.missingSources(a.currentView)
+	if len(sources) == 0 {
+		return nil
+	}
+	a.markPending(sources)
+
+	ctx, f := a.fetchContext(), a.fetcher()
+	epoch, gen := a.connEpoch, a.refreshGen
+	return func() tea.Msg {
+		return refreshMsg{
+			results: f.fetchSources(ctx, sources),
+			epoch:   epoch,
+			gen:     gen,
+			partial: true,
+		}
+	}
+}
+
+// String returns the short name of a data source
+func (s DataSource) String() string {
+	switch s {
+	case SourceHealth:
+		return "health"
+	case SourceStats:
+		return "stats"
+	case SourceNodes:
+		return "nodes"
+	case SourceIndices:
+		return "indices"
+	case SourceShards:
+		return "shards"
+	case SourceAllocation:
+		return "allocation"
+	case SourceThreadPool:
+		return "thread_pool"
+	case SourceTasks:
+		return "tasks"
+	case SourcePendingTasks:
+		return "pending_tasks"
+	case SourceRecovery:
+		return "recovery"
+	case SourceSegments:
+		return "segments"
+	case SourceFielddata:
+		return "fielddata"
+	case SourcePlugins:
+		return "plugins"
+	case SourceTemplates:
+		return "templates"
+	case SourceUnassigned:
+		return "unassigned shard explanations"
+	case SourceSnapshots:
+		return "snapshots"
+	case SourceISM:
+		return "ISM"
+	case SourceAliases:
+		return "aliases"
+	case SourceIndexTemplates:
+		return "index templates"
+	case SourceIngest:
+		return "ingest"
+	case SourceClusterSettings:
+		return "cluster settings"
+	case SourceMemory:
+		return "node memory"
+	case SourceNodeActivity:
+		return "node activity"
+	default:
+		return fmt.Sprintf("source(%d)", int(s))
+	}
+}
+
+// fetchSources fetches the given sources concurrently with a bounded worker
+// pool. Every source gets a result; one failure never discards the others.
+func (f fetcher) fetchSources(ctx context.Context, sources []DataSource) map[DataSource]sourceResult {
+	results := make(map[DataSource]sourceResult, len(sources))
+	if len(sources) == 0 {
+		return results
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan DataSource)
+
+	workers := refreshWorkers
+	if len(sources) < workers {
+		workers = len(sources)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for source := range jobs {
+				data, err := f.fetchSource(ctx, source)
+				mu.Lock()
+				results[source] = sourceResult{data: data, err: err, fetchedAt: time.Now()}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, source := range sources {
+		jobs <- source
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// fetchSource fetches a single data source, bounded by the request timeout
+func (f fetcher) fetchSource(ctx context.Context, source DataSource) (interface{}, error) {
+	ctx, cancel := f.requestContext(ctx)
+	defer cancel()
+
+	data, err := f.fetchSourceData(ctx, source)
+	return data, f.timeoutError(err)
+}
+
+// fetchSourceData calls the fetch function for a single data source
+func (f fetcher) fetchSourceData(ctx context.Context, source DataSource) (interface{}, error) {
+	switch source {
+	case SourceHealth:
+		return f.source.ClusterHealth(ctx)
+	case SourceStats:
+		return f.source.ClusterStats(ctx)
+	case SourceNodes:
+		return f.source.Nodes(ctx)
+	case SourceIndices:
+		return f.source.Indices(ctx)
+	case SourceShards:
+		return f.source.Shards(ctx)
+	case SourceAllocation:
+		return f.source.Allocation(ctx)
+	case SourceThreadPool:
+		return f.source.ThreadPool(ctx)
+	case SourceTasks:
+		return f.source.Tasks(ctx)
+	case SourcePendingTasks:
+		return f.source.PendingTasks(ctx)
+	case SourceRecovery:
+		return f.source.Recovery(ctx)
+	case SourceSegments:
+		return f.source.Segments(ctx)
+	case SourceFielddata:
+		return f.source.Fielddata(ctx)
+	case SourcePlugins:
+		return f.source.Plugins(ctx)
+	case SourceTemplates:
+		return f.source.Templates(ctx)
+	case SourceUnassigned:
+		return f.source.ExplainUnassigned(ctx, unassignedExplainLimit)
+	case SourceSnapshots:
+		return f.source.Snapshots(ctx)
+	case SourceISM:
+		return f.source.ISM(ctx)
+	case SourceAliases:
+		return f.source.Aliases(ctx)
+	case SourceIndexTemplates:
+		return f.source.IndexTemplates(ctx)
+	case SourceIngest:
+		return f.source.Ingest(ctx)
+	case SourceClusterSettings:
+		return f.source.ClusterSettings(ctx)
+	case SourceMemory:
+		return f.source.NodeMemory(ctx)
+	case SourceNodeActivity:
+		return f.source.NodeActivity(ctx)
+	default:
+		return nil, fmt.Errorf("unknown data source %s", source)
+	}
+}
+
+// applyResults stores successfully fetched data and records per-source
+// errors. Data from a failed source is kept from its last successful fetch.
+func (a *App) applyResults(results map[DataSource]sourceResult) {
+	if a.sourceErrs == nil {
+		a.sourceErrs = make(map[DataSource]error)
+	}
+	if a.sourceUpdated == nil {
+		a.sourceUpdated = make(map[DataSource]time.Time)
+	}
+
+	for source, res := range results {
+		delete(a.sourcePending, source)
+		if res.err != nil {
+			a.sourceErrs[source] = res.err
+			continue
+		}
+		delete(a.sourceErrs, source)
+		a.sourceUpdated[source] = res.fetchedAt
+
+		switch source {
+		case SourceHealth:
+			a.health = res.data.(*ClusterHealth)
+		case SourceStats:
+			a.stats = res.data.(*ClusterStats)
+		case SourceNodes:
+			a.nodes = res.data.([]NodeInfo)
+		case SourceIndices:
+			a.indices = res.data.([]IndexInfo)
+		case SourceShards:
+			a.shards = res.data.([]ShardInfo)
+		case SourceAllocation:
+			a.allocation = res.data.([]AllocationInfo)
+		case SourceThreadPool:
+			a.threadPool = res.data.([]ThreadPoolInfo)
+		case SourceTasks:
+			a.tasks = res.data.([]TaskInfo)
+		case SourcePendingTasks:
+			a.pendingTasks = res.data.([]PendingTaskInfo)
+		case SourceRecovery:
+			a.recovery = res.data.([]RecoveryInfo)
+		case SourceSegments:
+			a.segments = res.data.([]SegmentInfo)
+		case SourceFielddata:
+			a.fielddata = res.data.([]FielddataInfo)
+		case SourcePlugins:
+			a.plugins = res.data.([]PluginInfo)
+		case SourceTemplates:
+			a.templates = res.data.([]TemplateInfo)
+		case SourceUnassigned:
+			a.unassigned = res.data.(*UnassignedReport)
+		case SourceSnapshots:
+			a.snapshots = res.data.(*SnapshotOverview)
+		case SourceISM:
+			a.ism = res.data.(*ISMOverview)
+		case SourceAliases:
+			a.aliases = res.data.(*AliasOverview)
+		case SourceIndexTemplates:
+			a.indexTemplates = res.data.(*TemplateOverview)
+		case SourceIngest:
+			a.ingest = res.data.(*IngestOverview)
+		case SourceClusterSettings:
+			a.clusterSettings = res.data.(*ClusterSettings)
+		case SourceMemory:
+			a.memory = res.data.([]NodeMemory)
+			a.memoryTimeSeries.AddSnapshot(newMemorySnapshot(a.memory, res.fetchedAt))
+		case SourceNodeActivity:
+			a.nodeTimeSeries.AddSnapshot(newNodeActivitySnapshot(res.data.([]NodeActivity), res.fetchedAt))
+		}
+	}
+}
+
+// refreshError returns an error only when every source in the refresh failed
+// (e.g. the cluster is unreachable); partial failures are shown per view
+func refreshError(results map[DataSource]sourceResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, source := range allSources {
+		res, ok := results[source]
+		if !ok {
+			continue
+		}
+		if res.err == nil {
+			return nil
+		}
+		errs = append(errs, res.err)
+	}
+
+	// All sources usually fail for the same reason; report the first
+	return errors.Join(errs[0], fmt.Errorf("all %d data sources failed", len(results)))
+}
+
+// renderSourceStatus renders error and stale markers for the sources a view
+// depends on. Returns an empty string when everything is fresh.
+func (a *App) renderSourceStatus(view View) string {
+	var lines []string
+
+	sources := append([]DataSource(nil), viewSources[view]...)
+	sort.Slice(sources, func(i, j int) bool { return sources[i] < sources[j] })
+
+	for _, source := range sources {
+		err, failed := a.sourceErrs[source]
+		if !failed {
+			if _, ok := a.sourceUpdated[source]; !ok && a.sourcePending[source] {
+				lines = append(lines, subtleStyle.Render(fmt.Sprintf("Loading %s...", source)))
+			}
+			continue
+		}
+		if updated, ok := a.sourceUpdated[source]; ok {
+			lines = append(lines, statusYellow.Render(fmt.Sprintf("⚠ Stale %s data from %s: %v",
+				source, updated.Format("15:04:05"), err)))
+		} else {
+			lines = append(lines, errorStyle.Render(fmt.Sprintf("✗ %s unavailable: %v", source, err)))
+		}
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n\n"
+}
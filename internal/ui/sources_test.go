@@ -0,0 +1,324 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	opensearch "github.com/opensearch-project/opensearch-go/v2"
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// concurrencyTransport records the peak number of requests in flight
+type concurrencyTransport struct {
+	next     http.RoundTripper
+	delay    time.Duration
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (c *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.inFlight++
+	if c.inFlight > c.peak {
+		c.peak = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(c.delay)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+
+	return c.next.RoundTrip(req)
+}
+
+func TestFetchSources_BoundedConcurrency(t *testing.T) {
+	transport := NewMockTransport()
+	if err := transport.LoadAllFixtures(); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	counting := &concurrencyTransport{next: transport, delay: 20 * time.Millisecond}
+
+	client, err := opensearch.NewClient(opensearch.Config{
+		Addresses: []string{"http://localhost:9200"},
+		Transport: counting,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	app := NewTestApp(client, "http://localhost:9200")
+	results := app.fetcher().fetchSources(t.Context(), allSources)
+
+	if len(results) != len(allSources) {
+		t.Errorf("fetchSources() returned %d results, want %d", len(results), len(allSources))
+	}
+	for source, res := range results {
+		if res.err != nil {
+			t.Errorf("%s: unexpected error %v", source, res.err)
+		}
+		if res.fetchedAt.IsZero() {
+			t.Errorf("%s: fetchedAt not set", source)
+		}
+	}
+
+	if counting.peak > refreshWorkers {
+		t.Errorf("peak concurrency = %d, want <= %d", counting.peak, refreshWorkers)
+	}
+	if counting.peak < 2 {
+		t.Errorf("peak concurrency = %d, sources should be fetched in parallel", counting.peak)
+	}
+}
+
+func TestFetchSources_Empty(t *testing.T) {
+	app := &App{}
+	if results := app.fetcher().fetchSources(t.Context(), nil); len(results) != 0 {
+		t.Errorf("fetchSources(nil) = %d results, want 0", len(results))
+	}
+}
+
+func TestRefreshError(t *testing.T) {
+	failed := sourceResult{err: fmt.Errorf("connection refused")}
+	ok := sourceResult{data: &ClusterHealth{}}
+
+	tests := []struct {
+		name    string
+		results map[DataSource]sourceResult
+		wantErr bool
+	}{
+		{"all_ok", map[DataSource]sourceResult{SourceHealth: ok, SourceNodes: ok}, false},
+		{"partial", map[DataSource]sourceResult{SourceHealth: failed, SourceNodes: ok}, false},
+		{"all_failed", map[DataSource]sourceResult{SourceHealth: failed, SourceNodes: failed}, true},
+		{"empty", map[DataSource]sourceResult{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := refreshError(tt.results)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("refreshError() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "connection refused") {
+				t.Errorf("refreshError() = %v, want the underlying cause", err)
+			}
+		})
+	}
+}
+
+func TestRenderSourceStatus(t *testing.T) {
+	updated := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	app := &App{
+		sourceErrs: map[DataSource]error{
+			SourceHealth: fmt.Errorf("timeout"),
+			SourceNodes:  fmt.Errorf("forbidden"),
+		},
+		sourceUpdated: map[DataSource]time.Time{SourceHealth: updated},
+	}
+
+	cluster := app.renderSourceStatus(ViewCluster)
+	if !strings.Contains(cluster, "Stale health data from 15:04:05") || !strings.Contains(cluster, "timeout") {
+		t.Errorf("Cluster view should mark health as stale, got %q", cluster)
+	}
+
+	nodes := app.renderSourceStatus(ViewNodes)
+	if !strings.Contains(nodes, "nodes unavailable: forbidden") {
+		t.Errorf("Nodes view should mark nodes as unavailable, got %q", nodes)
+	}
+
+	if got := app.renderSourceStatus(ViewIndices); got != "" {
+		t.Errorf("Indices view has no failed sources, got %q", got)
+	}
+}
+
+func TestApplyResults_KeepsLastGoodData(t *testing.T) {
+	app := &App{}
+	health := &ClusterHealth{ClusterName: "first"}
+
+	app.applyResults(map[DataSource]sourceResult{
+		SourceHealth: {data: health, fetchedAt: time.Now()},
+	})
+	app.applyResults(map[DataSource]sourceResult{
+		SourceHealth: {err: fmt.Errorf("boom"), fetchedAt: time.Now()},
+	})
+
+	if app.health != health {
+		t.Error("failed fetch should keep the previous health data")
+	}
+	if app.sourceErrs[SourceHealth] == nil {
+		t.Error("failed fetch should be recorded")
+	}
+	if _, ok := app.sourceUpdated[SourceHealth]; !ok {
+		t.Error("last successful fetch time should be kept")
+	}
+}
+
+func TestRefresh_OnlyFetchesCurrentView(t *testing.T) {
+	transport := NewMockTransport()
+	if err := transport.LoadAllFixtures(); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	client, err := NewMockClient(transport)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	app := NewTestApp(client, "http://localhost:9200")
+	app.Update(ExecuteCommand(app.Init()))
+
+	for _, endpoint := range []string{"health", "stats"} {
+		if transport.GetCallCount(endpoint) != 1 {
+			t.Errorf("%s called %d times, want 1", endpoint, transport.GetCallCount(endpoint))
+		}
+	}
+	for _, endpoint := range []string{"shards", "segments", "fielddata", "recovery", "plugins", "templates"} {
+		if transport.GetCallCount(endpoint) != 0 {
+			t.Errorf("%s should not be polled from Cluster Overview", endpoint)
+		}
+	}
+
+	// Refreshing on the Segments view polls segments plus shared health only
+	app.currentView = ViewSegments
+	app.Update(ExecuteCommand(app.refresh()))
+	if transport.GetCallCount("segments") != 1 || transport.GetCallCount("health") != 2 {
+		t.Errorf("segments/health calls = %d/%d, want 1/2",
+			transport.GetCallCount("segments"), transport.GetCallCount("health"))
+	}
+	if transport.GetCallCount("stats") != 1 {
+		t.Error("stats should not be polled from the Segments view")
+	}
+}
+
+func TestNavigation_LoadsViewData(t *testing.T) {
+	transport := NewMockTransport()
+	if err := transport.LoadAllFixtures(); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	client, err := NewMockClient(transport)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	app := NewTestApp(client, "http://localhost:9200")
+	SendWindowSize(app, 120, 40)
+	app.Update(ExecuteCommand(app.Init()))
+
+	// Move from Cluster Overview to Nodes
+	_, cmd := SendKey(app, "down")
+	if cmd == nil {
+		t.Fatal("navigating to an unloaded view should fetch its data")
+	}
+	if !app.sourcePending[SourceNodes] {
+		t.Error("nodes should be marked as pending")
+	}
+	if !strings.Contains(app.viewport.View(), "Loading nodes") {
+		t.Error("view should show that its data is loading")
+	}
+
+	app.Update(ExecuteCommand(cmd))
+	if app.nodes == nil {
+		t.Error("nodes should load after navigating to the Nodes view")
+	}
+	if app.indices != nil {
+		t.Error("indices should not load until the Indices view is shown")
+	}
+
+	// Coming back to an already loaded view doesn't refetch
+	SendKey(app, "up")
+	if _, cmd := SendKey(app, "down"); cmd != nil {
+		t.Error("already loaded views should be kept fresh by refresh, not navigation")
+	}
+	if transport.GetCallCount("nodes") != 1 {
+		t.Errorf("nodes called %d times, want 1", transport.GetCallCount("nodes"))
+	}
+}
+
+func TestNavigation_FailedViewFetchKeepsApp(t *testing.T) {
+	client, err := NewMockClientWithError("plugins")
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+
+	app := NewTestApp(client, "http://localhost:9200")
+	SendWindowSize(app, 120, 40)
+	app.Update(ExecuteCommand(app.Init()))
+
+	app.currentView = ViewPlugins
+	app.Update(ExecuteCommand(app.fetchViewData()))
+
+	if app.err != nil {
+		t.Errorf("a failed view fetch should not replace the whole UI with an error: %v", app.err)
+	}
+	if app.sourceErrs[SourcePlugins] == nil {
+		t.Error("plugins error should be recorded")
+	}
+}
+
+func TestFakeSource_ViewsWithoutHTTP(t *testing.T) {
+	fake := &FakeSource{
+		HealthData: &ClusterHealth{ClusterName: "fake-cluster", Status: "green"},
+		NodesData: []NodeInfo{
+			{Name: "fake-node-1", IP: "10.0.0.1", HeapPercent: "40", CPU: "10", NodeRole: "dim"},
+		},
+		Errors: map[DataSource]error{SourceStats: fmt.Errorf("stats disabled")},
+	}
+
+	app := NewApp(fake, "fake://", "none")
+	SendWindowSize(app, 120, 40)
+	app.Update(ExecuteCommand(app.Init()))
+
+	if app.err != nil {
+		t.Fatalf("unexpected error: %v", app.err)
+	}
+	if app.health.ClusterName != "fake-cluster" {
+		t.Errorf("health.ClusterName = %q, want fake-cluster", app.health.ClusterName)
+	}
+	if !strings.Contains(app.viewport.View(), "stats unavailable: stats disabled") {
+		t.Error("Cluster view should flag the failed stats source")
+	}
+
+	_, cmd := SendKey(app, "down")
+	app.Update(ExecuteCommand(cmd))
+	if !strings.Contains(app.renderNodesView(), "fake-node-1") {
+		t.Error("Nodes view should render nodes from the fake source")
+	}
+}
+
+func TestFetchClusterMetrics_RejectionsAndContexts(t *testing.T) {
+	fake := &FakeSource{
+		ActivityData: &source.ActivityStats{IndexTotal: 100, QueryTimeInMillis: 40, ScrollCurrent: 3, PITCurrent: 1},
+		ThreadPoolData: []ThreadPoolInfo{
+			{NodeName: "node-1", Name: "write", Rejected: "7"},
+			{NodeName: "node-2", Name: "write", Rejected: "5"},
+			{NodeName: "node-2", Name: "search", Rejected: "3"},
+		},
+	}
+	app := NewApp(fake, "fake://", "none")
+
+	snapshot, err := app.fetcher().fetchClusterMetrics(context.Background())
+	if err != nil {
+		t.Fatalf("fetchClusterMetrics() error = %v", err)
+	}
+	if snapshot.RejectedTotal != 15 {
+		t.Errorf("RejectedTotal = %d, want the sum over every node and pool", snapshot.RejectedTotal)
+	}
+	if snapshot.IndexTotal != 100 || snapshot.QueryTimeInMillis != 40 || snapshot.ScrollCurrent != 3 || snapshot.PITCurrent != 1 {
+		t.Errorf("snapshot = %+v", snapshot)
+	}
+
+	// Rejections are optional; the rest of the sample survives without them
+	fake.Errors = map[DataSource]error{SourceThreadPool: fmt.Errorf("thread pools unavailable")}
+	snapshot, err = app.fetcher().fetchClusterMetrics(context.Background())
+	if err != nil {
+		t.Fatalf("fetchClusterMetrics() without thread pools error = %v", err)
+	}
+	if !snapshot.RejectedUnknown || snapshot.IndexTotal != 100 || snapshot.ScrollCurrent != 3 {
+		t.Errorf("snapshot without thread pools = %+v, want rejections unknown and the rest kept", snapshot)
+	}
+}
@@ -2,13 +2,16 @@ package ui
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	opensearch "github.com/opensearch-project/opensearch-go/v2"
+	"github.com/vegasq/ostop/internal/source"
 )
 
 // LoadFixture loads a test fixture from the testdata directory
@@ -51,7 +54,7 @@ func NewMockClientWithError(endpoint string) (*opensearch.Client, error) {
 
 // NewTestApp creates a new App instance for testing with a mock client
 func NewTestApp(client *opensearch.Client, endpoint string) *App {
-	return NewApp(client, endpoint)
+	return NewApp(source.NewOpenSearch(client), endpoint, "none")
 }
 
 // ExecuteCommand executes a Bubble Tea command synchronously and returns the message
@@ -110,7 +113,8 @@ func SetupTestApp() (*App, error) {
 	return app, nil
 }
 
-// InitializeTestApp creates, initializes, and refreshes a test app
+// InitializeTestApp creates, initializes, and refreshes a test app with data
+// loaded for every view
 func InitializeTestApp() (*App, error) {
 	app, err := SetupTestApp()
 	if err != nil {
@@ -122,9 +126,20 @@ func InitializeTestApp() (*App, error) {
 	msg := ExecuteCommand(cmd)
 	app.Update(msg)
 
+	LoadAllSources(app)
+
 	return app, nil
 }
 
+// LoadAllSources fetches every data source, as if the user had visited each
+// view, since a refresh only loads the current view's data
+func LoadAllSources(app *App) {
+	app.Update(refreshMsg{
+		results: app.fetcher().fetchSources(context.Background(), allSources),
+		epoch:   app.connEpoch,
+	})
+}
+
 // SendWindowSize sends a window size message to the app to initialize viewport
 func SendWindowSize(app *App, width, height int) *App {
 	msg := tea.WindowSizeMsg{Width: width, Height: height}
@@ -200,3 +215,252 @@ func AssertNotNil(t interface{ Errorf(string, ...interface{}) }, value interface
 		t.Errorf("%s: value is nil", message)
 	}
 }
+
+// FakeSource is an in-memory ClusterSource for tests that don't need HTTP.
+// Errors makes the matching data source fail.
+type FakeSource struct {
+	HealthData       *ClusterHealth
+	StatsData        *ClusterStats
+	NodesData        []NodeInfo
+	IndicesData      []IndexInfo
+	ShardsData       []ShardInfo
+	AllocationData   []AllocationInfo
+	ThreadPoolData   []ThreadPoolInfo
+	TasksData        []TaskInfo
+	PendingTasksData []PendingTaskInfo
+	RecoveryData     []RecoveryInfo
+	SegmentsData     []SegmentInfo
+	FielddataData    []FielddataInfo
+	PluginsData      []PluginInfo
+	TemplatesData    []TemplateInfo
+	MappingData      *IndexMapping
+	NodeDetailData   *NodeDetail
+	SettingsData     *IndexSettings
+	IndexStatsData   *IndexStats
+	AliasesData      []IndexAlias
+	ISMData          []ISMExplanation
+	ISMErr           error
+	ExplainData      *AllocationExplanation
+	UnassignedData   *UnassignedReport
+	HotThreadsData   []NodeHotThreads
+	HotThreadsCalls  []HotThreadsOptions // Options of each HotThreads call
+	SnapshotsData    *SnapshotOverview
+	ISMOverviewData  *ISMOverview
+	AliasesOverview  *AliasOverview
+	ComposableData   *TemplateOverview
+	SimulatedData    *SimulatedTemplate
+	IngestData       *IngestOverview
+	PipelineSimData  []SimulatedDoc
+	PipelineSimDocs  []json.RawMessage // Documents of the last SimulatePipeline call
+	ClusterSetData   *ClusterSettings
+	NodeMemoryData   []NodeMemory
+	TopIndicesData   []IndexActivity
+	NodeActivityData []NodeActivity
+	ActivityData     *source.ActivityStats
+	Errors           map[DataSource]error
+}
+
+func (f *FakeSource) ClusterHealth(ctx context.Context) (*ClusterHealth, error) {
+	return f.HealthData, f.Errors[SourceHealth]
+}
+
+func (f *FakeSource) ClusterStats(ctx context.Context) (*ClusterStats, error) {
+	return f.StatsData, f.Errors[SourceStats]
+}
+
+func (f *FakeSource) Nodes(ctx context.Context) ([]NodeInfo, error) {
+	return f.NodesData, f.Errors[SourceNodes]
+}
+
+func (f *FakeSource) Indices(ctx context.Context) ([]IndexInfo, error) {
+	return f.IndicesData, f.Errors[SourceIndices]
+}
+
+func (f *FakeSource) Shards(ctx context.Context) ([]ShardInfo, error) {
+	return f.ShardsData, f.Errors[SourceShards]
+}
+
+func (f *FakeSource) Allocation(ctx context.Context) ([]AllocationInfo, error) {
+	return f.AllocationData, f.Errors[SourceAllocation]
+}
+
+func (f *FakeSource) ThreadPool(ctx context.Context) ([]ThreadPoolInfo, error) {
+	return f.ThreadPoolData, f.Errors[SourceThreadPool]
+}
+
+func (f *FakeSource) Tasks(ctx context.Context) ([]TaskInfo, error) {
+	return f.TasksData, f.Errors[SourceTasks]
+}
+
+func (f *FakeSource) PendingTasks(ctx context.Context) ([]PendingTaskInfo, error) {
+	return f.PendingTasksData, f.Errors[SourcePendingTasks]
+}
+
+func (f *FakeSource) Recovery(ctx context.Context) ([]RecoveryInfo, error) {
+	return f.RecoveryData, f.Errors[SourceRecovery]
+}
+
+func (f *FakeSource) Segments(ctx context.Context) ([]SegmentInfo, error) {
+	return f.SegmentsData, f.Errors[SourceSegments]
+}
+
+func (f *FakeSource) Fielddata(ctx context.Context) ([]FielddataInfo, error) {
+	return f.FielddataData, f.Errors[SourceFielddata]
+}
+
+func (f *FakeSource) Plugins(ctx context.Context) ([]PluginInfo, error) {
+	return f.PluginsData, f.Errors[SourcePlugins]
+}
+
+func (f *FakeSource) Templates(ctx context.Context) ([]TemplateInfo, error) {
+	return f.TemplatesData, f.Errors[SourceTemplates]
+}
+
+func (f *FakeSource) IndexMapping(ctx context.Context, index string) (*IndexMapping, error) {
+	if f.MappingData == nil {
+		return nil, fmt.Errorf("no mapping for %s", index)
+	}
+	return f.MappingData, nil
+}
+
+func (f *FakeSource) NodeDetail(ctx context.Context, node string) (*NodeDetail, error) {
+	if f.NodeDetailData == nil {
+		return nil, fmt.Errorf("no stats for node %s", node)
+	}
+	return f.NodeDetailData, nil
+}
+
+func (f *FakeSource) IndexSettings(ctx context.Context, index string, includeDefaults bool) (*IndexSettings, error) {
+	if f.SettingsData == nil {
+		return nil, fmt.Errorf("no settings for %s", index)
+	}
+	return f.SettingsData, nil
+}
+
+func (f *FakeSource) IndexStats(ctx context.Context, index string) (*IndexStats, error) {
+	if f.IndexStatsData == nil {
+		return nil, fmt.Errorf("no stats for %s", index)
+	}
+	return f.IndexStatsData, nil
+}
+
+func (f *FakeSource) IndexAliases(ctx context.Context, index string) ([]IndexAlias, error) {
+	return f.AliasesData, nil
+}
+
+func (f *FakeSource) ISMExplain(ctx context.Context, index string) ([]ISMExplanation, error) {
+	return f.ISMData, f.ISMErr
+}
+
+func (f *FakeSource) AllocationExplain(ctx context.Context, index string, shard int, primary bool) (*AllocationExplanation, error) {
+	if f.ExplainData == nil {
+		return nil, fmt.Errorf("no explanation for %s[%d]", index, shard)
+	}
+	return f.ExplainData, nil
+}
+
+func (f *FakeSource) ExplainUnassigned(ctx context.Context, limit int) (*UnassignedReport, error) {
+	if f.UnassignedData == nil {
+		return &UnassignedReport{}, f.Errors[SourceUnassigned]
+	}
+	return f.UnassignedData, f.Errors[SourceUnassigned]
+}
+
+func (f *FakeSource) HotThreads(ctx context.Context, opts HotThreadsOptions) ([]NodeHotThreads, error) {
+	f.HotThreadsCalls = append(f.HotThreadsCalls, opts)
+	return f.HotThreadsData, nil
+}
+
+func (f *FakeSource) Snapshots(ctx context.Context) (*SnapshotOverview, error) {
+	if f.SnapshotsData == nil {
+		return &SnapshotOverview{}, f.Errors[SourceSnapshots]
+	}
+	return f.SnapshotsData, f.Errors[SourceSnapshots]
+}
+
+func (f *FakeSource) ISM(ctx context.Context) (*ISMOverview, error) {
+	if f.ISMOverviewData == nil {
+		return &ISMOverview{}, f.Errors[SourceISM]
+	}
+	return f.ISMOverviewData, f.Errors[SourceISM]
+}
+
+func (f *FakeSource) Aliases(ctx context.Context) (*AliasOverview, error) {
+	if f.AliasesOverview == nil {
+		return &AliasOverview{}, f.Errors[SourceAliases]
+	}
+	return f.AliasesOverview, f.Errors[SourceAliases]
+}
+
+func (f *FakeSource) IndexTemplates(ctx context.Context) (*TemplateOverview, error) {
+	if f.ComposableData == nil {
+		return &TemplateOverview{}, f.Errors[SourceIndexTemplates]
+	}
+	return f.ComposableData, f.Errors[SourceIndexTemplates]
+}
+
+func (f *FakeSource) SimulateIndexTemplate(ctx context.Context, name string) (*SimulatedTemplate, error) {
+	if f.SimulatedData == nil {
+		return nil, fmt.Errorf("no simulation for template %s", name)
+	}
+	return f.SimulatedData, nil
+}
+
+func (f *FakeSource) ClusterSettings(ctx context.Context) (*ClusterSettings, error) {
+	if f.ClusterSetData == nil {
+		return &ClusterSettings{}, f.Errors[SourceClusterSettings]
+	}
+	return f.ClusterSetData, f.Errors[SourceClusterSettings]
+}
+
+func (f *FakeSource) Ingest(ctx context.Context) (*IngestOverview, error) {
+	if f.IngestData == nil {
+		return &IngestOverview{}, f.Errors[SourceIngest]
+	}
+	return f.IngestData, f.Errors[SourceIngest]
+}
+
+func (f *FakeSource) SimulatePipeline(ctx context.Context, id string, docs []json.RawMessage) ([]SimulatedDoc, error) {
+	f.PipelineSimDocs = docs
+	if f.PipelineSimData == nil {
+		return nil, fmt.Errorf("no simulation for pipeline %s", id)
+	}
+	return f.PipelineSimData, nil
+}
+
+func (f *FakeSource) NodeMemory(ctx context.Context) ([]NodeMemory, error) {
+	if f.NodeMemoryData == nil {
+		return []NodeMemory{}, f.Errors[SourceMemory]
+	}
+	return f.NodeMemoryData, f.Errors[SourceMemory]
+}
+
+func (f *FakeSource) NodeActivity(ctx context.Context) ([]NodeActivity, error) {
+	if f.NodeActivityData == nil {
+		return []NodeActivity{}, f.Errors[SourceNodeActivity]
+	}
+	return f.NodeActivityData, f.Errors[SourceNodeActivity]
+}
+
+func (f *FakeSource) ActivityStats(ctx context.Context) (*source.ActivityStats, error) {
+	if f.ActivityData == nil {
+		return nil, fmt.Errorf("no activity stats")
+	}
+	return f.ActivityData, nil
+}
+
+func (f *FakeSource) IndexActivity(ctx context.Context, names ...string) ([]IndexActivity, error) {
+	if f.TopIndicesData == nil {
+		return nil, fmt.Errorf("no index activity")
+	}
+	if len(names) == 0 {
+		return f.TopIndicesData, nil
+	}
+	var named []IndexActivity
+	for _, index := range f.TopIndicesData {
+		if slices.Contains(names, index.Index) {
+			named = append(named, index)
+		}
+	}
+	return named, nil
+}
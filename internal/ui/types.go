@@ -1,6 +1,10 @@
 package ui
 
-import "time"
+import (
+	"time"
+
+	"github.com/vegasq/ostop/internal/source"
+)
 
 // View represents different views in the application
 type View int
@@ -22,7 +26,20 @@ const (
 	ViewPlugins
 	ViewTemplates
 	ViewThreadPoolMonitor
-	ViewIndexSchema // Special view accessed via drill-down from Indices
+	ViewHotThreads
+	ViewSnapshots
+	ViewISM
+	ViewAliases
+	ViewIngest
+	ViewSettings
+	ViewMemory
+	ViewTopIndices
+	ViewNodeGraphs
+	ViewIndexSchema    // Special view accessed via drill-down from Indices; tabbed index detail
+	ViewNodeDetail     // Special view accessed via drill-down from Nodes
+	ViewShardExplain   // Special view accessed via drill-down from Shards
+	ViewTemplateDetail // Special view accessed via drill-down from Templates
+	ViewIngestSimulate // Special view accessed via drill-down from Ingest
 )
 
 // Panel represents which panel is active
@@ -33,89 +50,74 @@ const (
 	PanelRight
 )
 
-// ClusterHealth represents the cluster health response
-type ClusterHealth struct {
-	ClusterName         string `json:"cluster_name"`
-	Status              string `json:"status"`
-	TimedOut            bool   `json:"timed_out"`
-	NumberOfNodes       int    `json:"number_of_nodes"`
-	NumberOfDataNodes   int    `json:"number_of_data_nodes"`
-	ActivePrimaryShards int    `json:"active_primary_shards"`
-	ActiveShards        int    `json:"active_shards"`
-	RelocatingShards    int    `json:"relocating_shards"`
-	InitializingShards  int    `json:"initializing_shards"`
-	UnassignedShards    int    `json:"unassigned_shards"`
-}
-
-// ClusterStats represents simplified cluster stats
-type ClusterStats struct {
-	ClusterName string `json:"cluster_name"`
-	Status      string `json:"status"`
-	Indices     struct {
-		Count int `json:"count"`
-		Docs  struct {
-			Count int64 `json:"count"`
-		} `json:"docs"`
-		Store struct {
-			SizeInBytes int64 `json:"size_in_bytes"`
-		} `json:"store"`
-	} `json:"indices"`
-	Nodes struct {
-		Count struct {
-			Total int `json:"total"`
-			Data  int `json:"data"`
-		} `json:"count"`
-	} `json:"nodes"`
-}
-
-// NodeInfo represents a node from CAT nodes API
-type NodeInfo struct {
-	IP              string `json:"ip"`
-	HeapPercent     string `json:"heap.percent"`
-	RAMPercent      string `json:"ram.percent"`
-	CPU             string `json:"cpu"`
-	Load1m          string `json:"load_1m"`
-	Load5m          string `json:"load_5m"`
-	Load15m         string `json:"load_15m"`
-	NodeRole        string `json:"node.role"`
-	Master          string `json:"master"`
-	Name            string `json:"name"`
-	DiskUsedPercent string `json:"disk.used_percent"`
-	DiskUsed        string `json:"disk.used"`
-	DiskAvail       string `json:"disk.avail"`
-	DiskTotal       string `json:"disk.total"`
-}
-
-// IndexInfo represents an index from CAT indices API
-type IndexInfo struct {
-	Health       string `json:"health"`
-	Status       string `json:"status"`
-	Index        string `json:"index"`
-	UUID         string `json:"uuid"`
-	Pri          string `json:"pri"`
-	Rep          string `json:"rep"`
-	DocsCount    string `json:"docs.count"`
-	DocsDeleted  string `json:"docs.deleted"`
-	StoreSize    string `json:"store.size"`
-	PriStoreSize string `json:"pri.store.size"`
-}
-
-// ShardInfo represents a shard from CAT shards API
-type ShardInfo struct {
-	Index  string `json:"index"`
-	Shard  string `json:"shard"`
-	Prirep string `json:"prirep"` // "p" for primary, "r" for replica
-	State  string `json:"state"`  // STARTED, RELOCATING, INITIALIZING, UNASSIGNED
-	Docs   string `json:"docs"`
-	Store  string `json:"store"`
-	IP     string `json:"ip"`
-	Node   string `json:"node"`
-}
-
-// IndexMapping represents the mapping structure for an index
-type IndexMapping struct {
-	IndexName string
-	Mappings  map[string]interface{}
+// Cluster data types come from the source package; aliased so views and
+// tests can keep using the short names
+type (
+	ClusterHealth         = source.ClusterHealth
+	ClusterStats          = source.ClusterStats
+	NodeInfo              = source.NodeInfo
+	IndexInfo             = source.IndexInfo
+	ShardInfo             = source.ShardInfo
+	IndexMapping          = source.IndexMapping
+	AllocationInfo        = source.AllocationInfo
+	ThreadPoolInfo        = source.ThreadPoolInfo
+	TaskInfo              = source.TaskInfo
+	PendingTaskInfo       = source.PendingTaskInfo
+	RecoveryInfo          = source.RecoveryInfo
+	SegmentInfo           = source.SegmentInfo
+	FielddataInfo         = source.FielddataInfo
+	PluginInfo            = source.PluginInfo
+	TemplateInfo          = source.TemplateInfo
+	NodeDetail            = source.NodeDetail
+	IndexSettings         = source.IndexSettings
+	IndexStats            = source.IndexStats
+	IndexAlias            = source.IndexAlias
+	ISMExplanation        = source.ISMExplanation
+	AllocationExplanation = source.AllocationExplanation
+	UnassignedReport      = source.UnassignedReport
+	NodeHotThreads        = source.NodeHotThreads
+	HotThreadsOptions     = source.HotThreadsOptions
+	SnapshotOverview      = source.SnapshotOverview
+	SnapshotInfo          = source.SnapshotInfo
+	ISMOverview           = source.ISMOverview
+	AliasOverview         = source.AliasOverview
+	TemplateOverview      = source.TemplateOverview
+	IndexTemplate         = source.IndexTemplate
+	SimulatedTemplate     = source.SimulatedTemplate
+	IngestOverview        = source.IngestOverview
+	IngestPipeline        = source.IngestPipeline
+	IngestProcessor       = source.IngestProcessor
+	IngestStats           = source.IngestStats
+	SimulatedDoc          = source.SimulatedDoc
+	ClusterSettings       = source.ClusterSettings
+	NodeMemory            = source.NodeMemory
+	CacheMemory           = source.CacheMemory
+	SegmentMemory         = source.SegmentMemory
+	IndexActivity         = source.IndexActivity
+	NodeActivity          = source.NodeActivity
+)
+
+// indexTab is a tab of the index drill-down
+type indexTab int
+
+const (
+	indexTabSchema indexTab = iota
+	indexTabSettings
+	indexTabStats
+	indexTabAliases
+	indexTabPolicy
+	indexTabShards
+)
+
+// indexTabNames are the tab labels, in display order
+var indexTabNames = []string{"Schema", "Settings", "Stats", "Aliases", "Policy", "Shards"}
+
+// String returns the tab's label
+func (t indexTab) String() string {
+	if t < 0 || int(t) >= len(indexTabNames) {
+		return "Unknown"
+	}
+	return indexTabNames[t]
 }
 
 // FieldInfo represents a field in the index mapping
@@ -128,122 +130,33 @@ type FieldInfo struct {
 	Properties map[string]FieldInfo // For nested fields
 }
 
-// AllocationInfo represents node disk allocation from CAT allocation API
-type AllocationInfo struct {
-	Shards      string `json:"shards"`
-	DiskIndices string `json:"disk.indices"`
-	DiskUsed    string `json:"disk.used"`
-	DiskAvail   string `json:"disk.avail"`
-	DiskTotal   string `json:"disk.total"`
-	DiskPercent string `json:"disk.percent"`
-	Host        string `json:"host"`
-	IP          string `json:"ip"`
-	Node        string `json:"node"`
-}
-
-// ThreadPoolInfo represents thread pool statistics from CAT thread_pool API
-type ThreadPoolInfo struct {
-	NodeName  string `json:"node_name"`
-	Name      string `json:"name"`
-	Active    string `json:"active"`
-	Queue     string `json:"queue"`
-	Rejected  string `json:"rejected"`
-	Completed string `json:"completed"`
-	Size      string `json:"size"`
-}
-
-// TaskInfo represents a running task from CAT tasks API
-type TaskInfo struct {
-	Action       string `json:"action"`
-	TaskID       string `json:"task_id"`
-	ParentTaskID string `json:"parent_task_id"`
-	Type         string `json:"type"`
-	StartTime    string `json:"start_time"`
-	Timestamp    string `json:"timestamp"`
-	RunningTime  string `json:"running_time"`
-	IP           string `json:"ip"`
-	Node         string `json:"node"`
-	Description  string `json:"description"`
-}
-
-// PendingTaskInfo represents a pending cluster task from CAT pending_tasks API
-type PendingTaskInfo struct {
-	InsertOrder string `json:"insertOrder"`
-	TimeInQueue string `json:"timeInQueue"`
-	Priority    string `json:"priority"`
-	Source      string `json:"source"`
-}
-
-// RecoveryInfo represents shard recovery information from CAT recovery API
-type RecoveryInfo struct {
-	Index          string `json:"index"`
-	Shard          string `json:"shard"`
-	Time           string `json:"time"`
-	Type           string `json:"type"`
-	Stage          string `json:"stage"`
-	SourceNode     string `json:"source_node"`
-	TargetNode     string `json:"target_node"`
-	Files          string `json:"files"`
-	FilesRecovered string `json:"files_recovered"`
-	FilesPercent   string `json:"files_percent"`
-	Bytes          string `json:"bytes"`
-	BytesRecovered string `json:"bytes_recovered"`
-	BytesPercent   string `json:"bytes_percent"`
-}
-
-// SegmentInfo represents Lucene segment information from CAT segments API
-type SegmentInfo struct {
-	Index       string `json:"index"`
-	Shard       string `json:"shard"`
-	Prirep      string `json:"prirep"`
-	IP          string `json:"ip"`
-	Segment     string `json:"segment"`
-	Generation  string `json:"generation"`
-	DocsCount   string `json:"docs.count"`
-	DocsDeleted string `json:"docs.deleted"`
-	Size        string `json:"size"`
-	Committed   string `json:"committed"`
-}
-
-// FielddataInfo represents fielddata cache usage from CAT fielddata API
-type FielddataInfo struct {
-	ID    string `json:"id"`
-	Host  string `json:"host"`
-	IP    string `json:"ip"`
-	Node  string `json:"node"`
-	Field string `json:"field"`
-	Size  string `json:"size"`
-}
-
-// PluginInfo represents installed plugin information from CAT plugins API
-type PluginInfo struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Component   string `json:"component"`
-	Version     string `json:"version"`
-	Description string `json:"description"`
-}
-
-// TemplateInfo represents index template information from CAT templates API
-type TemplateInfo struct {
-	Name          string `json:"name"`
-	IndexPatterns string `json:"index_patterns"`
-	Order         string `json:"order"`
-	Version       string `json:"version"`
-}
-
 // MetricsSnapshot represents a single point-in-time measurement from cluster stats
 type MetricsSnapshot struct {
-	Timestamp   time.Time
-	IndexTotal  int64 // Cumulative inserts since cluster start
-	SearchTotal int64 // Cumulative searches since cluster start
+	Timestamp         time.Time
+	IndexTotal        int64 // Cumulative inserts since cluster start
+	SearchTotal       int64 // Cumulative searches since cluster start
+	IndexTimeInMillis int64 // Cumulative time spent indexing
+	QueryTimeInMillis int64 // Cumulative time spent in the query phase
+	FetchTotal        int64 // Cumulative fetch phases
+	FetchTimeInMillis int64 // Cumulative time spent in the fetch phase
+	RejectedTotal     int64 // Cumulative thread pool rejections on all nodes
+	RejectedUnknown   bool  // Thread pools couldn't be read, so RejectedTotal is meaningless
+	ScrollCurrent     int64 // Scroll contexts open at this timestamp
+	PITCurrent        int64 // Point-in-time contexts open at this timestamp
 }
 
 // MetricsDataPoint represents a calculated rate over an interval
 type MetricsDataPoint struct {
-	Timestamp  time.Time
-	InsertRate float64 // Inserts per second
-	SearchRate float64 // Searches per second
+	Timestamp       time.Time
+	InsertRate      float64 // Inserts per second
+	SearchRate      float64 // Searches per second
+	IndexLatency    float64 // Average milliseconds per insert over the interval
+	QueryLatency    float64 // Average milliseconds per query phase over the interval
+	FetchLatency    float64 // Average milliseconds per fetch phase over the interval
+	RejectedRate    float64 // Rejected requests per second
+	RejectedUnknown bool    // Either end of the interval lacked thread pool stats
+	ScrollCurrent   float64 // Open scroll contexts
+	PITCurrent      float64 // Open point-in-time contexts
 }
 
 // MetricsTimeSeries manages the rolling window of metrics
@@ -251,6 +164,20 @@ type MetricsTimeSeries struct {
 	DataPoints   []MetricsDataPoint
 	MaxSize      int              // Maximum number of data points to store
 	LastSnapshot *MetricsSnapshot // For delta calculation
+	Window       time.Duration    // Time to keep; zero drops the oldest data point when full
+	Buckets      []MetricsBucket  // Downsampled data points older than DataPoints, oldest first
+}
+
+// MetricsBucket summarises data points pushed out of the full-resolution
+// buffer; each of Min, Avg and Max holds that aggregate of every metric
+type MetricsBucket struct {
+	Start         time.Time
+	End           time.Time
+	Count         int
+	RejectedCount int // Data points with a known rejection rate; RejectedRate aggregates only these
+	Min           MetricsDataPoint
+	Avg           MetricsDataPoint
+	Max           MetricsDataPoint
 }
 
 // MetricsSummary provides aggregate statistics
@@ -285,30 +212,178 @@ type ThreadPoolMetrics struct {
 	RejectionRate float64 // Calculated rejections/second
 }
 
+// MemorySnapshot represents a single point-in-time measurement of every
+// node's breaker trips and cache evictions
+type MemorySnapshot struct {
+	Timestamp time.Time
+	Counters  map[string]int64 // key = memoryCounterKey(node ID, counter)
+}
+
+// MemoryDataPoint represents the counter rates calculated over an interval
+type MemoryDataPoint struct {
+	Timestamp time.Time
+	Rates     map[string]float64 // Per second, keyed like MemorySnapshot.Counters
+}
+
+// IndexActivitySnapshot represents a single point-in-time measurement of
+// every index's indexing and search counters
+type IndexActivitySnapshot struct {
+	Timestamp time.Time
+	Indices   map[string]IndexActivity // key = index name
+}
+
+// IndexActivityDataPoint represents per-index rates calculated over an
+// interval
+type IndexActivityDataPoint struct {
+	Timestamp time.Time
+	Indices   map[string]IndexRates
+}
+
+// IndexRates contains calculated metrics for an index
+type IndexRates struct {
+	IndexRate    float64 // Documents indexed/second
+	QueryRate    float64 // Queries/second
+	IndexLatency float64 // Milliseconds per indexed document over the interval
+	QueryLatency float64 // Milliseconds per query over the interval
+}
+
+// NodeActivitySnapshot represents a single point-in-time measurement of
+// every node's counters and gauges
+type NodeActivitySnapshot struct {
+	Timestamp time.Time
+	Nodes     map[string]NodeActivity // key = node name
+}
+
+// NodeMetricsDataPoint represents per-node metrics calculated over an
+// interval
+type NodeMetricsDataPoint struct {
+	Timestamp time.Time
+	Nodes     map[string]NodeRates
+}
+
+// NodeRates contains calculated metrics for a node
+type NodeRates struct {
+	IndexRate       float64 // Documents indexed/second
+	SearchRate      float64 // Queries/second
+	HeapUsedPercent float64 // Heap used at this timestamp
+	OldGCRate       float64 // Old-gen collections/second
+	OldGCTimeRate   float64 // Milliseconds spent in old-gen GC per second
+	CPUPercent      float64 // CPU use at this timestamp
+}
+
+// DataSource identifies one cluster API polled by the refresh loop
+type DataSource int
+
+const (
+	SourceHealth DataSource = iota
+	SourceStats
+	SourceNodes
+	SourceIndices
+	SourceShards
+	SourceAllocation
+	SourceThreadPool
+	SourceTasks
+	SourcePendingTasks
+	SourceRecovery
+	SourceSegments
+	SourceFielddata
+	SourcePlugins
+	SourceTemplates
+	SourceUnassigned // Allocation explanations for a sample of unassigned shards
+	SourceSnapshots
+	SourceISM
+	SourceAliases
+	SourceIndexTemplates // Composable and component templates
+	SourceIngest
+	SourceClusterSettings
+	SourceMemory // Breakers, caches and segments of every node
+	SourceNodeActivity
+)
+
+// sourceResult is the outcome of fetching a single data source
+type sourceResult struct {
+	data      interface{}
+	err       error
+	fetchedAt time.Time
+}
+
 // refreshMsg is sent when data refresh completes
 type refreshMsg struct {
-	health             *ClusterHealth
-	stats              *ClusterStats
-	nodes              []NodeInfo
-	indices            []IndexInfo
-	shards             []ShardInfo
-	allocation         []AllocationInfo
-	threadPool         []ThreadPoolInfo
-	tasks              []TaskInfo
-	pendingTasks       []PendingTaskInfo
-	recovery           []RecoveryInfo
-	segments           []SegmentInfo
-	fielddata          []FielddataInfo
-	plugins            []PluginInfo
-	templates          []TemplateInfo
-	threadPoolSnapshot *ThreadPoolSnapshot
-	err                error
+	results map[DataSource]sourceResult
+	epoch   int  // Connection epoch the refresh was started in
+	gen     int  // Refresh generation; results from cancelled refreshes are dropped
+	partial bool // Loads data for a newly shown view rather than a full refresh
 }
 
 // mappingMsg is sent when index mapping fetch completes
 type mappingMsg struct {
 	mapping *IndexMapping
 	err     error
+	epoch   int
+	gen     int
+}
+
+// indexTabMsg is sent when data for an index drill-down tab has loaded
+type indexTabMsg struct {
+	index    string // Index the data was requested for
+	tab      indexTab
+	defaults bool // Settings were requested with include_defaults
+	data     interface{}
+	err      error
+	epoch    int
+	gen      int
+}
+
+// shardExplainMsg is sent when an allocation explain for a shard completes
+type shardExplainMsg struct {
+	shard       ShardInfo // Shard copy the explanation was requested for
+	explanation *AllocationExplanation
+	err         error
+	epoch       int
+	gen         int
+}
+
+// templateSimMsg is sent when simulating an index template completes
+type templateSimMsg struct {
+	name      string // Template the simulation was requested for
+	simulated *SimulatedTemplate
+	err       error
+	epoch     int
+	gen       int
+}
+
+// ingestSimMsg is sent when running sample documents through a pipeline
+// completes
+type ingestSimMsg struct {
+	pipeline string // Pipeline the simulation was requested for
+	docs     []SimulatedDoc
+	err      error
+	epoch    int
+	gen      int
+}
+
+// hotThreadsMsg is sent when a hot threads sample completes
+type hotThreadsMsg struct {
+	opts  HotThreadsOptions // Options the sample was taken with
+	nodes []NodeHotThreads
+	at    time.Time
+	err   error
+	epoch int
+	gen   int
+}
+
+// nodeDetailMsg is sent when a node drill-down fetch completes
+type nodeDetailMsg struct {
+	node   string // Node the detail was requested for
+	detail *NodeDetail
+	err    error
+	epoch  int
+	gen    int
+}
+
+// autoRefreshTickMsg drives the global auto-refresh countdown
+type autoRefreshTickMsg struct {
+	timestamp time.Time
 }
 
 // metricsTickMsg triggers periodic metrics refresh
@@ -320,6 +395,27 @@ type metricsTickMsg struct {
 type metricsRefreshMsg struct {
 	snapshot *MetricsSnapshot
 	err      error
+	epoch    int
+}
+
+// topIndicesTickMsg triggers periodic per-index activity refresh
+type topIndicesTickMsg struct {
+	timestamp time.Time
+}
+
+// topIndicesRefreshMsg carries fetched per-index activity
+type topIndicesRefreshMsg struct {
+	snapshot *IndexActivitySnapshot
+	err      error
+	epoch    int
+}
+
+// pinnedIndicesRefreshMsg carries the activity of indices pinned to Live
+// Metrics
+type pinnedIndicesRefreshMsg struct {
+	snapshot *IndexActivitySnapshot
+	err      error
+	epoch    int
 }
 
 // threadPoolTickMsg triggers periodic thread pool refresh
@@ -331,4 +427,12 @@ type threadPoolTickMsg struct {
 type threadPoolRefreshMsg struct {
 	snapshot *ThreadPoolSnapshot
 	err      error
+	epoch    int
+}
+
+// clusterSwitchMsg carries the result of connecting to another cluster
+type clusterSwitchMsg struct {
+	conn      *Connection
+	err       error
+	connectID int
 }
@@ -12,29 +12,39 @@ func (a *App) stylePanel(content string, panel Panel) string {
 	return inactivePanelStyle.Render(content)
 }
 
+// menuItems are the navigation menu's entries; an item's position is its View
+var menuItems = []string{
+	"Cluster Overview",
+	"Nodes",
+	"Indices",
+	"Shards",
+	"Resources",
+	"Live Metrics",
+	"Allocation",
+	"Thread Pools",
+	"Tasks",
+	"Pending Tasks",
+	"Recovery",
+	"Segments",
+	"Fielddata",
+	"Plugins",
+	"Templates",
+	"Thread Pool Monitor",
+	"Hot Threads",
+	"Snapshots",
+	"Index Management",
+	"Aliases & Data Streams",
+	"Ingest Pipelines",
+	"Cluster Settings",
+	"Memory Pressure",
+	"Top Indices",
+	"Node Graphs",
+}
+
 // renderLeftPanel renders the navigation menu
 func (a *App) renderLeftPanel() string {
 	var b strings.Builder
 
-	menuItems := []string{
-		"Cluster Overview",
-		"Nodes",
-		"Indices",
-		"Shards",
-		"Resources",
-		"Live Metrics",
-		"Allocation",
-		"Thread Pools",
-		"Tasks",
-		"Pending Tasks",
-		"Recovery",
-		"Segments",
-		"Fielddata",
-		"Plugins",
-		"Templates",
-		"Thread Pool Monitor",
-	}
-
 	for i, item := range menuItems {
 		if i == a.selectedItem {
 			b.WriteString(selectedMenuItemStyle.Render("▶ " + item))
@@ -64,6 +74,14 @@ func (a *App) renderRightPanel() string {
 		return a.renderMetricsView()
 	case ViewIndexSchema:
 		return a.renderIndexSchemaView()
+	case ViewNodeDetail:
+		return a.renderNodeDetailView()
+	case ViewTemplateDetail:
+		return a.renderTemplateDetailView()
+	case ViewIngestSimulate:
+		return a.renderIngestSimulateView()
+	case ViewShardExplain:
+		return a.renderShardExplainView()
 	case ViewAllocation:
 		return a.renderAllocationView()
 	case ViewThreadPool:
@@ -84,6 +102,24 @@ func (a *App) renderRightPanel() string {
 		return a.renderTemplatesView()
 	case ViewThreadPoolMonitor:
 		return a.renderThreadPoolMonitorView()
+	case ViewHotThreads:
+		return a.renderHotThreadsView()
+	case ViewSnapshots:
+		return a.renderSnapshotsView()
+	case ViewISM:
+		return a.renderISMView()
+	case ViewAliases:
+		return a.renderAliasesView()
+	case ViewIngest:
+		return a.renderIngestView()
+	case ViewSettings:
+		return a.renderClusterSettingsView()
+	case ViewMemory:
+		return a.renderMemoryView()
+	case ViewTopIndices:
+		return a.renderTopIndicesView()
+	case ViewNodeGraphs:
+		return a.renderNodeGraphsView()
 	default:
 		return "Unknown view"
 	}
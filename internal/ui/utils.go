@@ -2,7 +2,9 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 )
 
 // renderBar creates an ASCII progress bar with color thresholds
@@ -30,6 +32,16 @@ func renderBar(percentStr string, width int) string {
 	return statusGreen.Render(bar)
 }
 
+// renderFailureCount pads a count of failures, rejections or trips to width
+// and colours it red when it's non-zero
+func renderFailureCount(count int64, width int) string {
+	text := fmt.Sprintf("%*d", width, count)
+	if count > 0 {
+		return statusRed.Render(text)
+	}
+	return text
+}
+
 // formatBytes converts bytes to human-readable format
 func formatBytes(bytes int64) string {
 	const unit = 1024
@@ -137,3 +149,46 @@ func simplifyAction(action string) string {
 	}
 	return "Other"
 }
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// averageMillis formats total time divided by operation count
+func averageMillis(totalMillis, count int64) string {
+	if count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2fms", float64(totalMillis)/float64(count))
+}
+
+// formatMillis formats a cumulative time in milliseconds, e.g. "1m23.4s"
+func formatMillis(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	if d >= time.Second {
+		d = d.Round(100 * time.Millisecond)
+	}
+	return d.String()
+}
+
+// formatUptime formats a JVM uptime as days, hours and minutes
+func formatUptime(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	}
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
@@ -220,3 +220,47 @@ func TestRenderBar_ColorCoding(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatUptime(t *testing.T) {
+	tests := []struct {
+		ms   int64
+		want string
+	}{
+		{0, "0m"},
+		{90 * 1000, "1m"},
+		{(2*60 + 5) * 60 * 1000, "2h 5m"},
+		{((3*24+4)*60 + 2) * 60 * 1000, "3d 4h 2m"},
+	}
+
+	for _, tt := range tests {
+		if got := formatUptime(tt.ms); got != tt.want {
+			t.Errorf("formatUptime(%d) = %q, want %q", tt.ms, got, tt.want)
+		}
+	}
+}
+
+func TestFormatMillis(t *testing.T) {
+	tests := []struct {
+		ms   int64
+		want string
+	}{
+		{250, "250ms"},
+		{45600, "45.6s"},
+		{83456, "1m23.5s"},
+	}
+
+	for _, tt := range tests {
+		if got := formatMillis(tt.ms); got != tt.want {
+			t.Errorf("formatMillis(%d) = %q, want %q", tt.ms, got, tt.want)
+		}
+	}
+}
+
+func TestRenderFailureCount(t *testing.T) {
+	if got := renderFailureCount(0, 4); got != "   0" {
+		t.Errorf("renderFailureCount(0, 4) = %q, want %q", got, "   0")
+	}
+	if got := renderFailureCount(12, 4); !strings.Contains(got, "  12") {
+		t.Errorf("renderFailureCount(12, 4) = %q, want the padded count", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/vegasq/ostop/internal/check"
 )
 
 // renderAllocationView renders the disk allocation view
@@ -45,21 +46,22 @@ func (a *App) renderAllocationView() string {
 	criticalCount := 0
 	warningCount := 0
 	for _, nwp := range nodesWithPercent {
-		if nwp.percent >= 90 {
+		switch check.DiskStatus(nwp.percent) {
+		case check.Critical:
 			criticalCount++
-		} else if nwp.percent >= 75 {
+		case check.Warning:
 			warningCount++
 		}
 	}
 
 	// Show warnings if any nodes in danger zone
 	if criticalCount > 0 {
-		b.WriteString(statusRed.Render(fmt.Sprintf("⚠ CRITICAL: %d node(s) at ≥90%% disk usage", criticalCount)))
+		b.WriteString(statusRed.Render(fmt.Sprintf("⚠ CRITICAL: %d node(s) at ≥%.0f%% disk usage", criticalCount, check.DiskCriticalPercent)))
 		b.WriteString("\n")
 		b.WriteString(labelStyle.Render("Immediate action required: Add storage or delete data"))
 		b.WriteString("\n\n")
 	} else if warningCount > 0 {
-		b.WriteString(statusYellow.Render(fmt.Sprintf("⚠ WARNING: %d node(s) at ≥75%% disk usage", warningCount)))
+		b.WriteString(statusYellow.Render(fmt.Sprintf("⚠ WARNING: %d node(s) at ≥%.0f%% disk usage", warningCount, check.DiskWarningPercent)))
 		b.WriteString("\n")
 		b.WriteString(labelStyle.Render("Plan for capacity expansion"))
 		b.WriteString("\n\n")
@@ -74,11 +76,12 @@ func (a *App) renderAllocationView() string {
 		percent := nwp.percent
 
 		var nodeNameStyle lipgloss.Style
-		if percent >= 90 {
+		switch check.DiskStatus(percent) {
+		case check.Critical:
 			nodeNameStyle = statusRed
-		} else if percent >= 75 {
+		case check.Warning:
 			nodeNameStyle = statusYellow
-		} else {
+		default:
 			nodeNameStyle = statusGreen
 		}
 
@@ -444,14 +447,28 @@ func (a *App) renderPluginsView() string {
 func (a *App) renderTemplatesView() string {
 	var b strings.Builder
 
-	b.WriteString(headerStyle.Render(fmt.Sprintf("Index Templates (%d)", len(a.templates))))
+	composable := a.composableTemplates()
+	legacy := a.legacyTemplates()
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Index Templates (%d)", len(composable)+len(legacy))))
 	b.WriteString("\n\n")
 
-	if len(a.templates) == 0 {
+	if len(composable) == 0 && len(legacy) == 0 && (a.indexTemplates == nil || len(a.indexTemplates.ComponentTemplates) == 0) {
 		b.WriteString(labelStyle.Render("No index templates defined"))
 		return b.String()
 	}
 
+	renderTemplateConflicts(&b, findTemplateConflicts(composable, legacy))
+	if a.indexTemplates != nil {
+		a.renderComposableTemplates(&b, composable)
+		a.renderComponentTemplates(&b)
+	}
+	if len(legacy) == 0 {
+		return b.String()
+	}
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Legacy Templates (%d)", len(legacy))))
+	b.WriteString("\n")
+
 	// Sort by order (higher order = higher precedence)
 	type templateWithOrder struct {
 		template TemplateInfo
@@ -459,7 +476,7 @@ func (a *App) renderTemplatesView() string {
 	}
 
 	var templatesWithOrder []templateWithOrder
-	for _, template := range a.templates {
+	for _, template := range legacy {
 		var order int
 		fmt.Sscanf(template.Order, "%d", &order)
 		templatesWithOrder = append(templatesWithOrder, templateWithOrder{template: template, order: order})
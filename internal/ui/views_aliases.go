@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// aliasTargets returns the indices the Aliases view's cursor moves over, in
+// the order they're rendered
+func (a *App) aliasTargets() []string {
+	if a.aliases == nil {
+		return nil
+	}
+	var targets []string
+	for _, stream := range a.aliases.DataStreams {
+		targets = append(targets, stream.Indices...)
+	}
+	for _, alias := range a.aliases.Aliases {
+		targets = append(targets, alias.Indices...)
+	}
+	return targets
+}
+
+// selectedAliasTarget returns the index under the Aliases view cursor
+func (a *App) selectedAliasTarget() (string, bool) {
+	targets := a.aliasTargets()
+	if len(targets) == 0 {
+		return "", false
+	}
+	if a.selectedAlias < 0 || a.selectedAlias >= len(targets) {
+		a.selectedAlias = 0
+	}
+	return targets[a.selectedAlias], true
+}
+
+// renderAliasesView renders data streams and aliases with the indices behind
+// them
+func (a *App) renderAliasesView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render("Aliases & Data Streams"))
+	b.WriteString("\n\n")
+
+	if a.aliases == nil {
+		b.WriteString(labelStyle.Render("No alias data available"))
+		return b.String()
+	}
+
+	if len(a.aliasTargets()) > 0 {
+		b.WriteString(helpStyle.Render("Press Enter to open the selected index"))
+		b.WriteString("\n\n")
+	}
+	a.selectedAliasTarget() // Clamps the cursor
+
+	// Cursor positions run through data streams, then aliases
+	position := 0
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Data Streams (%d)", len(a.aliases.DataStreams))))
+	b.WriteString("\n\n")
+	if len(a.aliases.DataStreams) == 0 {
+		b.WriteString(labelStyle.Render("No data streams"))
+		b.WriteString("\n\n")
+	}
+	for _, stream := range a.aliases.DataStreams {
+		a.renderDataStream(&b, stream, position)
+		position += len(stream.Indices)
+	}
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Aliases (%d)", len(a.aliases.Aliases))))
+	b.WriteString("\n\n")
+	if len(a.aliases.Aliases) == 0 {
+		b.WriteString(labelStyle.Render("No aliases"))
+		b.WriteString("\n")
+	}
+	for _, alias := range a.aliases.Aliases {
+		a.renderAliasGroup(&b, alias, position)
+		position += len(alias.Indices)
+	}
+
+	return b.String()
+}
+
+// renderDataStream renders a data stream and its backing indices; first is
+// the cursor position of its oldest backing index
+func (a *App) renderDataStream(b *strings.Builder, stream source.DataStream, first int) {
+	b.WriteString(fmt.Sprintf("%s %s\n", valueStyle.Render(stream.Name), renderDataStreamStatus(stream.Status)))
+	b.WriteString(fmt.Sprintf("  %s %d  %s %s  %s %s\n",
+		labelStyle.Render("Generation:"), stream.Generation,
+		labelStyle.Render("Size:"), formatBytes(stream.StoreSizeBytes),
+		labelStyle.Render("Template:"), orDash(stream.Template)))
+	b.WriteString(fmt.Sprintf("  %s %s  %s %s\n",
+		labelStyle.Render("Write index:"), orDash(stream.WriteIndex()),
+		labelStyle.Render("Timestamp field:"), orDash(stream.TimestampField)))
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  Backing indices (%d)", len(stream.Indices))))
+	b.WriteString("\n")
+	for i, index := range stream.Indices {
+		a.renderAliasTarget(b, index, first+i, index == stream.WriteIndex())
+	}
+	b.WriteString("\n")
+}
+
+// renderAliasGroup renders an alias and the indices it points at; first is
+// the cursor position of its first index
+func (a *App) renderAliasGroup(b *strings.Builder, alias source.AliasGroup, first int) {
+	b.WriteString(valueStyle.Render(alias.Name))
+	b.WriteString(labelStyle.Render(fmt.Sprintf(" (%d indices)", len(alias.Indices))))
+	if alias.Filtered {
+		b.WriteString(statusYellow.Render(" filtered"))
+	}
+	b.WriteString("\n")
+	if alias.WriteIndex == "" && len(alias.Indices) > 1 {
+		b.WriteString(labelStyle.Render("  No write index; writes to this alias are rejected"))
+		b.WriteString("\n")
+	}
+	for i, index := range alias.Indices {
+		a.renderAliasTarget(b, index, first+i, index == alias.WriteIndex)
+	}
+	b.WriteString("\n")
+}
+
+// renderAliasTarget renders one index line, with the cursor when selected
+func (a *App) renderAliasTarget(b *strings.Builder, index string, position int, write bool) {
+	cursor := "    "
+	if position == a.selectedAlias {
+		cursor = "  " + statusGreen.Render("▶ ")
+	}
+	b.WriteString(cursor + index)
+	if write {
+		b.WriteString(statusGreen.Render(" (write)"))
+	}
+	b.WriteString("\n")
+}
+
+// renderDataStreamStatus colours a data stream's health
+func renderDataStreamStatus(status string) string {
+	switch strings.ToLower(status) {
+	case "green":
+		return statusGreen.Render(status)
+	case "yellow":
+		return statusYellow.Render(status)
+	case "red":
+		return statusRed.Render(status)
+	default:
+		return labelStyle.Render(orDash(status))
+	}
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+func TestRenderAliasesView(t *testing.T) {
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	overview, err := source.NewOpenSearch(client).Aliases(t.Context())
+	if err != nil {
+		t.Fatalf("Aliases() error = %v", err)
+	}
+
+	app := &App{aliases: overview}
+	result := app.renderAliasesView()
+
+	expected := []string{
+		"Data Streams (1)",
+		"logs-app",
+		"GREEN",
+		"Generation: 2",
+		"1.0 GB",
+		"logs-template",
+		"Write index: .ds-logs-app-000002",
+		"Backing indices (2)",
+		"▶ .ds-logs-app-000001",
+		"Aliases (3)",
+		"logs-all",
+		"No write index",
+		"logs-errors",
+		"filtered",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderAliasesView() should contain %q", want)
+		}
+	}
+}
+
+func TestAliasTargets(t *testing.T) {
+	app := &App{aliases: &AliasOverview{
+		DataStreams: []source.DataStream{{Name: "ds", Indices: []string{".ds-1", ".ds-2"}}},
+		Aliases:     []source.AliasGroup{{Name: "a", Indices: []string{"i1"}, WriteIndex: "i1"}},
+	}}
+
+	targets := app.aliasTargets()
+	if strings.Join(targets, ",") != ".ds-1,.ds-2,i1" {
+		t.Errorf("aliasTargets() = %v, want data stream indices before alias indices", targets)
+	}
+
+	// An out of range cursor is clamped
+	app.selectedAlias = 7
+	if index, ok := app.selectedAliasTarget(); !ok || index != ".ds-1" {
+		t.Errorf("selectedAliasTarget() = %q, %v", index, ok)
+	}
+
+	app.selectedAlias = 2
+	if result := app.renderAliasesView(); !strings.Contains(result, "▶ i1") {
+		t.Errorf("cursor should be on the alias index, got %q", result)
+	}
+
+	empty := &App{aliases: &AliasOverview{}}
+	if _, ok := empty.selectedAliasTarget(); ok {
+		t.Error("selectedAliasTarget() should fail without indices")
+	}
+	if result := empty.renderAliasesView(); !strings.Contains(result, "No data streams") || !strings.Contains(result, "No aliases") {
+		t.Errorf("empty overview = %q", result)
+	}
+}
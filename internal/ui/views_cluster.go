@@ -80,6 +80,8 @@ func (a *App) renderNodesView() string {
 	var b strings.Builder
 
 	b.WriteString(headerStyle.Render(fmt.Sprintf("Nodes (%d)", len(a.nodes))))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Press Enter to view node details"))
 	b.WriteString("\n\n")
 
 	if len(a.nodes) == 0 {
@@ -88,22 +90,7 @@ func (a *App) renderNodesView() string {
 	}
 
 	// Categorize nodes
-	var masterNodes []NodeInfo
-	var dataNodes []NodeInfo
-	var otherNodes []NodeInfo
-
-	for _, node := range a.nodes {
-		isMaster := strings.Contains(node.NodeRole, "m")
-		isData := strings.Contains(node.NodeRole, "d")
-
-		if isMaster && !isData {
-			masterNodes = append(masterNodes, node)
-		} else if isData {
-			dataNodes = append(dataNodes, node)
-		} else {
-			otherNodes = append(otherNodes, node)
-		}
-	}
+	masterNodes, dataNodes, otherNodes := groupNodes(a.nodes)
 
 	// Node type summary
 	b.WriteString(headerStyle.Render("Node Types"))
@@ -148,6 +135,39 @@ func (a *App) renderNodesView() string {
 	return b.String()
 }
 
+// nodeBlockLines is roughly how many lines renderNode takes per node, used
+// to scroll the viewport along with the selection
+const nodeBlockLines = 8
+
+// groupNodes splits nodes into the dedicated master, data and other sections
+// shown by the Nodes view
+func groupNodes(nodes []NodeInfo) (masterNodes, dataNodes, otherNodes []NodeInfo) {
+	for _, node := range nodes {
+		isMaster := strings.Contains(node.NodeRole, "m")
+		isData := strings.Contains(node.NodeRole, "d")
+
+		if isMaster && !isData {
+			masterNodes = append(masterNodes, node)
+		} else if isData {
+			dataNodes = append(dataNodes, node)
+		} else {
+			otherNodes = append(otherNodes, node)
+		}
+	}
+	return masterNodes, dataNodes, otherNodes
+}
+
+// selectedNodeInfo returns the node under the cursor. The cursor follows the
+// on-screen order, which groups nodes by role.
+func (a *App) selectedNodeInfo() (NodeInfo, bool) {
+	masterNodes, dataNodes, otherNodes := groupNodes(a.nodes)
+	ordered := append(append(masterNodes, dataNodes...), otherNodes...)
+	if a.selectedNode < 0 || a.selectedNode >= len(ordered) {
+		return NodeInfo{}, false
+	}
+	return ordered[a.selectedNode], true
+}
+
 // renderNode renders a single node's details
 func (a *App) renderNode(b *strings.Builder, node NodeInfo) {
 	var nodeStr strings.Builder
@@ -156,7 +176,12 @@ func (a *App) renderNode(b *strings.Builder, node NodeInfo) {
 	nodeType := a.getNodeTypeLabel(node.NodeRole)
 	isMaster := node.Master == "*"
 
-	nodeStr.WriteString("  ")
+	// Show selection indicator
+	if selected, ok := a.selectedNodeInfo(); ok && selected.Name == node.Name {
+		nodeStr.WriteString(statusGreen.Render("▶ "))
+	} else {
+		nodeStr.WriteString("  ")
+	}
 	if isMaster {
 		nodeStr.WriteString(statusGreen.Render("★ "))
 	} else {
@@ -198,10 +223,34 @@ func (a *App) renderNode(b *strings.Builder, node NodeInfo) {
 			node.DiskTotal))
 	}
 
+	a.renderNodeTrend(&nodeStr, node.Name)
+
 	nodeStr.WriteString("\n")
 	b.WriteString(nodeStr.String())
 }
 
+// renderNodeTrend renders sparklines of a node's indexing rate, search rate
+// and heap over the last refreshes, once there are any
+func (a *App) renderNodeTrend(b *strings.Builder, name string) {
+	if a.nodeTimeSeries == nil {
+		return
+	}
+	rates, ok := a.nodeTimeSeries.Latest(name)
+	if !ok {
+		return
+	}
+	b.WriteString(fmt.Sprintf("      %s %s %s  %s %s %s  %s %s %s\n",
+		labelStyle.Render("Index:"),
+		renderSparkline(a.nodeTimeSeries.Series(name, nodeIndexRate), a.nodeTimeSeries.maxDataPoints),
+		valueStyle.Render(formatMetricNumber(rates.IndexRate)+"/s"),
+		labelStyle.Render("Search:"),
+		renderSparkline(a.nodeTimeSeries.Series(name, nodeSearchRate), a.nodeTimeSeries.maxDataPoints),
+		valueStyle.Render(formatMetricNumber(rates.SearchRate)+"/s"),
+		labelStyle.Render("Heap:"),
+		renderSparkline(a.nodeTimeSeries.Series(name, nodeHeapUsed), a.nodeTimeSeries.maxDataPoints),
+		valueStyle.Render(fmt.Sprintf("%.0f%%", rates.HeapUsedPercent))))
+}
+
 // getNodeTypeLabel returns a human-readable label for node type
 func (a *App) getNodeTypeLabel(role string) string {
 	types := []string{}
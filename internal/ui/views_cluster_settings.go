@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// deprecatedSettings maps deprecated cluster settings to what replaced them.
+// Keys ending in a dot cover every setting under that prefix; a replacement
+// ending in a dot keeps the rest of the key.
+var deprecatedSettings = map[string]string{
+	"cluster.initial_master_nodes":                       "cluster.initial_cluster_manager_nodes",
+	"cluster.no_master_block":                            "cluster.no_cluster_manager_block",
+	"cluster.service.slow_master_task_logging_threshold": "cluster.service.slow_cluster_manager_task_logging_threshold",
+	"cluster.remote.connect":                             "node.remote_cluster_client",
+	"discovery.zen.":                                     "",
+	"http.tcp_no_delay":                                  "http.tcp.no_delay",
+	"node.data":                                          "node.roles",
+	"node.ingest":                                        "node.roles",
+	"node.master":                                        "node.roles",
+	"opendistro.":                                        "plugins.",
+	"transport.tcp.compress":                             "transport.compress",
+	"transport.tcp.connect_timeout":                      "transport.connect_timeout",
+	"transport.tcp.port":                                 "transport.port",
+}
+
+// deprecatedSetting reports whether a setting is deprecated and what
+// replaced it, if anything
+func deprecatedSetting(key string) (string, bool) {
+	if replacement, ok := deprecatedSettings[key]; ok {
+		return replacement, true
+	}
+	for prefix, replacement := range deprecatedSettings {
+		if !strings.HasSuffix(prefix, ".") || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if strings.HasSuffix(replacement, ".") {
+			return replacement + strings.TrimPrefix(key, prefix), true
+		}
+		return replacement, true
+	}
+	return "", false
+}
+
+// effectiveSetting returns the value of a setting the cluster uses and where
+// it's set: transient overrides persistent, which overrides the default
+func effectiveSetting(settings *ClusterSettings, key string) (string, string) {
+	if value, ok := settings.Transient[key]; ok {
+		return value, "transient"
+	}
+	if value, ok := settings.Persistent[key]; ok {
+		return value, "persistent"
+	}
+	return settings.Defaults[key], "default"
+}
+
+// settingChanged reports whether a setting's value in use differs from its
+// default
+func settingChanged(settings *ClusterSettings, key string) bool {
+	value, scope := effectiveSetting(settings, key)
+	if scope == "default" {
+		return false
+	}
+	def, ok := settings.Defaults[key]
+	return !ok || def != value
+}
+
+// clusterSettingKeys returns the settings shown, honouring the filter and
+// the changed-only toggle
+func (a *App) clusterSettingKeys() []string {
+	if a.clusterSettings == nil {
+		return nil
+	}
+
+	all := make(map[string]bool)
+	for _, values := range []map[string]string{a.clusterSettings.Defaults, a.clusterSettings.Persistent, a.clusterSettings.Transient} {
+		for key := range values {
+			all[key] = true
+		}
+	}
+
+	filter := strings.ToLower(strings.TrimSpace(a.settingsFilter.Value()))
+	var keys []string
+	for key := range all {
+		if a.settingsChanged && !settingChanged(a.clusterSettings, key) {
+			continue
+		}
+		if filter != "" {
+			value, _ := effectiveSetting(a.clusterSettings, key)
+			if !strings.Contains(strings.ToLower(key), filter) && !strings.Contains(strings.ToLower(value), filter) {
+				continue
+			}
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// startSettingsFilter focuses the filter input, keeping the current filter
+func (a *App) startSettingsFilter() {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "filter by key or value"
+	input.SetValue(a.settingsFilter.Value())
+	input.Cursor.SetMode(cursor.CursorStatic) // No blink messages to route
+	input.Focus()
+	a.settingsFilter = input
+	a.settingsFiltering = true
+	a.updateViewportContent()
+}
+
+// clearSettingsFilter drops the filter and takes focus from its input
+func (a *App) clearSettingsFilter() {
+	a.settingsFilter = textinput.Model{}
+	a.settingsFiltering = false
+}
+
+// handleSettingsFilterKey edits the filter while it has focus; Enter keeps
+// it and Esc clears it
+func (a *App) handleSettingsFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return a, tea.Quit
+
+	case "enter":
+		a.settingsFilter.Blur()
+		a.settingsFiltering = false
+
+	case "esc":
+		a.clearSettingsFilter()
+
+	default:
+		var cmd tea.Cmd
+		a.settingsFilter, cmd = a.settingsFilter.Update(msg)
+		a.updateViewportContent()
+		if a.viewportReady {
+			a.viewport.GotoTop()
+		}
+		return a, cmd
+	}
+
+	a.updateViewportContent()
+	if a.viewportReady {
+		a.viewport.GotoTop()
+	}
+	return a, nil
+}
+
+// renderClusterSettingsView renders the cluster settings as a tree keyed by
+// their dotted names
+func (a *App) renderClusterSettingsView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render("Cluster Settings"))
+	b.WriteString("\n\n")
+
+	if a.clusterSettings == nil {
+		b.WriteString(labelStyle.Render("No cluster settings available"))
+		return b.String()
+	}
+	a.renderClusterSettingsSummary(&b)
+
+	if a.settingsFiltering {
+		b.WriteString(a.settingsFilter.View())
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("Enter: Apply filter | Esc: Clear filter"))
+	} else {
+		help := "/: Filter | C: Show changed settings only"
+		if a.settingsChanged {
+			help = "/: Filter | C: Show all settings"
+		}
+		if filter := a.settingsFilter.Value(); filter != "" {
+			b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Filter:"), valueStyle.Render(filter)))
+		}
+		b.WriteString(helpStyle.Render(help))
+	}
+	b.WriteString("\n\n")
+
+	keys := a.clusterSettingKeys()
+	if len(keys) == 0 {
+		b.WriteString(labelStyle.Render("No settings match"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	b.WriteString(labelStyle.Render("Highlighted values differ from the default; transient settings are lost on a full cluster restart"))
+	b.WriteString("\n\n")
+
+	var previous []string
+	for _, key := range keys {
+		parts := strings.Split(key, ".")
+		common := 0
+		for common < len(previous) && common < len(parts)-1 && previous[common] == parts[common] {
+			common++
+		}
+		// Open the groups this setting doesn't share with the previous one
+		for depth := common; depth < len(parts)-1; depth++ {
+			b.WriteString(strings.Repeat("  ", depth))
+			b.WriteString(metricHeaderStyle.Render(parts[depth]))
+			b.WriteString("\n")
+		}
+		b.WriteString(strings.Repeat("  ", len(parts)-1))
+		b.WriteString(a.renderClusterSetting(key, parts[len(parts)-1]))
+		b.WriteString("\n")
+		previous = parts[:len(parts)-1]
+	}
+
+	return b.String()
+}
+
+// renderClusterSettingsSummary counts where settings are set and flags
+// transient and deprecated ones
+func (a *App) renderClusterSettingsSummary(b *strings.Builder) {
+	settings := a.clusterSettings
+
+	set := make(map[string]bool)
+	for key := range settings.Persistent {
+		set[key] = true
+	}
+	for key := range settings.Transient {
+		set[key] = true
+	}
+
+	changed, deprecated := 0, 0
+	for key := range set {
+		if settingChanged(settings, key) {
+			changed++
+		}
+		if _, ok := deprecatedSetting(key); ok {
+			deprecated++
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("%s %d  %s %d  %s %d  %s %d\n",
+		labelStyle.Render("Persistent:"), len(settings.Persistent),
+		labelStyle.Render("Transient:"), len(settings.Transient),
+		labelStyle.Render("Defaults:"), len(settings.Defaults),
+		labelStyle.Render("Changed from default:"), changed))
+	if len(settings.Transient) > 0 {
+		b.WriteString(statusYellow.Render(fmt.Sprintf("⚠ %d transient settings will be lost on a full cluster restart", len(settings.Transient))))
+		b.WriteString("\n")
+	}
+	if deprecated > 0 {
+		b.WriteString(statusRed.Render(fmt.Sprintf("⚠ %d deprecated settings are set", deprecated)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// renderClusterSetting renders one setting's value in use, where it's set,
+// its default when overridden and whether it's deprecated
+func (a *App) renderClusterSetting(key, name string) string {
+	settings := a.clusterSettings
+	value, scope := effectiveSetting(settings, key)
+
+	line := fmt.Sprintf("%s = ", name)
+	if settingChanged(settings, key) {
+		line += statusYellow.Render(orDash(value))
+	} else if scope == "default" {
+		line += labelStyle.Render(orDash(value))
+	} else {
+		line += valueStyle.Render(orDash(value))
+	}
+
+	switch scope {
+	case "transient":
+		line += " " + statusYellow.Render("[transient]")
+		if persistent, ok := settings.Persistent[key]; ok {
+			line += labelStyle.Render(fmt.Sprintf(" (persistent: %s)", orDash(persistent)))
+		}
+	case "persistent":
+		line += " " + valueStyle.Render("[persistent]")
+	}
+	if def, ok := settings.Defaults[key]; ok && settingChanged(settings, key) {
+		line += labelStyle.Render(fmt.Sprintf(" (default: %s)", orDash(def)))
+	}
+
+	if replacement, ok := deprecatedSetting(key); ok {
+		text := "[deprecated]"
+		if replacement != "" {
+			text = fmt.Sprintf("[deprecated, use %s]", replacement)
+		}
+		line += " " + statusRed.Render(text)
+	}
+	return line
+}
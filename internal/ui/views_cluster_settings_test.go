@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+func TestDeprecatedSetting(t *testing.T) {
+	tests := []struct {
+		key         string
+		deprecated  bool
+		replacement string
+	}{
+		{"cluster.no_master_block", true, "cluster.no_cluster_manager_block"},
+		{"opendistro.index_state_management.enabled", true, "plugins.index_state_management.enabled"},
+		{"discovery.zen.minimum_master_nodes", true, ""},
+		{"cluster.no_cluster_manager_block", false, ""},
+		{"cluster.routing.allocation.enable", false, ""},
+	}
+
+	for _, tt := range tests {
+		replacement, deprecated := deprecatedSetting(tt.key)
+		if deprecated != tt.deprecated || replacement != tt.replacement {
+			t.Errorf("deprecatedSetting(%q) = %q, %v", tt.key, replacement, deprecated)
+		}
+	}
+}
+
+func TestSettingChanged(t *testing.T) {
+	settings := &ClusterSettings{
+		Persistent: map[string]string{"a": "1", "b": "2", "custom": "x"},
+		Transient:  map[string]string{"a": "3"},
+		Defaults:   map[string]string{"a": "1", "b": "2", "c": "4"},
+	}
+
+	tests := []struct {
+		key   string
+		value string
+		scope string
+		want  bool
+	}{
+		{"a", "3", "transient", true},   // Transient overrides persistent
+		{"b", "2", "persistent", false}, // Set to the default
+		{"c", "4", "default", false},
+		{"custom", "x", "persistent", true}, // No default to compare with
+	}
+
+	for _, tt := range tests {
+		value, scope := effectiveSetting(settings, tt.key)
+		if value != tt.value || scope != tt.scope {
+			t.Errorf("effectiveSetting(%q) = %q, %q", tt.key, value, scope)
+		}
+		if got := settingChanged(settings, tt.key); got != tt.want {
+			t.Errorf("settingChanged(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestRenderClusterSettingsView(t *testing.T) {
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	settings, err := source.NewOpenSearch(client).ClusterSettings(t.Context())
+	if err != nil {
+		t.Fatalf("ClusterSettings() error = %v", err)
+	}
+
+	app := &App{clusterSettings: settings}
+	result := app.renderClusterSettingsView()
+
+	expected := []string{
+		"Persistent: 3",
+		"Transient: 2",
+		"Changed from default: 1",
+		"2 transient settings will be lost",
+		"1 deprecated settings are set",
+		"routing",
+		"enable = all [transient] (persistent: primaries)",
+		"max_bytes_per_sec = 200mb [transient] (default: 40mb)",
+		"no_master_block = write [persistent] [deprecated, use cluster.no_cluster_manager_block]",
+		"max_buckets = 65535",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderClusterSettingsView() should contain %q", want)
+		}
+	}
+
+	// Settings sharing a group are nested under one heading
+	if strings.Count(result, "routing") != 1 {
+		t.Errorf("cluster.routing should be rendered once, got %d", strings.Count(result, "routing"))
+	}
+}
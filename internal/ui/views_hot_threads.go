@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// hotThreadFrames is how many frames of a thread's stack are shown
+const hotThreadFrames = 5
+
+// renderHotThreadsView renders the hot threads sample, next to the previous
+// one after sampling again
+func (a *App) renderHotThreadsView() string {
+	var b strings.Builder
+
+	target := "all nodes"
+	if a.hotThreadsOpts.Node != "" {
+		target = a.hotThreadsOpts.Node
+	}
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Hot Threads: %s", target)))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s  %s %d  %s %s\n",
+		labelStyle.Render("Type:"), valueStyle.Render(a.hotThreadsOpts.Type),
+		labelStyle.Render("Threads:"), a.hotThreadsOpts.Threads,
+		labelStyle.Render("Interval:"), valueStyle.Render(a.hotThreadsOpts.Interval.String())))
+	b.WriteString(helpStyle.Render("s: Sample again | t: Type | i: Interval | [/]: Threads | n: Node"))
+	b.WriteString("\n\n")
+
+	if a.hotThreadsErr != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Failed to sample hot threads: %v", a.hotThreadsErr)))
+		b.WriteString("\n\n")
+	}
+
+	latest := a.renderHotThreadsSample(a.hotThreads, "Latest")
+	if a.hotThreadsPrev == nil {
+		b.WriteString(latest)
+		return b.String()
+	}
+
+	width := a.hotThreadsColumnWidth()
+	column := lipgloss.NewStyle().Width(width).MaxWidth(width)
+	previous := a.renderHotThreadsSample(a.hotThreadsPrev, "Previous")
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+		column.Render(previous),
+		subtleStyle.Render(" │ "),
+		column.Render(latest)))
+	return b.String()
+}
+
+// hotThreadsColumnWidth is the width of each sample when two are shown side
+// by side
+func (a *App) hotThreadsColumnWidth() int {
+	width := 120
+	if a.viewportReady {
+		width = a.viewport.Width
+	}
+	return max((width-3)/2, 20)
+}
+
+// renderHotThreadsSample renders one sample's nodes and their busiest
+// threads
+func (a *App) renderHotThreadsSample(sample *hotThreadsSample, title string) string {
+	var b strings.Builder
+
+	if sample == nil {
+		if a.hotThreadsLoading {
+			b.WriteString(labelStyle.Render(fmt.Sprintf("Sampling hot threads for %s...", a.hotThreadsOpts.Interval)))
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+
+	width := 0
+	if a.hotThreadsPrev != nil {
+		width = a.hotThreadsColumnWidth()
+		b.WriteString(valueStyle.Render(fmt.Sprintf("%s (%s)", title, sample.at.Format("15:04:05"))))
+		b.WriteString("\n\n")
+	}
+
+	if len(sample.nodes) == 0 {
+		b.WriteString(labelStyle.Render("No nodes reported hot threads"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for _, node := range sample.nodes {
+		b.WriteString(headerStyle.Render(node.Node))
+		b.WriteString("\n")
+		if node.Summary != "" {
+			b.WriteString(labelStyle.Render(truncateText(node.Summary, width)))
+			b.WriteString("\n")
+		}
+		if len(node.Threads) == 0 {
+			b.WriteString(labelStyle.Render("  No busy threads"))
+			b.WriteString("\n")
+		}
+
+		for _, thread := range node.Threads {
+			b.WriteString(fmt.Sprintf("%s %s\n",
+				renderHotThreadPercent(thread.Percent),
+				valueStyle.Render(truncateText(thread.Name, width-8))))
+			b.WriteString(fmt.Sprintf("        %s\n", labelStyle.Render(truncateText(thread.Usage+" "+thread.Type, width-8))))
+
+			if stack, ok := topHotThreadStack(thread); ok {
+				b.WriteString(fmt.Sprintf("        %s\n", subtleStyle.Render(truncateText(stack.Snapshots, width-8))))
+				for i, frame := range stack.Frames {
+					if i == hotThreadFrames {
+						b.WriteString(fmt.Sprintf("          %s\n", subtleStyle.Render(fmt.Sprintf("... %d more", len(stack.Frames)-hotThreadFrames))))
+						break
+					}
+					b.WriteString(fmt.Sprintf("          %s\n", truncateText(frame, width-10)))
+				}
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderHotThreadPercent colours a thread's share of the sampling interval
+func renderHotThreadPercent(percent float64) string {
+	text := fmt.Sprintf("%6.1f%%", percent)
+	switch {
+	case percent >= 50:
+		return statusRed.Render(text)
+	case percent >= 20:
+		return statusYellow.Render(text)
+	default:
+		return statusGreen.Render(text)
+	}
+}
+
+// topHotThreadStack returns the stack shared by most of a thread's snapshots
+func topHotThreadStack(thread source.HotThread) (source.HotThreadStack, bool) {
+	best, bestCount := -1, 0
+	for i, stack := range thread.Stacks {
+		count := 1 // "unique snapshot"
+		fmt.Sscanf(stack.Snapshots, "%d/", &count)
+		if best == -1 || count > bestCount {
+			best, bestCount = i, count
+		}
+	}
+	if best == -1 {
+		return source.HotThreadStack{}, false
+	}
+	return thread.Stacks[best], true
+}
+
+// truncateText shortens s to width runes; a width of zero or less leaves it
+// alone
+func truncateText(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
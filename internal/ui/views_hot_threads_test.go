@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// loadHotThreadsFixture samples hot threads through the mock transport
+func loadHotThreadsFixture(t *testing.T) []NodeHotThreads {
+	t.Helper()
+
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	nodes, err := source.NewOpenSearch(client).HotThreads(t.Context(), defaultHotThreadsOptions)
+	if err != nil {
+		t.Fatalf("HotThreads() error = %v", err)
+	}
+	return nodes
+}
+
+func TestRenderHotThreadsView(t *testing.T) {
+	app := &App{
+		hotThreadsOpts: defaultHotThreadsOptions,
+		hotThreads:     &hotThreadsSample{opts: defaultHotThreadsOptions, nodes: loadHotThreadsFixture(t), at: time.Now()},
+	}
+	result := app.renderHotThreadsView()
+
+	expected := []string{
+		"Hot Threads: all nodes",
+		"500ms",
+		"node-1",
+		"62.5%",
+		"opensearch[node-1][search][T#3]",
+		"8/10 snapshots sharing", // The most common stack is shown
+		"BooleanScorer.score",
+		"... 4 more",
+		"unique snapshot",
+		"node-2",
+		"No busy threads",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderHotThreadsView() should contain %q", want)
+		}
+	}
+	if strings.Contains(result, "PriorityQueue.downHeap") {
+		t.Error("only the stack shared by most snapshots should be shown")
+	}
+}
+
+func TestRenderHotThreadsView_SideBySide(t *testing.T) {
+	nodes := loadHotThreadsFixture(t)
+	app := &App{
+		hotThreadsOpts: HotThreadsOptions{Node: "node-1", Threads: 3, Interval: time.Second, Type: "wait"},
+		hotThreadsPrev: &hotThreadsSample{nodes: nodes, at: time.Date(2024, 1, 15, 10, 20, 30, 0, time.UTC)},
+		hotThreads:     &hotThreadsSample{nodes: nodes, at: time.Date(2024, 1, 15, 10, 21, 0, 0, time.UTC)},
+	}
+	result := app.renderHotThreadsView()
+
+	for _, want := range []string{"Hot Threads: node-1", "wait", "Previous (10:20:30)", "Latest (10:21:00)"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderHotThreadsView() should contain %q", want)
+		}
+	}
+
+	// Both samples share lines, so both titles land on the same row
+	for _, line := range strings.Split(result, "\n") {
+		if strings.Contains(line, "Previous") && !strings.Contains(line, "Latest") {
+			t.Error("samples should be rendered side by side")
+		}
+	}
+}
+
+func TestRenderHotThreadsView_Loading(t *testing.T) {
+	app := &App{hotThreadsOpts: defaultHotThreadsOptions, hotThreadsLoading: true}
+	if result := app.renderHotThreadsView(); !strings.Contains(result, "Sampling hot threads for 500ms") {
+		t.Errorf("pending sample should show loading, got %q", result)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10!"},
+		{"much too long", 8, "much ..."},
+		{"anything", 0, "anything"},
+		{"abc", 2, "ab"},
+	}
+	for _, tt := range tests {
+		if got := truncateText(tt.s, tt.width); got != tt.want {
+			t.Errorf("truncateText(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// renderIndexSchemaView renders the tabbed drill-down for a specific index
+func (a *App) renderIndexSchemaView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Index: %s", a.selectedIndexName)))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("←/→ to switch tabs, Esc or Backspace to return to indices list"))
+	b.WriteString("\n\n")
+
+	b.WriteString(a.renderIndexTabBar())
+	b.WriteString("\n\n")
+
+	if err := a.indexTabErrs[a.indexTab]; err != nil && !errors.Is(err, source.ErrISMUnavailable) {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Failed to load %s: %v", strings.ToLower(a.indexTab.String()), err)))
+		b.WriteString("\n\n")
+	}
+
+	switch a.indexTab {
+	case indexTabSchema:
+		a.renderIndexMapping(&b)
+	case indexTabSettings:
+		a.renderIndexSettings(&b)
+	case indexTabStats:
+		a.renderIndexStats(&b)
+	case indexTabAliases:
+		a.renderIndexAliases(&b)
+	case indexTabPolicy:
+		a.renderIndexPolicy(&b)
+	case indexTabShards:
+		a.renderIndexShards(&b)
+	}
+
+	return b.String()
+}
+
+// renderIndexTabBar renders the tab labels with the current tab highlighted
+func (a *App) renderIndexTabBar() string {
+	tabs := make([]string, len(indexTabNames))
+	for i, name := range indexTabNames {
+		if indexTab(i) == a.indexTab {
+			tabs[i] = selectedMenuItemStyle.Render("[" + name + "]")
+		} else {
+			tabs[i] = labelStyle.Render(" " + name + " ")
+		}
+	}
+	return strings.Join(tabs, " ")
+}
+
+// indexTabPending reports whether a tab has neither data nor an error yet
+func (a *App) indexTabPending(tab indexTab) bool {
+	return !a.indexTabLoaded[tab] && a.indexTabErrs[tab] == nil
+}
+
+// renderIndexSettings renders the index's flat settings, and the defaults
+// once they've been requested
+func (a *App) renderIndexSettings(b *strings.Builder) {
+	if a.indexSettings == nil {
+		if a.indexTabPending(indexTabSettings) {
+			b.WriteString(labelStyle.Render("Loading settings..."))
+		}
+		return
+	}
+
+	toggle := "Press d to include default values"
+	if a.indexDefaults {
+		toggle = "Press d to hide default values"
+	}
+	b.WriteString(helpStyle.Render(toggle))
+	b.WriteString("\n\n")
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Settings (%d)", len(a.indexSettings.Settings))))
+	b.WriteString("\n")
+	renderSettingsList(b, a.indexSettings.Settings, valueStyle.Render)
+
+	if a.indexDefaults {
+		b.WriteString("\n")
+		b.WriteString(headerStyle.Render(fmt.Sprintf("Defaults (%d)", len(a.indexSettings.Defaults))))
+		b.WriteString("\n")
+		renderSettingsList(b, a.indexSettings.Defaults, labelStyle.Render)
+	}
+}
+
+// renderSettingsList renders settings sorted by key with aligned values
+func renderSettingsList(b *strings.Builder, settings map[string]string, render func(...string) string) {
+	keys := sortedKeys(settings)
+
+	width := 0
+	for _, key := range keys {
+		if len(key) > width {
+			width = len(key)
+		}
+	}
+
+	for _, key := range keys {
+		b.WriteString(fmt.Sprintf("  %-*s  %s\n", width, key, render(settings[key])))
+	}
+}
+
+// renderIndexStats renders the index's operation counters, caches and
+// segment memory
+func (a *App) renderIndexStats(b *strings.Builder) {
+	if a.indexStats == nil {
+		if a.indexTabPending(indexTabStats) {
+			b.WriteString(labelStyle.Render("Loading index stats..."))
+		}
+		return
+	}
+
+	pri, total := a.indexStats.Primaries, a.indexStats.Total
+
+	b.WriteString(headerStyle.Render("Documents"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s (%s deleted)\n", labelStyle.Render("Docs:      "),
+		valueStyle.Render(formatNumber(pri.Docs.Count)), formatNumber(pri.Docs.Deleted)))
+	b.WriteString(fmt.Sprintf("%s %s primaries, %s total\n\n", labelStyle.Render("Store:     "),
+		valueStyle.Render(formatBytes(pri.Store.SizeInBytes)), formatBytes(total.Store.SizeInBytes)))
+
+	b.WriteString(headerStyle.Render("Operations"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s ops, %s total, %s avg",
+		labelStyle.Render("Indexing:  "),
+		valueStyle.Render(formatNumber(total.Indexing.IndexTotal)),
+		formatMillis(total.Indexing.IndexTimeInMillis),
+		averageMillis(total.Indexing.IndexTimeInMillis, total.Indexing.IndexTotal)))
+	if total.Indexing.IndexFailed > 0 {
+		b.WriteString(" " + statusRed.Render(fmt.Sprintf("(%s failed)", formatNumber(total.Indexing.IndexFailed))))
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s ops, %s total, %s avg\n",
+		labelStyle.Render("Query:     "),
+		valueStyle.Render(formatNumber(total.Search.QueryTotal)),
+		formatMillis(total.Search.QueryTimeInMillis),
+		averageMillis(total.Search.QueryTimeInMillis, total.Search.QueryTotal)))
+	b.WriteString(fmt.Sprintf("%s %s ops, %s total, %s avg\n",
+		labelStyle.Render("Fetch:     "),
+		valueStyle.Render(formatNumber(total.Search.FetchTotal)),
+		formatMillis(total.Search.FetchTimeInMillis),
+		averageMillis(total.Search.FetchTimeInMillis, total.Search.FetchTotal)))
+	b.WriteString(fmt.Sprintf("%s %s merges, %s total, %s merged",
+		labelStyle.Render("Merges:    "),
+		valueStyle.Render(formatNumber(total.Merges.Total)),
+		formatMillis(total.Merges.TotalTimeInMillis),
+		formatBytes(total.Merges.TotalSizeInBytes)))
+	if total.Merges.Current > 0 {
+		b.WriteString(" " + statusYellow.Render(fmt.Sprintf("(%d running)", total.Merges.Current)))
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s refreshes, %s total\n",
+		labelStyle.Render("Refresh:   "),
+		valueStyle.Render(formatNumber(total.Refresh.Total)),
+		formatMillis(total.Refresh.TotalTimeInMillis)))
+	b.WriteString(fmt.Sprintf("%s %s flushes, %s total\n\n",
+		labelStyle.Render("Flush:     "),
+		valueStyle.Render(formatNumber(total.Flush.Total)),
+		formatMillis(total.Flush.TotalTimeInMillis)))
+
+	b.WriteString(headerStyle.Render("Caches"))
+	b.WriteString("\n")
+	renderCacheLine(b, "Query:     ", total.QueryCache)
+	renderCacheLine(b, "Request:   ", total.RequestCache)
+	b.WriteString(fmt.Sprintf("%s %s, %s evictions\n\n",
+		labelStyle.Render("Fielddata: "),
+		valueStyle.Render(formatBytes(total.Fielddata.MemorySizeInBytes)),
+		formatNumber(total.Fielddata.Evictions)))
+
+	seg := total.Segments
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Segments (%s)", formatNumber(seg.Count))))
+	b.WriteString("\n")
+	for _, row := range []struct {
+		label string
+		bytes int64
+	}{
+		{"Total memory", seg.MemoryInBytes},
+		{"Terms", seg.TermsMemoryInBytes},
+		{"Stored fields", seg.StoredFieldsMemoryInBytes},
+		{"Doc values", seg.DocValuesMemoryInBytes},
+		{"Points", seg.PointsMemoryInBytes},
+		{"Norms", seg.NormsMemoryInBytes},
+		{"Index writer", seg.IndexWriterMemoryInBytes},
+		{"Version map", seg.VersionMapMemoryInBytes},
+		{"Fixed bitsets", seg.FixedBitSetMemoryInBytes},
+	} {
+		b.WriteString(fmt.Sprintf("  %-14s %10s\n", row.label, formatBytes(row.bytes)))
+	}
+}
+
+// renderCacheLine renders a cache's size, hit ratio and evictions
+func renderCacheLine(b *strings.Builder, label string, cache source.CacheStats) {
+	ratio := "-"
+	if lookups := cache.HitCount + cache.MissCount; lookups > 0 {
+		ratio = fmt.Sprintf("%.1f%%", float64(cache.HitCount)/float64(lookups)*100)
+	}
+	b.WriteString(fmt.Sprintf("%s %s, hit ratio %s, %s evictions\n",
+		labelStyle.Render(label),
+		valueStyle.Render(formatBytes(cache.MemorySizeInBytes)),
+		ratio,
+		formatNumber(cache.Evictions)))
+}
+
+// renderIndexAliases renders the aliases pointing at the index
+func (a *App) renderIndexAliases(b *strings.Builder) {
+	if !a.indexTabLoaded[indexTabAliases] {
+		if a.indexTabPending(indexTabAliases) {
+			b.WriteString(labelStyle.Render("Loading aliases..."))
+		}
+		return
+	}
+
+	if len(a.indexAliases) == 0 {
+		b.WriteString(labelStyle.Render("No aliases point at this index"))
+		return
+	}
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Aliases (%d)", len(a.indexAliases))))
+	b.WriteString("\n")
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-30s %-6s %-7s %-12s %-12s", "Alias", "Write", "Filter", "Index route", "Search route")))
+	b.WriteString("\n")
+
+	for _, alias := range a.indexAliases {
+		write := fmt.Sprintf("%-6s", "-")
+		if alias.IsWriteIndex {
+			write = statusGreen.Render(fmt.Sprintf("%-6s", "yes"))
+		}
+		filter := "-"
+		if alias.Filter {
+			filter = "yes"
+		}
+		b.WriteString(fmt.Sprintf("  %s %s %-7s %-12s %-12s\n",
+			valueStyle.Render(fmt.Sprintf("%-30s", alias.Name)), write, filter,
+			orDash(alias.IndexRouting), orDash(alias.SearchRouting)))
+	}
+}
+
+// orDash returns s, or "-" when it's empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// renderIndexPolicy renders the index's ISM policy state
+func (a *App) renderIndexPolicy(b *strings.Builder) {
+	if errors.Is(a.indexTabErrs[indexTabPolicy], source.ErrISMUnavailable) {
+		b.WriteString(labelStyle.Render("Index State Management is not available on this cluster"))
+		return
+	}
+	if !a.indexTabLoaded[indexTabPolicy] {
+		if a.indexTabPending(indexTabPolicy) {
+			b.WriteString(labelStyle.Render("Loading policy state..."))
+		}
+		return
+	}
+
+	policy := a.indexPolicy
+	if policy == nil || policy.PolicyID == "" {
+		b.WriteString(labelStyle.Render("This index is not managed by an ISM policy"))
+		return
+	}
+
+	b.WriteString(headerStyle.Render("Index State Management"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Policy: "), valueStyle.Render(policy.PolicyID)))
+	if policy.Enabled != nil && !*policy.Enabled {
+		b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Enabled:"), statusYellow.Render("no")))
+	}
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("State:  "), orDash(policy.State.Name)))
+
+	action := orDash(policy.Action.Name)
+	if policy.Failed() {
+		action = statusRed.Render(action + " (failed)")
+	}
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Action: "), action))
+	if policy.Step.Name != "" {
+		b.WriteString(fmt.Sprintf("%s %s %s\n", labelStyle.Render("Step:   "), policy.Step.Name, labelStyle.Render(policy.Step.StepStatus)))
+	}
+	if policy.RetryInfo.ConsumedRetries > 0 {
+		b.WriteString(fmt.Sprintf("%s %d\n", labelStyle.Render("Retries:"), policy.RetryInfo.ConsumedRetries))
+	}
+	if policy.Info.Message != "" {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Info:   "), policy.Info.Message))
+	}
+	if policy.Info.Cause != "" {
+		b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Cause:  "), errorStyle.Render(policy.Info.Cause)))
+	}
+}
+
+// renderIndexShards renders the index's shard copies from the shards source
+func (a *App) renderIndexShards(b *strings.Builder) {
+	var shards []ShardInfo
+	for _, shard := range a.shards {
+		if shard.Index == a.selectedIndexName {
+			shards = append(shards, shard)
+		}
+	}
+
+	if len(shards) == 0 {
+		if _, ok := a.sourceUpdated[SourceShards]; ok {
+			b.WriteString(labelStyle.Render("No shards found for this index"))
+		}
+		return
+	}
+
+	// Shard number, then primary before replicas
+	sort.SliceStable(shards, func(i, j int) bool {
+		si, _ := strconv.Atoi(shards[i].Shard)
+		sj, _ := strconv.Atoi(shards[j].Shard)
+		if si != sj {
+			return si < sj
+		}
+		return shards[i].Prirep == "p" && shards[j].Prirep != "p"
+	})
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Shards (%d)", len(shards))))
+	b.WriteString("\n")
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-6s %-8s %-13s %10s %10s  %s", "Shard", "Type", "State", "Docs", "Store", "Node")))
+	b.WriteString("\n")
+
+	for _, shard := range shards {
+		kind := "replica"
+		if shard.Prirep == "p" {
+			kind = "primary"
+		}
+
+		state := fmt.Sprintf("%-13s", shard.State)
+		switch shard.State {
+		case "STARTED":
+			state = statusGreen.Render(state)
+		case "UNASSIGNED":
+			state = statusRed.Render(state)
+		default:
+			state = statusYellow.Render(state)
+		}
+
+		b.WriteString(fmt.Sprintf("  %-6s %-8s %s %10s %10s  %s\n",
+			shard.Shard, kind, state, orDash(shard.Docs), orDash(shard.Store), orDash(shard.Node)))
+	}
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// indexDetailApp returns an app with every index drill-down tab loaded from
+// the fixtures
+func indexDetailApp(t *testing.T) *App {
+	t.Helper()
+
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	src := source.NewOpenSearch(client)
+	ctx := t.Context()
+
+	settings, err := src.IndexSettings(ctx, "test-index-1", true)
+	if err != nil {
+		t.Fatalf("IndexSettings() error = %v", err)
+	}
+	stats, err := src.IndexStats(ctx, "test-index-1")
+	if err != nil {
+		t.Fatalf("IndexStats() error = %v", err)
+	}
+	aliases, err := src.IndexAliases(ctx, "test-index-1")
+	if err != nil {
+		t.Fatalf("IndexAliases() error = %v", err)
+	}
+	explained, err := src.ISMExplain(ctx, "test-index-1")
+	if err != nil || len(explained) != 1 {
+		t.Fatalf("ISMExplain() = %v, %v", explained, err)
+	}
+	shards, err := src.Shards(ctx)
+	if err != nil {
+		t.Fatalf("Shards() error = %v", err)
+	}
+
+	return &App{
+		selectedIndexName: "test-index-1",
+		indexDefaults:     true,
+		indexSettings:     settings,
+		indexStats:        stats,
+		indexAliases:      aliases,
+		indexPolicy:       &explained[0],
+		shards:            shards,
+		indexTabLoaded: map[indexTab]bool{
+			indexTabSettings: true, indexTabStats: true, indexTabAliases: true, indexTabPolicy: true,
+		},
+	}
+}
+
+func TestRenderIndexSchemaView_Tabs(t *testing.T) {
+	app := indexDetailApp(t)
+
+	tests := []struct {
+		tab      indexTab
+		expected []string
+	}{
+		{indexTabSettings, []string{"[Settings]", "index.number_of_shards", "Defaults (2)", "index.refresh_interval"}},
+		{indexTabStats, []string{"2,000", "4.0 MB", "3 failed", "5.00ms", "hit ratio 90.0%", "hit ratio 25.0%", "Segments (8)", "64.0 KB"}},
+		{indexTabAliases, []string{"Aliases (2)", "logs-write", "logs-errors"}},
+		{indexTabPolicy, []string{"hot-warm", "rollover (failed)", "Missing rollover_alias", "Retries:"}},
+		{indexTabShards, []string{"Shards (", "primary", "replica", "RELOCATING", "node-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tab.String(), func(t *testing.T) {
+			app.indexTab = tt.tab
+			result := app.renderIndexSchemaView()
+			for _, want := range tt.expected {
+				if !strings.Contains(result, want) {
+					t.Errorf("%s tab should contain %q", tt.tab, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderIndexShards_OnlySelectedIndex(t *testing.T) {
+	app := &App{
+		selectedIndexName: "logs",
+		indexTab:          indexTabShards,
+		shards: []ShardInfo{
+			{Index: "logs", Shard: "1", Prirep: "r", State: "STARTED", Node: "node-b"},
+			{Index: "logs", Shard: "0", Prirep: "r", State: "UNASSIGNED"},
+			{Index: "logs", Shard: "0", Prirep: "p", State: "STARTED", Node: "node-a"},
+			{Index: "metrics", Shard: "0", Prirep: "p", State: "STARTED", Node: "node-c"},
+		},
+	}
+
+	result := app.renderIndexSchemaView()
+	if strings.Contains(result, "node-c") {
+		t.Error("shards of other indices should be filtered out")
+	}
+	if !strings.Contains(result, "Shards (3)") {
+		t.Error("Shards tab should count the index's shard copies")
+	}
+
+	// Shard 0 primary, shard 0 replica, then shard 1
+	a, b, c := strings.Index(result, "node-a"), strings.Index(result, "UNASSIGNED"), strings.Index(result, "node-b")
+	if a > b || b > c {
+		t.Errorf("shards should be ordered by number with primaries first, got %q", result)
+	}
+}
+
+func TestRenderIndexPolicy_States(t *testing.T) {
+	tests := []struct {
+		name   string
+		app    *App
+		expect string
+	}{
+		{"loading", &App{}, "Loading policy state"},
+		{"unavailable", &App{indexTabErrs: map[indexTab]error{indexTabPolicy: source.ErrISMUnavailable}}, "not available"},
+		{"unmanaged", &App{indexTabLoaded: map[indexTab]bool{indexTabPolicy: true}}, "not managed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.app.indexTab = indexTabPolicy
+			if result := tt.app.renderIndexSchemaView(); !strings.Contains(result, tt.expect) {
+				t.Errorf("Policy tab should contain %q, got %q", tt.expect, result)
+			}
+		})
+	}
+}
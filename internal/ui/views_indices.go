@@ -73,14 +73,14 @@ func (a *App) renderShardsView() string {
 		return b.String()
 	}
 
+	// Unassigned and initializing shards come first
+	a.renderProblemShards(&b)
+
 	// Group shards by node
 	shardsByNode := make(map[string][]ShardInfo)
-	unassignedShards := []ShardInfo{}
 
 	for _, shard := range a.shards {
-		if shard.Node == "" {
-			unassignedShards = append(unassignedShards, shard)
-		} else {
+		if shard.Node != "" {
 			shardsByNode[shard.Node] = append(shardsByNode[shard.Node], shard)
 		}
 	}
@@ -150,23 +150,6 @@ func (a *App) renderShardsView() string {
 		b.WriteString("\n")
 	}
 
-	// Show unassigned shards if any
-	if len(unassignedShards) > 0 {
-		b.WriteString("\n")
-		b.WriteString(statusRed.Render(fmt.Sprintf("⚠ Unassigned Shards: %d", len(unassignedShards))))
-		b.WriteString("\n\n")
-
-		// Group unassigned by index
-		unassignedByIndex := make(map[string]int)
-		for _, shard := range unassignedShards {
-			unassignedByIndex[shard.Index]++
-		}
-
-		for index, count := range unassignedByIndex {
-			b.WriteString(fmt.Sprintf("  %s: %d shards\n", index, count))
-		}
-	}
-
 	// Summary statistics
 	b.WriteString("\n")
 	b.WriteString(headerStyle.Render("Shard Balance"))
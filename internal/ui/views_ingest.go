@@ -0,0 +1,319 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ingestInputHeight is the number of lines of the sample document editor
+const ingestInputHeight = 10
+
+// ingestPipelines returns the pipelines the Ingest view's cursor moves over,
+// busiest first
+func (a *App) ingestPipelines() []IngestPipeline {
+	if a.ingest == nil {
+		return nil
+	}
+	pipelines := append([]IngestPipeline(nil), a.ingest.Pipelines...)
+	sort.SliceStable(pipelines, func(i, j int) bool {
+		return pipelines[i].Stats.TimeMillis > pipelines[j].Stats.TimeMillis
+	})
+	return pipelines
+}
+
+// selectedIngestPipeline returns the pipeline under the Ingest view cursor
+func (a *App) selectedIngestPipeline() (IngestPipeline, bool) {
+	pipelines := a.ingestPipelines()
+	if len(pipelines) == 0 {
+		return IngestPipeline{}, false
+	}
+	if a.selectedPipeline < 0 || a.selectedPipeline >= len(pipelines) {
+		a.selectedPipeline = 0
+	}
+	return pipelines[a.selectedPipeline], true
+}
+
+// processorsByTime returns a pipeline's processors, slowest first, with
+// their position in the pipeline
+func processorsByTime(pipeline IngestPipeline) ([]IngestProcessor, []int) {
+	order := make([]int, len(pipeline.Processors))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return pipeline.Processors[order[i]].Stats.TimeMillis > pipeline.Processors[order[j]].Stats.TimeMillis
+	})
+	processors := make([]IngestProcessor, len(order))
+	for i, position := range order {
+		processors[i] = pipeline.Processors[position]
+	}
+	return processors, order
+}
+
+// clearIngestSimulation drops the simulate drill-down's pipeline, sample
+// document and result
+func (a *App) clearIngestSimulation() {
+	a.ingestDetail = ""
+	a.ingestInput = textarea.Model{}
+	a.ingestResult = nil
+	a.ingestSimErr = nil
+	a.ingestSimRunning = false
+}
+
+// openIngestSimulation switches to the simulate drill-down for a pipeline
+// with an empty sample document editor
+func (a *App) openIngestSimulation(id string) {
+	a.clearIngestSimulation()
+	a.ingestDetail = id
+
+	input := textarea.New()
+	input.Placeholder = `{"message": "paste a sample document, or an array of them"}`
+	input.CharLimit = 0
+	input.SetWidth(max(a.viewport.Width-2, 20))
+	input.SetHeight(ingestInputHeight)
+	input.Cursor.SetMode(cursor.CursorStatic) // No blink messages to route
+	input.Focus()
+	a.ingestInput = input
+
+	a.currentView = ViewIngestSimulate
+	a.updateViewportContent()
+	if a.viewportReady {
+		a.viewport.GotoTop()
+	}
+}
+
+// handleIngestSimKey edits the sample document; the editor captures all
+// keys but quit, panel switching, running and leaving the drill-down
+func (a *App) handleIngestSimKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return a, tea.Quit
+
+	case "tab":
+		a.activePanel = PanelLeft
+		return a, nil
+
+	case "esc":
+		a.currentView = ViewIngest
+		a.clearIngestSimulation()
+		a.updateViewportContent()
+		if a.viewportReady {
+			a.viewport.GotoTop()
+		}
+		return a, nil
+
+	case "ctrl+r":
+		docs, err := parseSampleDocs(a.ingestInput.Value())
+		if err != nil {
+			a.ingestResult = nil
+			a.ingestSimErr = err
+			a.updateViewportContent()
+			return a, nil
+		}
+		cmd := a.fetchIngestSimulation(docs)
+		a.updateViewportContent()
+		return a, cmd
+	}
+
+	var cmd tea.Cmd
+	a.ingestInput, cmd = a.ingestInput.Update(msg)
+	a.updateViewportContent()
+	return a, cmd
+}
+
+// parseSampleDocs reads the pasted sample document, or an array of them
+func parseSampleDocs(text string) ([]json.RawMessage, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.New("paste a sample document first")
+	}
+
+	if isJSONObject(json.RawMessage(text)) {
+		return []json.RawMessage{json.RawMessage(text)}, nil
+	}
+
+	var docs []json.RawMessage
+	if err := json.Unmarshal([]byte(text), &docs); err != nil || len(docs) == 0 {
+		return nil, errors.New("the sample must be a JSON object or a non-empty array of objects")
+	}
+	for i, doc := range docs {
+		if !isJSONObject(doc) {
+			return nil, fmt.Errorf("sample document %d is not a JSON object", i+1)
+		}
+	}
+	return docs, nil
+}
+
+// isJSONObject reports whether raw holds a JSON object
+func isJSONObject(raw json.RawMessage) bool {
+	var fields map[string]json.RawMessage
+	return json.Unmarshal(raw, &fields) == nil && fields != nil
+}
+
+// renderIngestView renders every pipeline's counters, with the selected
+// pipeline's processors broken down by time spent
+func (a *App) renderIngestView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render("Ingest Pipelines"))
+	b.WriteString("\n\n")
+
+	pipelines := a.ingestPipelines()
+	if a.ingest == nil {
+		b.WriteString(labelStyle.Render("No ingest data available"))
+		return b.String()
+	}
+	if len(pipelines) == 0 {
+		b.WriteString(labelStyle.Render("No ingest pipelines defined"))
+		return b.String()
+	}
+	a.selectedIngestPipeline() // Clamps the cursor
+
+	var total IngestStats
+	for _, pipeline := range pipelines {
+		total.Count += pipeline.Stats.Count
+		total.TimeMillis += pipeline.Stats.TimeMillis
+		total.Failed += pipeline.Stats.Failed
+	}
+	b.WriteString(fmt.Sprintf("%s %d  %s %s  %s %s  %s %s\n",
+		labelStyle.Render("Pipelines:"), len(pipelines),
+		labelStyle.Render("Documents:"), formatNumber(total.Count),
+		labelStyle.Render("Time:"), formatMillis(total.TimeMillis),
+		labelStyle.Render("Failed:"), renderFailureCount(total.Failed, 0)))
+	b.WriteString(helpStyle.Render("Press Enter to simulate the selected pipeline with a sample document"))
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-30s %12s %10s %10s %8s %8s",
+		"Pipeline", "Count", "Time", "Avg/doc", "Current", "Failed")))
+	b.WriteString("\n")
+
+	for i, pipeline := range pipelines {
+		cursor := "  "
+		if i == a.selectedPipeline {
+			cursor = statusGreen.Render("▶ ")
+		}
+		b.WriteString(cursor)
+		b.WriteString(fmt.Sprintf("%-30s %12s %10s %10s %8d %8s\n",
+			truncateText(pipeline.ID, 30),
+			formatNumber(pipeline.Stats.Count),
+			formatMillis(pipeline.Stats.TimeMillis),
+			averageMillis(pipeline.Stats.TimeMillis, pipeline.Stats.Count),
+			pipeline.Stats.Current,
+			renderFailureCount(pipeline.Stats.Failed, 8)))
+
+		if i == a.selectedPipeline {
+			renderIngestProcessors(&b, pipeline)
+		}
+	}
+
+	return b.String()
+}
+
+// renderIngestProcessors renders a pipeline's description and processors,
+// slowest first
+func renderIngestProcessors(b *strings.Builder, pipeline IngestPipeline) {
+	if pipeline.Description != "" {
+		b.WriteString(fmt.Sprintf("    %s\n", labelStyle.Render(pipeline.Description)))
+	}
+	if len(pipeline.Processors) == 0 {
+		b.WriteString(labelStyle.Render("    No processors"))
+		b.WriteString("\n\n")
+		return
+	}
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("    %-3s %-24s %12s %10s %7s %8s",
+		"#", "Processor", "Count", "Time", "Share", "Failed")))
+	b.WriteString("\n")
+	processors, positions := processorsByTime(pipeline)
+	for i, processor := range processors {
+		b.WriteString(fmt.Sprintf("    %-3d %-24s %12s %10s %6.1f%% %8s\n",
+			positions[i]+1,
+			truncateText(processor.Name(), 24),
+			formatNumber(processor.Stats.Count),
+			formatMillis(processor.Stats.TimeMillis),
+			percentOf(processor.Stats.TimeMillis, pipeline.Stats.TimeMillis),
+			renderFailureCount(processor.Stats.Failed, 8)))
+	}
+	b.WriteString("\n")
+}
+
+// renderIngestSimulateView renders the sample document editor and the
+// documents the pipeline produced from it
+func (a *App) renderIngestSimulateView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Simulate Pipeline: %s", a.ingestDetail)))
+	b.WriteString("\n\n")
+
+	for _, pipeline := range a.ingestPipelines() {
+		if pipeline.ID != a.ingestDetail {
+			continue
+		}
+		if pipeline.Description != "" {
+			b.WriteString(labelStyle.Render(pipeline.Description))
+			b.WriteString("\n")
+		}
+		names := make([]string, len(pipeline.Processors))
+		for i, processor := range pipeline.Processors {
+			names[i] = processor.Name()
+		}
+		b.WriteString(fmt.Sprintf("%s %s\n\n", labelStyle.Render("Processors:"), orDash(strings.Join(names, " → "))))
+	}
+
+	b.WriteString(helpStyle.Render("Paste or type a sample document | ctrl+r: Run | Tab: Menu | Esc: Back"))
+	b.WriteString("\n")
+	b.WriteString(a.ingestInput.View())
+	b.WriteString("\n\n")
+
+	switch {
+	case a.ingestSimRunning:
+		b.WriteString(labelStyle.Render("Running pipeline..."))
+		b.WriteString("\n")
+	case a.ingestSimErr != nil:
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Simulation failed: %v", a.ingestSimErr)))
+		b.WriteString("\n")
+	case a.ingestResult != nil:
+		renderSimulatedDocs(&b, a.ingestResult)
+	}
+
+	return b.String()
+}
+
+// renderSimulatedDocs renders each document as the pipeline left it
+func renderSimulatedDocs(b *strings.Builder, docs []SimulatedDoc) {
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Result (%d documents)", len(docs))))
+	b.WriteString("\n\n")
+
+	for i, doc := range docs {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("Document %d: ", i+1)))
+		switch {
+		case doc.Error != "":
+			b.WriteString(statusRed.Render("failed"))
+			b.WriteString("\n")
+			b.WriteString(errorStyle.Render("  " + doc.Error))
+			b.WriteString("\n\n")
+			continue
+		case doc.Dropped:
+			b.WriteString(statusYellow.Render("dropped"))
+			b.WriteString("\n\n")
+			continue
+		}
+
+		b.WriteString(statusGreen.Render("ok"))
+		b.WriteString("\n")
+		source, err := json.MarshalIndent(doc.Source, "  ", "  ")
+		if err != nil {
+			b.WriteString(errorStyle.Render(fmt.Sprintf("  %v", err)))
+		} else {
+			b.WriteString("  " + string(source))
+		}
+		b.WriteString("\n\n")
+	}
+}
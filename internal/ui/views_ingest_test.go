@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+func TestRenderIngestView(t *testing.T) {
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	overview, err := source.NewOpenSearch(client).Ingest(t.Context())
+	if err != nil {
+		t.Fatalf("Ingest() error = %v", err)
+	}
+
+	app := &App{ingest: overview}
+	result := app.renderIngestView()
+
+	expected := []string{
+		"Pipelines: 2",
+		"Documents: 2,500",
+		"▶ logs-app",
+		"Parse application logs",
+		"grok",
+		"set:env",
+		"76.7%", // grok's share of logs-app's 1.5s
+		"metrics",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderIngestView() should contain %q", want)
+		}
+	}
+
+	// Processors are listed slowest first; set:env runs last and is fastest
+	if strings.Index(result, "grok") > strings.Index(result, "date") || strings.Index(result, "date") > strings.Index(result, "set:env") {
+		t.Error("processors should be sorted by time spent")
+	}
+	// Only the selected pipeline is broken down
+	if strings.Contains(result, "rename") {
+		t.Error("unselected pipelines shouldn't list their processors")
+	}
+}
+
+func TestIngestPipelines_BusiestFirst(t *testing.T) {
+	app := &App{ingest: &IngestOverview{Pipelines: []IngestPipeline{
+		{ID: "a", Stats: IngestStats{TimeMillis: 5}},
+		{ID: "b", Stats: IngestStats{TimeMillis: 50}},
+		{ID: "c"},
+	}}}
+
+	var ids []string
+	for _, pipeline := range app.ingestPipelines() {
+		ids = append(ids, pipeline.ID)
+	}
+	if strings.Join(ids, ",") != "b,a,c" {
+		t.Errorf("ingestPipelines() = %v, want busiest first", ids)
+	}
+
+	// An out of range cursor is clamped
+	app.selectedPipeline = 9
+	if pipeline, ok := app.selectedIngestPipeline(); !ok || pipeline.ID != "b" {
+		t.Errorf("selectedIngestPipeline() = %q, %v", pipeline.ID, ok)
+	}
+}
+
+func TestParseSampleDocs(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    int
+		wantErr bool
+	}{
+		{`{"message": "hi"}`, 1, false},
+		{`[{"a": 1}, {"b": 2}]`, 2, false},
+		{"  \n", 0, true},
+		{`{"message": `, 0, true},
+		{`[]`, 0, true},
+		{`[{"a": 1}, 2]`, 0, true},
+		{`"text"`, 0, true},
+		{`null`, 0, true},
+	}
+
+	for _, tt := range tests {
+		docs, err := parseSampleDocs(tt.text)
+		if (err != nil) != tt.wantErr || len(docs) != tt.want {
+			t.Errorf("parseSampleDocs(%q) = %d docs, %v", tt.text, len(docs), err)
+		}
+	}
+}
+
+func TestRenderIngestSimulateView(t *testing.T) {
+	app := &App{ingest: &IngestOverview{Pipelines: []IngestPipeline{{
+		ID:         "logs",
+		Processors: []IngestProcessor{{Type: "grok"}, {Type: "set", Tag: "env"}},
+	}}}}
+	app.openIngestSimulation("logs")
+	app.ingestResult = []SimulatedDoc{
+		{Source: map[string]interface{}{"env": "prod"}},
+		{Error: "illegal_argument_exception: field [message] not present"},
+		{Dropped: true},
+	}
+	result := app.renderIngestSimulateView()
+
+	expected := []string{
+		"Simulate Pipeline: logs",
+		"grok → set:env",
+		"ctrl+r: Run",
+		"Result (3 documents)",
+		`"env": "prod"`,
+		"field [message] not present",
+		"dropped",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderIngestSimulateView() should contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// ismPluginMissing reports whether the loaded plugin list shows no index
+// management plugin on any node
+func (a *App) ismPluginMissing() bool {
+	if a.plugins == nil {
+		return false
+	}
+	for _, plugin := range a.plugins {
+		component := strings.ReplaceAll(plugin.Component, "_", "-")
+		if strings.Contains(component, "index-management") {
+			return false
+		}
+	}
+	return true
+}
+
+// ismManagedIndices returns the managed indices shown, honouring the
+// failed-only filter
+func (a *App) ismManagedIndices() []ISMExplanation {
+	if !a.ismFailedOnly {
+		return a.ism.Managed
+	}
+	var failed []ISMExplanation
+	for _, explanation := range a.ism.Managed {
+		if explanation.Failed() {
+			failed = append(failed, explanation)
+		}
+	}
+	return failed
+}
+
+// renderISMView renders ISM policies and the state of every managed index
+func (a *App) renderISMView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render("Index State Management"))
+	b.WriteString("\n\n")
+
+	if (a.ism == nil && a.ismPluginMissing()) || (a.ism != nil && !a.ism.Available) {
+		b.WriteString(labelStyle.Render("The Index State Management plugin is not installed on this cluster"))
+		b.WriteString("\n")
+		b.WriteString(labelStyle.Render("Install opensearch-index-management to manage index lifecycles with policies"))
+		return b.String()
+	}
+	if a.ism == nil {
+		b.WriteString(labelStyle.Render("No ISM data available"))
+		return b.String()
+	}
+
+	failed := 0
+	for _, explanation := range a.ism.Managed {
+		if explanation.Failed() {
+			failed++
+		}
+	}
+	failedText := statusGreen.Render("0")
+	if failed > 0 {
+		failedText = statusRed.Render(fmt.Sprintf("%d", failed))
+	}
+	b.WriteString(fmt.Sprintf("%s %d  %s %d  %s %s\n",
+		labelStyle.Render("Policies:"), len(a.ism.Policies),
+		labelStyle.Render("Managed indices:"), len(a.ism.Managed),
+		labelStyle.Render("Failed:"), failedText))
+	if a.ismFailedOnly {
+		b.WriteString(helpStyle.Render("F: Show all indices"))
+	} else {
+		b.WriteString(helpStyle.Render("F: Show failed indices only"))
+	}
+	b.WriteString("\n\n")
+
+	a.renderISMPolicies(&b)
+	a.renderISMManaged(&b)
+
+	return b.String()
+}
+
+// renderISMPolicies renders each policy's states and the indices it manages
+func (a *App) renderISMPolicies(b *strings.Builder) {
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Policies (%d)", len(a.ism.Policies))))
+	b.WriteString("\n\n")
+
+	if len(a.ism.Policies) == 0 {
+		b.WriteString(labelStyle.Render("No ISM policies defined"))
+		b.WriteString("\n\n")
+		return
+	}
+
+	managed := make(map[string]int)
+	for _, explanation := range a.ism.Managed {
+		managed[explanation.PolicyID]++
+	}
+
+	for _, policy := range a.ism.Policies {
+		b.WriteString(fmt.Sprintf("%s %s\n",
+			valueStyle.Render(policy.ID),
+			labelStyle.Render(fmt.Sprintf("(%d indices)", managed[policy.ID]))))
+		if policy.Description != "" {
+			b.WriteString(fmt.Sprintf("  %s\n", labelStyle.Render(policy.Description)))
+		}
+		b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("States:  "), renderISMStates(policy)))
+		if len(policy.IndexPatterns) > 0 {
+			b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Patterns:"), strings.Join(policy.IndexPatterns, ", ")))
+		}
+		if policy.LastUpdated > 0 {
+			b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Updated: "),
+				time.UnixMilli(policy.LastUpdated).Format("2006-01-02 15:04")))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// renderISMStates lists a policy's states in order, marking the default one
+func renderISMStates(policy source.ISMPolicy) string {
+	if len(policy.States) == 0 {
+		return "-"
+	}
+	states := make([]string, len(policy.States))
+	for i, state := range policy.States {
+		states[i] = state
+		if state == policy.DefaultState {
+			states[i] = valueStyle.Render(state + "*")
+		}
+	}
+	return strings.Join(states, " → ")
+}
+
+// renderISMManaged renders the state, action and step of each managed index
+func (a *App) renderISMManaged(b *strings.Builder) {
+	indices := a.ismManagedIndices()
+	title := fmt.Sprintf("Managed Indices (%d)", len(a.ism.Managed))
+	if a.ismFailedOnly {
+		title = fmt.Sprintf("Failed Indices (%d of %d)", len(indices), len(a.ism.Managed))
+	}
+	b.WriteString(headerStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if len(indices) == 0 {
+		if a.ismFailedOnly {
+			b.WriteString(statusGreen.Render("✓ No managed index has a failed action"))
+		} else {
+			b.WriteString(labelStyle.Render("No indices are managed by ISM"))
+		}
+		b.WriteString("\n")
+		return
+	}
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("%-30s %-20s %-12s %-16s %-26s %s",
+		"Index", "Policy", "State", "Action", "Step", "Status")))
+	b.WriteString("\n")
+
+	for _, explanation := range indices {
+		step := explanation.Step.Name
+		if explanation.Step.StepStatus != "" {
+			step = fmt.Sprintf("%s (%s)", step, explanation.Step.StepStatus)
+		}
+
+		b.WriteString(fmt.Sprintf("%-30s %-20s %-12s %-16s %-26s %s\n",
+			truncateText(explanation.Index, 30),
+			truncateText(explanation.PolicyID, 20),
+			truncateText(orDash(explanation.State.Name), 12),
+			truncateText(orDash(explanation.Action.Name), 16),
+			truncateText(orDash(step), 26),
+			renderISMStatus(explanation)))
+
+		if explanation.Info.Message != "" {
+			message := labelStyle.Render(explanation.Info.Message)
+			if explanation.Failed() {
+				message = errorStyle.Render(explanation.Info.Message)
+			}
+			b.WriteString(fmt.Sprintf("  %s\n", message))
+		}
+	}
+}
+
+// renderISMStatus summarizes whether ISM is making progress on an index
+func renderISMStatus(explanation ISMExplanation) string {
+	switch {
+	case explanation.Failed():
+		status := "FAILED"
+		if explanation.RetryInfo.ConsumedRetries > 0 {
+			status = fmt.Sprintf("FAILED (%d retries)", explanation.RetryInfo.ConsumedRetries)
+		}
+		return statusRed.Render(status)
+	case explanation.Enabled != nil && !*explanation.Enabled:
+		return statusYellow.Render("disabled")
+	default:
+		return statusGreen.Render("ok")
+	}
+}
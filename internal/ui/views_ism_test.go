@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+func TestRenderISMView(t *testing.T) {
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	overview, err := source.NewOpenSearch(client).ISM(t.Context())
+	if err != nil {
+		t.Fatalf("ISM() error = %v", err)
+	}
+
+	app := &App{ism: overview}
+	result := app.renderISMView()
+
+	expected := []string{
+		"Policies: 1",
+		"Managed indices: 1",
+		"Policies (1)",
+		"hot-warm",
+		"(1 indices)",
+		"warm → delete",
+		"test-index-*",
+		"Managed Indices (1)",
+		"test-index-1",
+		"rollover",
+		"attempt_rollover (failed)",
+		"FAILED (3 retries)",
+		"Missing rollover_alias index setting",
+		"F: Show failed indices only",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderISMView() should contain %q", want)
+		}
+	}
+}
+
+func TestRenderISMView_FailedOnly(t *testing.T) {
+	disabled := false
+	overview := &ISMOverview{
+		Available: true,
+		Managed: []ISMExplanation{
+			{Index: "healthy", PolicyID: "p"},
+			{Index: "paused", PolicyID: "p", Enabled: &disabled},
+		},
+	}
+	overview.Managed[0].State.Name = "hot"
+
+	app := &App{ism: overview}
+	result := app.renderISMView()
+	if !strings.Contains(result, "healthy") || !strings.Contains(result, "disabled") {
+		t.Errorf("all indices should be listed, got %q", result)
+	}
+
+	app.ismFailedOnly = true
+	result = app.renderISMView()
+	if strings.Contains(result, "healthy") {
+		t.Error("failed-only filter should hide indices without failures")
+	}
+	for _, want := range []string{"Failed Indices (0 of 2)", "No managed index has a failed action", "F: Show all indices"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderISMView() should contain %q", want)
+		}
+	}
+}
+
+func TestRenderISMView_Unavailable(t *testing.T) {
+	tests := []struct {
+		name string
+		app  *App
+		want string
+	}{
+		{"no data", &App{}, "No ISM data available"},
+		{"api unavailable", &App{ism: &ISMOverview{}}, "not installed"},
+		{"plugin missing", &App{plugins: []PluginInfo{{Component: "analysis-icu"}}}, "not installed"},
+		{"plugin present", &App{plugins: []PluginInfo{{Component: "opensearch-index-management"}}}, "No ISM data available"},
+		{"open distro plugin", &App{plugins: []PluginInfo{{Component: "opendistro_index_management"}}}, "No ISM data available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.app.renderISMView(); !strings.Contains(result, tt.want) {
+				t.Errorf("renderISMView() = %q, want %q", result, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderMemoryView renders every node's circuit breakers, cache memory and
+// segment memory, with breaker trips and cache evictions as rates
+func (a *App) renderMemoryView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render("Memory Pressure"))
+	b.WriteString("\n\n")
+
+	if a.memory == nil {
+		b.WriteString(labelStyle.Render("No node memory data available"))
+		return b.String()
+	}
+	if len(a.memory) == 0 {
+		b.WriteString(labelStyle.Render("No nodes reported memory stats"))
+		return b.String()
+	}
+
+	var tripped int64
+	tripping := 0
+	for _, node := range a.memory {
+		for name, breaker := range node.Breakers {
+			tripped += breaker.Tripped
+			if rate, _ := a.memoryTimeSeries.Rate(memoryCounterKey(node.ID, breakerTripCounter(name))); rate > 0 {
+				tripping++
+			}
+		}
+	}
+	b.WriteString(fmt.Sprintf("%s %d  %s %s  %s %s\n",
+		labelStyle.Render("Nodes:"), len(a.memory),
+		labelStyle.Render("Breaker trips:"), renderFailureCount(tripped, 0),
+		labelStyle.Render("Breakers tripping now:"), renderFailureCount(int64(tripping), 0)))
+	if a.memoryTimeSeries.Size() == 0 {
+		b.WriteString(helpStyle.Render("Trip and eviction rates appear after the next refresh"))
+	} else {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Rates are per second since the last refresh; peaks cover the last %d refreshes", a.memoryTimeSeries.Size())))
+	}
+	b.WriteString("\n\n")
+
+	for _, node := range a.memory {
+		b.WriteString(metricHeaderStyle.Render(orDash(node.Name)))
+		b.WriteString("\n")
+		a.renderMemoryBreakers(&b, node)
+		a.renderMemoryCaches(&b, node)
+		renderSegmentMemory(&b, node.Segments)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderMemoryBreakers renders a node's circuit breakers, the fullest
+// highlighted
+func (a *App) renderMemoryBreakers(b *strings.Builder, node NodeMemory) {
+	if len(node.Breakers) == 0 {
+		b.WriteString(labelStyle.Render("  No circuit breakers reported"))
+		b.WriteString("\n")
+		return
+	}
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-20s %10s %10s %7s %9s %9s %9s",
+		"Breaker", "Limit", "Estimated", "Used", "Tripped", "Trips/s", "Peak/s")))
+	b.WriteString("\n")
+	for _, name := range sortedKeys(node.Breakers) {
+		breaker := node.Breakers[name]
+		key := memoryCounterKey(node.ID, breakerTripCounter(name))
+		b.WriteString(fmt.Sprintf("  %-20s %10s %10s %s %9d %s %9.2f\n",
+			truncateText(name, 20),
+			formatBytes(breaker.LimitSizeInBytes),
+			formatBytes(breaker.EstimatedSizeInBytes),
+			renderBreakerUsed(percentOf(breaker.EstimatedSizeInBytes, breaker.LimitSizeInBytes)),
+			breaker.Tripped,
+			a.renderMemoryRate(key, 9),
+			a.memoryTimeSeries.PeakRate(key)))
+	}
+}
+
+// renderBreakerUsed colours how full a breaker is: yellow from 75% and red
+// from 90%, where requests start being rejected soon
+func renderBreakerUsed(used float64) string {
+	text := fmt.Sprintf("%6.1f%%", used)
+	switch {
+	case used >= 90:
+		return statusRed.Render(text)
+	case used >= 75:
+		return statusYellow.Render(text)
+	}
+	return text
+}
+
+// renderMemoryCaches renders the heap held by a node's caches and how fast
+// they evict
+func (a *App) renderMemoryCaches(b *strings.Builder, node NodeMemory) {
+	caches := []struct {
+		name    string
+		counter string
+		cache   CacheMemory
+	}{
+		{"query_cache", counterQueryCacheEvictions, node.QueryCache},
+		{"request_cache", counterRequestCacheEvictions, node.RequestCache},
+		{"fielddata", counterFielddataEvictions, node.Fielddata},
+	}
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-20s %10s %10s %7s %9s %9s",
+		"Cache", "Memory", "Evictions", "Hits", "Evict/s", "Peak/s")))
+	b.WriteString("\n")
+	for _, c := range caches {
+		key := memoryCounterKey(node.ID, c.counter)
+		hits := "-"
+		if lookups := c.cache.HitCount + c.cache.MissCount; lookups > 0 {
+			hits = fmt.Sprintf("%.1f%%", percentOf(c.cache.HitCount, lookups))
+		}
+		b.WriteString(fmt.Sprintf("  %-20s %10s %10s %7s %s %9.2f\n",
+			c.name,
+			formatBytes(c.cache.MemorySizeInBytes),
+			formatNumber(c.cache.Evictions),
+			hits,
+			a.renderMemoryRate(key, 9),
+			a.memoryTimeSeries.PeakRate(key)))
+	}
+}
+
+// renderMemoryRate pads a counter's latest rate to width and colours it red
+// when the counter is rising, or shows a dash before a rate is known
+func (a *App) renderMemoryRate(key string, width int) string {
+	rate, ok := a.memoryTimeSeries.Rate(key)
+	if !ok {
+		return fmt.Sprintf("%*s", width, "-")
+	}
+	text := fmt.Sprintf("%*.2f", width, rate)
+	if rate > 0 {
+		return statusRed.Render(text)
+	}
+	return text
+}
+
+// renderSegmentMemory renders the heap held by a node's segments, broken
+// down by what holds it
+func renderSegmentMemory(b *strings.Builder, segments SegmentMemory) {
+	b.WriteString(fmt.Sprintf("  %s %s  %s %s\n",
+		labelStyle.Render("Segments:"), formatNumber(segments.Count),
+		labelStyle.Render("Memory:"), formatBytes(segments.MemoryInBytes)))
+
+	parts := []struct {
+		name  string
+		bytes int64
+	}{
+		{"terms", segments.TermsMemoryInBytes},
+		{"stored fields", segments.StoredFieldsMemoryInBytes},
+		{"doc values", segments.DocValuesMemoryInBytes},
+		{"points", segments.PointsMemoryInBytes},
+		{"norms", segments.NormsMemoryInBytes},
+		{"index writer", segments.IndexWriterMemoryInBytes},
+		{"version map", segments.VersionMapMemoryInBytes},
+		{"fixed bit set", segments.FixedBitSetMemoryInBytes},
+	}
+	var held []string
+	for _, part := range parts {
+		if part.bytes > 0 {
+			held = append(held, fmt.Sprintf("%s %s", part.name, formatBytes(part.bytes)))
+		}
+	}
+	if len(held) > 0 {
+		b.WriteString(labelStyle.Render("    " + strings.Join(held, ", ")))
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+func TestRenderMemoryView(t *testing.T) {
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	nodes, err := source.NewOpenSearch(client).NodeMemory(t.Context())
+	if err != nil {
+		t.Fatalf("NodeMemory() error = %v", err)
+	}
+
+	app := &App{memory: nodes, memoryTimeSeries: NewMemoryTimeSeries(12)}
+	app.memoryTimeSeries.AddSnapshot(newMemorySnapshot(nodes, time.Unix(0, 0)))
+	result := app.renderMemoryView()
+
+	expected := []string{
+		"Nodes: 2",
+		"Breaker trips: 4",
+		"rates appear after the next refresh",
+		"node-1",
+		"node-2",
+		"parent",
+		"92.5%",  // 900mb of 972.7mb
+		"75.0%",  // query cache hits
+		"8.0 MB", // segment memory
+		"terms 6.0 MB",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderMemoryView() should contain %q", want)
+		}
+	}
+
+	// Node 1 trips its parent breaker 10 times and evicts 50 query cache
+	// entries over the next 5 seconds
+	later := append([]NodeMemory(nil), nodes...)
+	later[0].Breakers = maps.Clone(nodes[0].Breakers)
+	parent := later[0].Breakers["parent"]
+	parent.Tripped += 10
+	later[0].Breakers["parent"] = parent
+	later[0].QueryCache.Evictions += 50
+	app.memory = later
+	app.memoryTimeSeries.AddSnapshot(newMemorySnapshot(later, time.Unix(5, 0)))
+	result = app.renderMemoryView()
+
+	for _, want := range []string{"Breakers tripping now: 1", "Rates are per second", "2.00", "10.00"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderMemoryView() with rates should contain %q", want)
+		}
+	}
+}
+
+func TestRenderMemoryView_NoData(t *testing.T) {
+	app := &App{memoryTimeSeries: NewMemoryTimeSeries(12)}
+	if result := app.renderMemoryView(); !strings.Contains(result, "No node memory data available") {
+		t.Errorf("renderMemoryView() = %q, want the no data message", result)
+	}
+}
@@ -2,29 +2,125 @@ package ui
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/NimbleMarkets/ntcharts/canvas"
 	"github.com/NimbleMarkets/ntcharts/linechart"
 )
 
+// metricsRawPoints is how many Live Metrics samples are kept at full
+// resolution before being downsampled
+const metricsRawPoints = 60
+
+const (
+	defaultMetricsWindow   = time.Minute
+	defaultMetricsInterval = 5 * time.Second
+)
+
+// metricsWindows are the Live Metrics windows 'w' cycles through
+var metricsWindows = []time.Duration{
+	1 * time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	1 * time.Hour,
+}
+
+// metricsIntervals are the Live Metrics sample intervals 'i' cycles through
+var metricsIntervals = []time.Duration{
+	1 * time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// cycleMetricsWindow shows the next longer window, wrapping to the shortest
+func (a *App) cycleMetricsWindow() {
+	a.metricsTimeSeries.SetWindow(nextDuration(metricsWindows, a.metricsTimeSeries.Window))
+	for _, series := range a.pinnedSeries {
+		series.SetWindow(a.metricsTimeSeries.Window)
+	}
+	a.updateViewportContent()
+}
+
+// cycleMetricsInterval samples at the next longer interval, wrapping to the
+// shortest; the sample already scheduled keeps the old interval
+func (a *App) cycleMetricsInterval() {
+	a.metricsInterval = nextDuration(metricsIntervals, a.metricsInterval)
+	a.updateViewportContent()
+}
+
+// addPinnedSnapshot adds every pinned index's counters to its series, which
+// keeps the cluster series' window and downsampling. Returns true if any
+// series gained a data point
+func (a *App) addPinnedSnapshot(snapshot *IndexActivitySnapshot) bool {
+	if a.pinnedSeries == nil {
+		a.pinnedSeries = make(map[string]*MetricsTimeSeries)
+	}
+	added := false
+	for _, index := range a.pinnedIndices {
+		activity, ok := snapshot.Indices[index]
+		if !ok {
+			continue
+		}
+		series, ok := a.pinnedSeries[index]
+		if !ok {
+			series = NewMetricsWindow(metricsRawPoints, a.metricsTimeSeries.Window)
+			a.pinnedSeries[index] = series
+		}
+		if series.AddSnapshot(indexMetricsSnapshot(activity, snapshot.Timestamp)) {
+			added = true
+		}
+	}
+	return added
+}
+
+// nextDuration returns the option after current, or the first option when
+// current is the last or not an option
+func nextDuration(options []time.Duration, current time.Duration) time.Duration {
+	for i, d := range options {
+		if d == current {
+			return options[(i+1)%len(options)]
+		}
+	}
+	return options[0]
+}
+
+// shortDuration formats whole minutes and hours without trailing zero units
+// (5m rather than 5m0s)
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
 // renderMetricsView renders the Live Metrics view with graphs
 func (a *App) renderMetricsView() string {
 	var content string
 
 	// Header
-	header := headerStyle.Render("Live Cluster Metrics") + " " + subtleStyle.Render("(Last 60 seconds, auto-refresh: 5s)")
+	header := headerStyle.Render("Live Cluster Metrics") + " " + subtleStyle.Render(fmt.Sprintf("(Last %s, sampled every %s)",
+		shortDuration(a.metricsTimeSeries.Window), shortDuration(a.metricsInterval)))
 	content += header + "\n"
+	content += helpStyle.Render("w: Window | i: Sample interval") + "\n"
 	content += dividerStyle.Render("────────────────────────────────────────────────────────────────") + "\n\n"
 
 	// Check if we have data
 	if a.metricsTimeSeries.Size() == 0 {
 		content += subtleStyle.Render("Collecting metrics data...") + "\n"
-		content += subtleStyle.Render("Graphs will appear after first data point (5 seconds)") + "\n"
+		content += subtleStyle.Render(fmt.Sprintf("Graphs will appear after first data point (%s)", shortDuration(a.metricsInterval))) + "\n"
 		return content
 	}
 
-	// Get data points
-	dataPoints := a.metricsTimeSeries.GetDataPoints()
+	// Get buckets, older data points downsampled into them
+	buckets := a.metricsTimeSeries.WindowBuckets()
+	timeAxis := renderTimeAxis(a.metricsTimeSeries.GetTimeRange(), 68)
 
 	// Calculate summaries
 	insertSummary := a.metricsTimeSeries.CalculateSummary("insert")
@@ -37,18 +133,38 @@ func (a *App) renderMetricsView() string {
 	// Render insert rate graph
 	graphWidth := 68
 	graphHeight := 8
-	insertGraph := renderMetricsGraph(dataPoints, "insert", graphWidth, graphHeight)
+	insertGraph := renderMetricsRangeGraph(buckets, "insert", graphWidth, graphHeight)
 	content += insertGraph + "\n"
-	content += subtleStyle.Render("0s            30s             60s") + "\n\n"
+	content += timeAxis + "\n\n"
 
 	// Search section
-	content += metricHeaderStyle.Render("Search Rate") + "\n"
+	content += metricHeaderStyle.Render("Search Rate (queries on primaries)") + "\n"
 	content += renderMetricStats(searchSummary) + "\n\n"
 
 	// Render search rate graph
-	searchGraph := renderMetricsGraph(dataPoints, "search", graphWidth, graphHeight)
+	searchGraph := renderMetricsRangeGraph(buckets, "search", graphWidth, graphHeight)
 	content += searchGraph + "\n"
-	content += subtleStyle.Render("0s            30s             60s") + "\n\n"
+	content += timeAxis + "\n\n"
+
+	// Latency sections, averaged per operation over each interval
+	content += a.renderMetricSection("Query Latency (ms/query)", "query_latency", "ms", buckets, graphWidth, graphHeight)
+	content += a.renderMetricSection("Fetch Latency (ms/fetch)", "fetch_latency", "ms", buckets, graphWidth, graphHeight)
+	content += a.renderMetricSection("Indexing Latency (ms/document)", "index_latency", "ms", buckets, graphWidth, graphHeight)
+
+	// Rejections and open search contexts
+	if n := len(a.metricsTimeSeries.DataPoints); n > 0 && a.metricsTimeSeries.DataPoints[n-1].RejectedUnknown {
+		content += metricHeaderStyle.Render("Rejected Requests (thread pools, all nodes)") + "\n"
+		content += subtleStyle.Render("Rejection rate unavailable: thread pool stats could not be read") + "\n\n"
+	} else {
+		content += a.renderMetricSection("Rejected Requests (thread pools, all nodes)", "rejected", "/s", buckets, graphWidth, graphHeight/2)
+	}
+	content += a.renderMetricSection("Open Scroll Contexts", "scroll", "", buckets, graphWidth, graphHeight/2)
+	content += a.renderMetricSection("Open Point-in-Time Contexts", "pit", "", buckets, graphWidth, graphHeight/2)
+
+	// Indices pinned from the Top Indices view
+	for _, index := range a.pinnedIndices {
+		content += a.renderPinnedIndexMetrics(index, graphWidth, graphHeight/2)
+	}
 
 	// Footer with info
 	lastUpdate := "Never"
@@ -62,48 +178,96 @@ func (a *App) renderMetricsView() string {
 		a.metricsTimeSeries.Size(),
 		timeRange.Seconds(),
 	)
+	if buckets := len(a.metricsTimeSeries.Buckets); buckets > 0 {
+		footer += fmt.Sprintf("  │  Older samples: %d buckets (graphs show averages, with bucket min and max as dim lines)", buckets)
+	}
 	content += subtleStyle.Render(footer) + "\n"
 
 	return content
 }
 
-// renderMetricStats renders statistics for a metric (current, average, peak)
+// renderTimeAxis labels a graph's x axis from the oldest data point to now
+func renderTimeAxis(span time.Duration, width int) string {
+	left := "-" + shortDuration(span.Round(time.Second))
+	return subtleStyle.Render(left + strings.Repeat(" ", max(width-len(left)-len("now"), 1)) + "now")
+}
+
+// renderMetricSection renders a metric's statistics over its graph
+func (a *App) renderMetricSection(title, metricType, unit string, buckets []MetricsBucket, width, height int) string {
+	content := metricHeaderStyle.Render(title) + "\n"
+	content += renderMetricStatsWithUnit(a.metricsTimeSeries.CalculateSummary(metricType), unit) + "\n\n"
+	content += renderMetricsRangeGraph(buckets, metricType, width, height) + "\n\n"
+	return content
+}
+
+// renderPinnedIndexMetrics renders a pinned index's indexing and search
+// rates under the cluster graphs
+func (a *App) renderPinnedIndexMetrics(index string, width, height int) string {
+	content := metricHeaderStyle.Render("Pinned Index: "+index) + "\n"
+
+	series, ok := a.pinnedSeries[index]
+	if !ok || series.Size() == 0 {
+		return content + subtleStyle.Render("Collecting index metrics...") + "\n\n"
+	}
+	buckets := series.WindowBuckets()
+
+	content += "Indexing            " + renderMetricStats(series.CalculateSummary("insert")) + "\n"
+	content += renderMetricsRangeGraph(buckets, "insert", width, height) + "\n"
+	content += "Search (primaries)  " + renderMetricStats(series.CalculateSummary("search")) + "\n"
+	content += renderMetricsRangeGraph(buckets, "search", width, height) + "\n"
+	content += renderTimeAxis(series.GetTimeRange(), width) + "\n\n"
+	return content
+}
+
+// renderMetricStats renders statistics for a metric (current, average, min, peak)
 func renderMetricStats(summary MetricsSummary) string {
-	return fmt.Sprintf("Current: %s/s  │  Average: %s/s  │  Peak: %s/s",
-		highlightStyle.Render(formatMetricNumber(summary.Current)),
-		statsStyle.Render(formatMetricNumber(summary.Average)),
-		highlightStyle.Render(formatMetricNumber(summary.Peak)),
+	return renderMetricStatsWithUnit(summary, "/s")
+}
+
+// renderMetricStatsWithUnit renders statistics for a metric measured in unit
+func renderMetricStatsWithUnit(summary MetricsSummary, unit string) string {
+	return fmt.Sprintf("Current: %s%s  │  Average: %s%s  │  Min: %s%s  │  Peak: %s%s",
+		highlightStyle.Render(formatMetricNumber(summary.Current)), unit,
+		statsStyle.Render(formatMetricNumber(summary.Average)), unit,
+		statsStyle.Render(formatMetricNumber(summary.Min)), unit,
+		highlightStyle.Render(formatMetricNumber(summary.Peak)), unit,
 	)
 }
 
 // renderMetricsGraph creates a line chart using ntcharts
 func renderMetricsGraph(dataPoints []MetricsDataPoint, metricType string, width, height int) string {
-	if len(dataPoints) == 0 {
+	buckets := make([]MetricsBucket, 0, len(dataPoints))
+	for _, dp := range dataPoints {
+		buckets = append(buckets, MetricsBucket{Count: 1, Min: dp, Avg: dp, Max: dp})
+	}
+	return renderMetricsRangeGraph(buckets, metricType, width, height)
+}
+
+// renderMetricsRangeGraph graphs every bucket's average, with the min and max
+// of downsampled buckets as dim lines so short spikes stay visible
+func renderMetricsRangeGraph(buckets []MetricsBucket, metricType string, width, height int) string {
+	if len(buckets) == 0 {
 		return subtleStyle.Render("No data")
 	}
 
 	// Extract values
-	var values []float64
-	for _, dp := range dataPoints {
-		var val float64
-		switch metricType {
-		case "insert":
-			val = dp.InsertRate
-		case "search":
-			val = dp.SearchRate
-		}
-		values = append(values, val)
+	var values, lows, highs []float64
+	downsampled := false
+	for _, bucket := range buckets {
+		avg, _ := bucket.Avg.metric(metricType)
+		low, _ := bucket.Min.metric(metricType)
+		high, _ := bucket.Max.metric(metricType)
+		values = append(values, avg)
+		lows = append(lows, low)
+		highs = append(highs, high)
+		downsampled = downsampled || bucket.Count > 1
 	}
 
 	// Find min/max for Y axis
-	minY, maxY := values[0], values[0]
-	for _, v := range values {
-		if v < minY {
-			minY = v
-		}
-		if v > maxY {
-			maxY = v
-		}
+	minY, maxY := lows[0], highs[0]
+	for i := range values {
+		minY = min(minY, lows[i])
+		maxY = max(maxY, highs[i])
 	}
 
 	// Add padding to Y axis range
@@ -120,9 +284,9 @@ func renderMetricsGraph(dataPoints []MetricsDataPoint, metricType string, width,
 		minY = 0
 	}
 
-	// X axis goes from 0 to len(dataPoints)-1
+	// X axis goes from 0 to len(buckets)-1
 	minX := 0.0
-	maxX := float64(len(dataPoints) - 1)
+	maxX := float64(len(buckets) - 1)
 	if maxX == 0 {
 		maxX = 1 // Prevent zero range
 	}
@@ -130,7 +294,17 @@ func renderMetricsGraph(dataPoints []MetricsDataPoint, metricType string, width,
 	// Create linechart
 	lc := linechart.New(width, height, minX, maxX, minY, maxY)
 
-	// Draw lines connecting data points using Braille
+	// Draw lines connecting data points using Braille, the average last so
+	// it stays on top where the lines meet
+	if downsampled {
+		for _, bound := range [][]float64{lows, highs} {
+			for i := 0; i < len(bound)-1; i++ {
+				p1 := canvas.Float64Point{X: float64(i), Y: bound[i]}
+				p2 := canvas.Float64Point{X: float64(i + 1), Y: bound[i+1]}
+				lc.DrawBrailleLineWithStyle(p1, p2, subtleStyle)
+			}
+		}
+	}
 	for i := 0; i < len(values)-1; i++ {
 		p1 := canvas.Float64Point{X: float64(i), Y: values[i]}
 		p2 := canvas.Float64Point{X: float64(i + 1), Y: values[i+1]}
@@ -23,6 +23,23 @@ func TestApp_RenderMetricsView_EmptyData(t *testing.T) {
 	}
 }
 
+func TestApp_RenderMetricsView_RejectionsUnavailable(t *testing.T) {
+	app := &App{
+		metricsTimeSeries: NewMetricsTimeSeries(12),
+		lastMetricsUpdate: time.Now(),
+	}
+	app.metricsTimeSeries.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(0, 0), RejectedUnknown: true})
+	app.metricsTimeSeries.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(5, 0), IndexTotal: 500})
+
+	result := app.renderMetricsView()
+	if !strings.Contains(result, "Rejection rate unavailable") {
+		t.Error("Should say the rejection rate is unavailable without thread pool stats")
+	}
+	if !strings.Contains(result, "Indexing Rate") {
+		t.Error("Should still graph the other metrics")
+	}
+}
+
 func TestApp_RenderMetricsView_WithData(t *testing.T) {
 	app := &App{
 		metricsTimeSeries: NewMetricsTimeSeries(12),
@@ -52,6 +69,12 @@ func TestApp_RenderMetricsView_WithData(t *testing.T) {
 		t.Error("Should show Search Rate header")
 	}
 
+	for _, header := range []string{"Query Latency", "Fetch Latency", "Indexing Latency", "Rejected Requests", "Open Scroll Contexts", "Open Point-in-Time Contexts"} {
+		if !strings.Contains(result, header) {
+			t.Errorf("Should show %s header", header)
+		}
+	}
+
 	// Should show statistics
 	if !strings.Contains(result, "Current:") {
 		t.Error("Should show Current statistic")
@@ -101,6 +124,47 @@ func TestRenderMetricStats(t *testing.T) {
 	if !strings.Contains(result, "Peak:") {
 		t.Error("Should have Peak label")
 	}
+
+	if !strings.Contains(result, "Min: 500") {
+		t.Errorf("Should show the minimum, got: %s", result)
+	}
+}
+
+func TestRenderMetricsRangeGraph(t *testing.T) {
+	var buckets []MetricsBucket
+	var averages []MetricsDataPoint
+	for i := 0; i < 10; i++ {
+		avg := MetricsDataPoint{InsertRate: 10}
+		buckets = append(buckets, MetricsBucket{Count: 60, Min: MetricsDataPoint{InsertRate: 5}, Avg: avg, Max: MetricsDataPoint{InsertRate: 10}})
+		averages = append(averages, avg)
+	}
+	buckets[3].Max.InsertRate = 100 // A spike the average hides
+
+	ranged := renderMetricsRangeGraph(buckets, "insert", 40, 8)
+	if ranged == renderMetricsGraph(averages, "insert", 40, 8) {
+		t.Error("Downsampled buckets should draw their min and max around the average")
+	}
+
+	// Full-resolution buckets have nothing to draw besides the line
+	for i := range buckets {
+		buckets[i].Count = 1
+	}
+	if renderMetricsRangeGraph(buckets[:1], "insert", 40, 8) != renderMetricsGraph(averages[:1], "insert", 40, 8) {
+		t.Error("Single data point buckets should graph like plain data points")
+	}
+}
+
+func TestRenderMetricStatsWithUnit(t *testing.T) {
+	result := renderMetricStatsWithUnit(MetricsSummary{Current: 12, Average: 8, Peak: 30}, "ms")
+
+	for _, exp := range []string{"12ms", "8ms", "30ms"} {
+		if !strings.Contains(result, exp) {
+			t.Errorf("Expected %q in %s", exp, result)
+		}
+	}
+	if strings.Contains(result, "/s") {
+		t.Errorf("Latency stats should not be shown per second: %s", result)
+	}
 }
 
 func TestRenderMetricsGraph_EmptyData(t *testing.T) {
@@ -464,3 +528,59 @@ func TestRenderMetricsGraph_ConsistentOutput(t *testing.T) {
 		t.Error("renderMetricsGraph should produce consistent output for same input")
 	}
 }
+
+func TestShortDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "30s"},
+		{time.Minute, "1m"},
+		{15 * time.Minute, "15m"},
+		{90 * time.Second, "1m30s"},
+		{time.Hour, "1h"},
+		{90 * time.Minute, "1h30m"},
+	}
+
+	for _, tt := range tests {
+		if got := shortDuration(tt.d); got != tt.want {
+			t.Errorf("shortDuration(%s) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestNextDuration(t *testing.T) {
+	if got := nextDuration(metricsWindows, 15*time.Minute); got != time.Hour {
+		t.Errorf("nextDuration(15m) = %s, want 1h", got)
+	}
+	if got := nextDuration(metricsWindows, time.Hour); got != time.Minute {
+		t.Errorf("nextDuration(1h) = %s, want to wrap to 1m", got)
+	}
+	if got := nextDuration(metricsIntervals, 3*time.Second); got != time.Second {
+		t.Errorf("nextDuration(3s) = %s, want the first option", got)
+	}
+}
+
+func TestApp_RenderMetricsView_Downsampled(t *testing.T) {
+	app := &App{
+		metricsTimeSeries: NewMetricsWindow(2, time.Hour),
+		metricsInterval:   time.Second,
+	}
+	for i := int64(0); i <= 120; i++ {
+		app.metricsTimeSeries.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(i, 0), IndexTotal: i * 10})
+	}
+
+	result := app.renderMetricsView()
+	expected := []string{
+		"(Last 1h, sampled every 1s)",
+		"w: Window | i: Sample interval",
+		"-1m59s", // First data point is at 1s
+		"now",
+		"Older samples: 2 buckets",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("Expected output to contain %q", exp)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// keyNodeThreadPools are always shown in the node detail; other pools only
+// when they're busy or have rejected work
+var keyNodeThreadPools = map[string]bool{
+	"search":     true,
+	"write":      true,
+	"get":        true,
+	"management": true,
+}
+
+// renderNodeDetailView renders the drill-down for a single node
+func (a *App) renderNodeDetailView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Node: %s", a.selectedNodeName)))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Press Esc or Backspace to return to nodes list"))
+	b.WriteString("\n\n")
+
+	if a.nodeDetailErr != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Failed to load node stats: %v", a.nodeDetailErr)))
+		b.WriteString("\n\n")
+	}
+	if a.nodeDetail == nil {
+		if a.nodeDetailErr == nil {
+			b.WriteString(labelStyle.Render("Loading node stats..."))
+		}
+		return b.String()
+	}
+
+	detail := a.nodeDetail
+	stats := detail.Stats
+
+	// Overview
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Node ID:     "), detail.ID))
+	b.WriteString(fmt.Sprintf("%s %s (%s)\n", labelStyle.Render("Host:        "), stats.Host, stats.IP))
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Roles:       "), strings.Join(stats.Roles, ", ")))
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("OpenSearch:  "), valueStyle.Render(detail.Version)))
+	b.WriteString(fmt.Sprintf("%s %s %s\n", labelStyle.Render("JVM:         "), detail.JVMVersion, labelStyle.Render(detail.JVMVMName)))
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Uptime:      "), formatUptime(stats.JVM.UptimeInMillis)))
+	b.WriteString("\n")
+
+	a.renderNodeHeap(&b, stats)
+	a.renderNodeGC(&b, stats)
+	a.renderNodeFileDescriptors(&b, stats)
+	a.renderNodeBreakers(&b, stats)
+	a.renderNodeThreadPools(&b, stats)
+	a.renderNodeOperations(&b, stats)
+	a.renderNodeNetwork(&b, stats)
+
+	return b.String()
+}
+
+// renderNodeHeap renders overall heap usage and the per-pool breakdown
+func (a *App) renderNodeHeap(b *strings.Builder, stats source.NodeStats) {
+	mem := stats.JVM.Mem
+
+	b.WriteString(headerStyle.Render("JVM Heap"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s %s (%s / %s)\n",
+		labelStyle.Render("Heap:"),
+		renderBar(fmt.Sprintf("%d", mem.HeapUsedPercent), 20),
+		valueStyle.Render(fmt.Sprintf("%d%%", mem.HeapUsedPercent)),
+		formatBytes(mem.HeapUsedInBytes),
+		formatBytes(mem.HeapMaxInBytes)))
+
+	for _, name := range sortedKeys(mem.Pools) {
+		pool := mem.Pools[name]
+		line := fmt.Sprintf("  %-10s %10s", name, formatBytes(pool.UsedInBytes))
+		if pool.MaxInBytes > 0 {
+			line += fmt.Sprintf(" / %-10s", formatBytes(pool.MaxInBytes))
+		} else {
+			line += fmt.Sprintf(" / %-10s", "-")
+		}
+		line += labelStyle.Render(fmt.Sprintf("  peak %s", formatBytes(pool.PeakUsedInBytes)))
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+}
+
+// renderNodeGC renders garbage collection counts and times per collector
+func (a *App) renderNodeGC(b *strings.Builder, stats source.NodeStats) {
+	b.WriteString(headerStyle.Render("Garbage Collection"))
+	b.WriteString("\n")
+
+	collectors := stats.JVM.GC.Collectors
+	if len(collectors) == 0 {
+		b.WriteString(labelStyle.Render("No GC data available"))
+		b.WriteString("\n\n")
+		return
+	}
+
+	for _, name := range sortedKeys(collectors) {
+		gc := collectors[name]
+		avg := "-"
+		if gc.CollectionCount > 0 {
+			avg = fmt.Sprintf("%.1fms", float64(gc.CollectionTimeInMillis)/float64(gc.CollectionCount))
+		}
+		b.WriteString(fmt.Sprintf("  %-8s %s collections, %s total, %s avg\n",
+			name,
+			valueStyle.Render(formatNumber(gc.CollectionCount)),
+			formatMillis(gc.CollectionTimeInMillis),
+			avg))
+	}
+	b.WriteString("\n")
+}
+
+// renderNodeFileDescriptors renders open file descriptors against the limit
+func (a *App) renderNodeFileDescriptors(b *strings.Builder, stats source.NodeStats) {
+	proc := stats.Process
+
+	b.WriteString(headerStyle.Render("File Descriptors"))
+	b.WriteString("\n")
+	if proc.MaxFileDescriptors <= 0 {
+		b.WriteString(fmt.Sprintf("%s %s\n\n", labelStyle.Render("Open:"), formatNumber(proc.OpenFileDescriptors)))
+		return
+	}
+
+	percent := float64(proc.OpenFileDescriptors) / float64(proc.MaxFileDescriptors) * 100
+	b.WriteString(fmt.Sprintf("%s %s %s / %s\n\n",
+		labelStyle.Render("Open:"),
+		renderBar(fmt.Sprintf("%.1f", percent), 20),
+		valueStyle.Render(formatNumber(proc.OpenFileDescriptors)),
+		formatNumber(proc.MaxFileDescriptors)))
+}
+
+// renderNodeBreakers renders circuit breaker usage and trip counts
+func (a *App) renderNodeBreakers(b *strings.Builder, stats source.NodeStats) {
+	b.WriteString(headerStyle.Render("Circuit Breakers"))
+	b.WriteString("\n")
+
+	if len(stats.Breakers) == 0 {
+		b.WriteString(labelStyle.Render("No circuit breaker data available"))
+		b.WriteString("\n\n")
+		return
+	}
+
+	for _, name := range sortedKeys(stats.Breakers) {
+		breaker := stats.Breakers[name]
+		var percent float64
+		if breaker.LimitSizeInBytes > 0 {
+			percent = float64(breaker.EstimatedSizeInBytes) / float64(breaker.LimitSizeInBytes) * 100
+		}
+
+		tripped := fmt.Sprintf("tripped %d", breaker.Tripped)
+		if breaker.Tripped > 0 {
+			tripped = statusRed.Render(tripped)
+		} else {
+			tripped = labelStyle.Render(tripped)
+		}
+
+		b.WriteString(fmt.Sprintf("  %-20s %s %s / %s  %s\n",
+			name,
+			renderBar(fmt.Sprintf("%.1f", percent), 12),
+			formatBytes(breaker.EstimatedSizeInBytes),
+			formatBytes(breaker.LimitSizeInBytes),
+			tripped))
+	}
+	b.WriteString("\n")
+}
+
+// renderNodeThreadPools renders the node's key and busy thread pools
+func (a *App) renderNodeThreadPools(b *strings.Builder, stats source.NodeStats) {
+	b.WriteString(headerStyle.Render("Thread Pools"))
+	b.WriteString("\n")
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-22s %8s %8s %10s %14s", "Pool", "Active", "Queue", "Rejected", "Completed")))
+	b.WriteString("\n")
+
+	shown := 0
+	for _, name := range sortedKeys(stats.ThreadPool) {
+		pool := stats.ThreadPool[name]
+		if !keyNodeThreadPools[name] && pool.Active == 0 && pool.Queue == 0 && pool.Rejected == 0 {
+			continue
+		}
+		shown++
+
+		nameStyle := lipgloss.NewStyle()
+		if pool.Rejected > 0 {
+			nameStyle = statusRed
+		} else if pool.Queue > 0 {
+			nameStyle = statusYellow
+		}
+
+		b.WriteString(fmt.Sprintf("  %s %8s %8s %10s %14s\n",
+			nameStyle.Render(fmt.Sprintf("%-22s", name)),
+			fmt.Sprintf("%d/%d", pool.Active, pool.Threads),
+			formatNumber(pool.Queue),
+			formatNumber(pool.Rejected),
+			formatNumber(pool.Completed)))
+	}
+
+	if shown == 0 {
+		b.WriteString(labelStyle.Render("  No thread pool data available"))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// renderNodeOperations renders indexing and search totals for the node
+func (a *App) renderNodeOperations(b *strings.Builder, stats source.NodeStats) {
+	idx := stats.Indices.Indexing
+	search := stats.Indices.Search
+
+	b.WriteString(headerStyle.Render("Indexing & Search"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Documents:"), formatNumber(stats.Indices.Docs.Count)))
+	b.WriteString(fmt.Sprintf("%s %s ops, %s total, %s avg",
+		labelStyle.Render("Indexing: "),
+		valueStyle.Render(formatNumber(idx.IndexTotal)),
+		formatMillis(idx.IndexTimeInMillis),
+		averageMillis(idx.IndexTimeInMillis, idx.IndexTotal)))
+	if idx.IndexFailed > 0 {
+		b.WriteString(" " + statusRed.Render(fmt.Sprintf("(%s failed)", formatNumber(idx.IndexFailed))))
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s ops, %s total, %s avg\n",
+		labelStyle.Render("Query:    "),
+		valueStyle.Render(formatNumber(search.QueryTotal)),
+		formatMillis(search.QueryTimeInMillis),
+		averageMillis(search.QueryTimeInMillis, search.QueryTotal)))
+	b.WriteString(fmt.Sprintf("%s %s ops, %s total, %s avg\n",
+		labelStyle.Render("Fetch:    "),
+		valueStyle.Render(formatNumber(search.FetchTotal)),
+		formatMillis(search.FetchTimeInMillis),
+		averageMillis(search.FetchTimeInMillis, search.FetchTotal)))
+	b.WriteString(fmt.Sprintf("%s %d indexing, %d queries\n\n",
+		labelStyle.Render("In flight:"),
+		idx.IndexCurrent, search.QueryCurrent))
+}
+
+// renderNodeNetwork renders transport and HTTP connection stats
+func (a *App) renderNodeNetwork(b *strings.Builder, stats source.NodeStats) {
+	transport := stats.Transport
+
+	b.WriteString(headerStyle.Render("Connections"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s open, rx %s / tx %s\n",
+		labelStyle.Render("Transport:"),
+		valueStyle.Render(formatNumber(transport.ServerOpen)),
+		formatBytes(transport.RxSizeInBytes),
+		formatBytes(transport.TxSizeInBytes)))
+	b.WriteString(fmt.Sprintf("%s %s open, %s opened since start\n",
+		labelStyle.Render("HTTP:     "),
+		valueStyle.Render(formatNumber(stats.HTTP.CurrentOpen)),
+		formatNumber(stats.HTTP.TotalOpened)))
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+func TestRenderNodeDetailView(t *testing.T) {
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	detail, err := source.NewOpenSearch(client).NodeDetail(t.Context(), "node-1")
+	if err != nil {
+		t.Fatalf("NodeDetail() error = %v", err)
+	}
+
+	app := &App{selectedNodeName: "node-1", nodeDetail: detail}
+	result := app.renderNodeDetailView()
+
+	expected := []string{
+		"Node: node-1",
+		"2.11.0",   // OpenSearch version
+		"17.0.8",   // JVM version
+		"3d 4h 2m", // uptime
+		"survivor", // heap pool
+		"1,520",    // young GC count
+		"1,024",    // open file descriptors
+		"fielddata",
+		"tripped 2",
+		"write",
+		"500,000", // index total
+		"0.50ms",  // index avg
+		"26",      // transport connections
+		"1,500",   // HTTP opened
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderNodeDetailView() should contain %q", want)
+		}
+	}
+
+	if strings.Contains(result, "snapshot") {
+		t.Error("idle non-key thread pools should be hidden")
+	}
+}
+
+func TestRenderNodeDetailView_Loading(t *testing.T) {
+	app := &App{selectedNodeName: "node-1"}
+	if result := app.renderNodeDetailView(); !strings.Contains(result, "Loading node stats") {
+		t.Errorf("nil detail should show loading, got %q", result)
+	}
+}
+
+func TestSelectedNodeInfo_FollowsDisplayOrder(t *testing.T) {
+	app := &App{
+		nodes: []NodeInfo{
+			{Name: "data-1", NodeRole: "di"},
+			{Name: "master-1", NodeRole: "m"},
+			{Name: "coord-1", NodeRole: "-"},
+		},
+	}
+
+	// Dedicated masters are listed first, then data, then other nodes
+	want := []string{"master-1", "data-1", "coord-1"}
+	for i, name := range want {
+		app.selectedNode = i
+		node, ok := app.selectedNodeInfo()
+		if !ok || node.Name != name {
+			t.Errorf("selectedNode %d = %q, want %q", i, node.Name, name)
+		}
+	}
+
+	app.selectedNode = 3
+	if _, ok := app.selectedNodeInfo(); ok {
+		t.Error("out of range selection should return false")
+	}
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/linechart"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// nodeColors tell nodes apart on the overlay graphs; they repeat past eight
+// nodes
+var nodeColors = []lipgloss.Color{"42", "39", "201", "51", "226", "208", "141", "196"}
+
+// Metrics of a node's data point, shared by the Nodes view sparklines and
+// the overlay graphs
+func nodeIndexRate(r NodeRates) float64  { return r.IndexRate }
+func nodeSearchRate(r NodeRates) float64 { return r.SearchRate }
+func nodeHeapUsed(r NodeRates) float64   { return r.HeapUsedPercent }
+
+// nodeGraphMetrics are the metrics the Node Graphs view overlays, with the
+// smallest gap to the other nodes worth flagging a node for
+var nodeGraphMetrics = []struct {
+	title string
+	unit  string
+	floor float64
+	value func(NodeRates) float64
+}{
+	{"Indexing Rate (docs/second)", "/s", 10, nodeIndexRate},
+	{"Search Rate (queries/second)", "/s", 10, nodeSearchRate},
+	{"Heap Used (%)", "%", 10, nodeHeapUsed},
+	{"Old-Gen GC (collections/second)", "/s", 0.01, func(r NodeRates) float64 { return r.OldGCRate }},
+	{"Old-Gen GC Time (ms/second)", "ms/s", 10, func(r NodeRates) float64 { return r.OldGCTimeRate }},
+	{"CPU (%)", "%", 10, func(r NodeRates) float64 { return r.CPUPercent }},
+}
+
+// renderNodeGraphsView renders every node's metrics overlaid on one graph
+// per metric, flagging a node that stands out from the rest
+func (a *App) renderNodeGraphsView() string {
+	var content string
+
+	header := headerStyle.Render("Node Graphs") + " " + subtleStyle.Render(fmt.Sprintf("(Last %d refreshes)", a.nodeTimeSeries.maxDataPoints))
+	content += header + "\n"
+	content += dividerStyle.Render("────────────────────────────────────────────────────────────────") + "\n\n"
+
+	if a.nodeTimeSeries.Size() == 0 {
+		content += subtleStyle.Render("Collecting node metrics...") + "\n"
+		content += subtleStyle.Render("Graphs will appear after the next refresh") + "\n"
+		return content
+	}
+
+	nodes := a.nodeTimeSeries.Nodes()
+	content += renderNodeLegend(nodes) + "\n\n"
+
+	for _, metric := range nodeGraphMetrics {
+		content += metricHeaderStyle.Render(metric.title) + "\n"
+
+		latest := make(map[string]float64, len(nodes))
+		for _, node := range nodes {
+			if rates, ok := a.nodeTimeSeries.Latest(node); ok {
+				latest[node] = metric.value(rates)
+			}
+		}
+		if node, ok := outlierNode(latest, metric.floor); ok {
+			content += statusYellow.Render(fmt.Sprintf("⚠ %s stands out at %s%s", node, formatMetricNumber(latest[node]), metric.unit)) + "\n"
+		}
+
+		series := make([][]float64, len(nodes))
+		for i, node := range nodes {
+			series[i] = a.nodeTimeSeries.Series(node, metric.value)
+		}
+		content += renderNodeOverlayGraph(series, 68, 6) + "\n\n"
+	}
+
+	return content
+}
+
+// renderNodeLegend names each node in the colour of its line
+func renderNodeLegend(nodes []string) string {
+	var legend strings.Builder
+	for i, node := range nodes {
+		if i > 0 {
+			legend.WriteString("  ")
+		}
+		style := lipgloss.NewStyle().Foreground(nodeColors[i%len(nodeColors)])
+		legend.WriteString(style.Render("● " + node))
+	}
+	return legend.String()
+}
+
+// renderNodeOverlayGraph draws one line per node on a shared line chart
+func renderNodeOverlayGraph(series [][]float64, width, height int) string {
+	maxValue := 0.0
+	points := 0
+	for _, values := range series {
+		points = max(points, len(values))
+		for _, v := range values {
+			maxValue = max(maxValue, v)
+		}
+	}
+	if points == 0 {
+		return subtleStyle.Render("No data")
+	}
+
+	// Add padding to Y axis range
+	yRange := maxValue
+	if yRange == 0 {
+		yRange = 1 // Prevent zero range
+	}
+	maxY := maxValue + yRange*0.1
+
+	// X axis goes from 0 to the number of data points - 1
+	maxX := float64(points - 1)
+	if maxX == 0 {
+		maxX = 1 // Prevent zero range
+	}
+
+	lc := linechart.New(width, height, 0, maxX, 0, maxY)
+	for i, values := range series {
+		style := lipgloss.NewStyle().Foreground(nodeColors[i%len(nodeColors)])
+		for j := 0; j < len(values)-1; j++ {
+			p1 := canvas.Float64Point{X: float64(j), Y: values[j]}
+			p2 := canvas.Float64Point{X: float64(j + 1), Y: values[j+1]}
+			lc.DrawBrailleLineWithStyle(p1, p2, style)
+		}
+	}
+	return lc.View()
+}
+
+// outlierNode returns the node whose latest value is at least twice the
+// median of the other nodes and more than floor above it
+func outlierNode(latest map[string]float64, floor float64) (string, bool) {
+	if len(latest) < 2 {
+		return "", false
+	}
+
+	top := ""
+	for node, value := range latest {
+		if top == "" || value > latest[top] || (value == latest[top] && node < top) {
+			top = node
+		}
+	}
+
+	others := make([]float64, 0, len(latest)-1)
+	for node, value := range latest {
+		if node != top {
+			others = append(others, value)
+		}
+	}
+	sort.Float64s(others)
+	median := others[len(others)/2]
+	if len(others)%2 == 0 {
+		median = (others[len(others)/2-1] + others[len(others)/2]) / 2
+	}
+
+	if latest[top] >= 2*median && latest[top]-median > floor {
+		return top, true
+	}
+	return "", false
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOutlierNode(t *testing.T) {
+	tests := []struct {
+		name   string
+		latest map[string]float64
+		floor  float64
+		want   string
+	}{
+		{"single node", map[string]float64{"node-1": 90}, 10, ""},
+		{"even load", map[string]float64{"node-1": 40, "node-2": 45, "node-3": 50}, 10, ""},
+		{"one hot node", map[string]float64{"node-1": 20, "node-2": 95, "node-3": 25}, 10, "node-2"},
+		{"gap below floor", map[string]float64{"node-1": 1, "node-2": 4, "node-3": 1}, 10, ""},
+		{"idle cluster", map[string]float64{"node-1": 0, "node-2": 0}, 0.01, ""},
+		{"median of even others", map[string]float64{"a": 10, "b": 30, "c": 50, "d": 100}, 10, "d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := outlierNode(tt.latest, tt.floor)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("outlierNode() = %q, %v, want %q", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderNodeGraphsView(t *testing.T) {
+	app := &App{nodeTimeSeries: NewNodeTimeSeries(12)}
+	if result := app.renderNodeGraphsView(); !strings.Contains(result, "Collecting node metrics") {
+		t.Errorf("renderNodeGraphsView() without data = %q", result)
+	}
+
+	app.nodeTimeSeries.AddSnapshot(newNodeActivitySnapshot([]NodeActivity{
+		{Name: "node-1"}, {Name: "node-2"}, {Name: "node-3"},
+	}, time.Unix(0, 0)))
+	app.nodeTimeSeries.AddSnapshot(newNodeActivitySnapshot([]NodeActivity{
+		{Name: "node-1", IndexTotal: 500, HeapUsedInBytes: 40, HeapMaxInBytes: 100, CPUPercent: 20},
+		{Name: "node-2", IndexTotal: 550, HeapUsedInBytes: 92, HeapMaxInBytes: 100, CPUPercent: 25},
+		{Name: "node-3", IndexTotal: 450, HeapUsedInBytes: 35, HeapMaxInBytes: 100, CPUPercent: 22},
+	}, time.Unix(5, 0)))
+	result := app.renderNodeGraphsView()
+
+	expected := []string{
+		"Node Graphs",
+		"(Last 12 refreshes)",
+		"● node-1", "● node-2", "● node-3",
+		"Indexing Rate (docs/second)",
+		"Old-Gen GC Time (ms/second)",
+		"CPU (%)",
+		"node-2 stands out at 92%",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("Expected output to contain %q", exp)
+		}
+	}
+	if n := strings.Count(result, "stands out"); n != 1 {
+		t.Errorf("%d nodes flagged, want only node-2's heap", n)
+	}
+}
+
+func TestRenderNode_Trend(t *testing.T) {
+	node := NodeInfo{Name: "node-1", HeapPercent: "50", RAMPercent: "60", CPU: "10"}
+	app := &App{nodeTimeSeries: NewNodeTimeSeries(12)}
+	var b strings.Builder
+	app.renderNode(&b, node)
+	if strings.Contains(b.String(), "Search:") {
+		t.Error("A node without rates should not render a trend line")
+	}
+
+	app.nodeTimeSeries.AddSnapshot(newNodeActivitySnapshot([]NodeActivity{{Name: "node-1"}}, time.Unix(0, 0)))
+	app.nodeTimeSeries.AddSnapshot(newNodeActivitySnapshot([]NodeActivity{
+		{Name: "node-1", IndexTotal: 1000, QueryTotal: 50, HeapUsedInBytes: 3, HeapMaxInBytes: 4},
+	}, time.Unix(10, 0)))
+	b.Reset()
+	app.renderNode(&b, node)
+	for _, exp := range []string{"Index:", "100/s", "Search:", "5/s", "Heap:", "75%"} {
+		if !strings.Contains(b.String(), exp) {
+			t.Errorf("Expected trend line to contain %q", exp)
+		}
+	}
+}
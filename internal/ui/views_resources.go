@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/vegasq/ostop/internal/check"
 )
 
 // renderResourcesView renders the resource utilization dashboard
@@ -120,41 +121,10 @@ func (a *App) renderResourcesView() string {
 
 	hotspots := []string{}
 	for _, node := range a.nodes {
-		var heap, cpu, ram, disk float64
-		fmt.Sscanf(node.HeapPercent, "%f", &heap)
-		fmt.Sscanf(node.CPU, "%f", &cpu)
-		fmt.Sscanf(node.RAMPercent, "%f", &ram)
-		fmt.Sscanf(node.DiskUsedPercent, "%f", &disk)
-
-		if heap >= 90 || cpu >= 90 || ram >= 90 || disk >= 90 {
-			reason := ""
-			if heap >= 90 {
-				reason += fmt.Sprintf("Heap: %.0f%% ", heap)
-			}
-			if cpu >= 90 {
-				reason += fmt.Sprintf("CPU: %.0f%% ", cpu)
-			}
-			if ram >= 90 {
-				reason += fmt.Sprintf("RAM: %.0f%% ", ram)
-			}
-			if disk >= 90 {
-				reason += fmt.Sprintf("Disk: %.0f%% ", disk)
-			}
+		switch status, reason := check.NodeHotspot(node); status {
+		case check.Critical:
 			hotspots = append(hotspots, fmt.Sprintf("%s - %s", node.Name, statusRed.Render(reason)))
-		} else if heap >= 75 || cpu >= 75 || ram >= 75 || disk >= 85 {
-			reason := ""
-			if heap >= 75 {
-				reason += fmt.Sprintf("Heap: %.0f%% ", heap)
-			}
-			if cpu >= 75 {
-				reason += fmt.Sprintf("CPU: %.0f%% ", cpu)
-			}
-			if ram >= 75 {
-				reason += fmt.Sprintf("RAM: %.0f%% ", ram)
-			}
-			if disk >= 85 {
-				reason += fmt.Sprintf("Disk: %.0f%% ", disk)
-			}
+		case check.Warning:
 			hotspots = append(hotspots, fmt.Sprintf("%s - %s", node.Name, statusYellow.Render(reason)))
 		}
 	}
@@ -199,25 +169,18 @@ func (a *App) renderResourcesView() string {
 	return b.String()
 }
 
-// renderIndexSchemaView renders the schema/mapping for a specific index
-func (a *App) renderIndexSchemaView() string {
-	var b strings.Builder
-
-	b.WriteString(headerStyle.Render(fmt.Sprintf("Index Schema: %s", a.selectedIndexName)))
-	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("Press Esc or Backspace to return to indices list"))
-	b.WriteString("\n\n")
-
+// renderIndexMapping renders the Schema tab of the index drill-down
+func (a *App) renderIndexMapping(b *strings.Builder) {
 	if a.indexMapping == nil {
 		b.WriteString(labelStyle.Render("Loading mapping..."))
-		return b.String()
+		return
 	}
 
 	// Extract properties from mappings
 	properties, ok := a.indexMapping.Mappings["properties"].(map[string]interface{})
 	if !ok {
 		b.WriteString(errorStyle.Render("No properties found in mapping"))
-		return b.String()
+		return
 	}
 
 	// Count total fields
@@ -226,9 +189,7 @@ func (a *App) renderIndexSchemaView() string {
 	b.WriteString("\n\n")
 
 	// Render fields recursively
-	a.renderFields(&b, properties, 0)
-
-	return b.String()
+	a.renderFields(b, properties, 0)
 }
 
 // countFields recursively counts the number of fields in the mapping
@@ -0,0 +1,281 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// maxReasonWidth truncates decider explanations in the blocking reasons
+// summary; the drill-down shows them in full
+const maxReasonWidth = 90
+
+// problemShards returns the unassigned and initializing shard copies,
+// unassigned first, in the order the Shards view lists them
+func (a *App) problemShards() []ShardInfo {
+	var shards []ShardInfo
+	for _, shard := range a.shards {
+		if shard.State == "UNASSIGNED" || shard.State == "INITIALIZING" {
+			shards = append(shards, shard)
+		}
+	}
+
+	sort.SliceStable(shards, func(i, j int) bool {
+		if shards[i].State != shards[j].State {
+			return shards[i].State == "UNASSIGNED"
+		}
+		if shards[i].Index != shards[j].Index {
+			return shards[i].Index < shards[j].Index
+		}
+		si, _ := strconv.Atoi(shards[i].Shard)
+		sj, _ := strconv.Atoi(shards[j].Shard)
+		if si != sj {
+			return si < sj
+		}
+		return shards[i].Prirep == "p" && shards[j].Prirep != "p"
+	})
+	return shards
+}
+
+// selectedProblemShard returns the shard copy under the Shards view cursor
+func (a *App) selectedProblemShard() (ShardInfo, bool) {
+	shards := a.problemShards()
+	if len(shards) == 0 {
+		return ShardInfo{}, false
+	}
+	if a.selectedShard < 0 || a.selectedShard >= len(shards) {
+		a.selectedShard = 0
+	}
+	return shards[a.selectedShard], true
+}
+
+// sameShardCopy reports whether a and b identify the same shard copy as far
+// as the allocation explain API is concerned
+func sameShardCopy(a, b ShardInfo) bool {
+	return a.Index == b.Index && a.Shard == b.Shard && (a.Prirep == "p") == (b.Prirep == "p")
+}
+
+// shardLabel names a shard copy, e.g. "logs[0] primary"
+func shardLabel(shard ShardInfo) string {
+	kind := "replica"
+	if shard.Prirep == "p" {
+		kind = "primary"
+	}
+	return fmt.Sprintf("%s[%s] %s", shard.Index, shard.Shard, kind)
+}
+
+// blockingReason is one allocation decider and how many explained shards it
+// blocks
+type blockingReason struct {
+	decider string
+	shards  int
+	example string
+}
+
+// blockingReasons counts, per decider, the explained shards it refuses on at
+// least one node. Shards without a NO decider are counted under their
+// overall can_allocate decision, e.g. no_valid_shard_copy.
+func blockingReasons(report *UnassignedReport) []blockingReason {
+	if report == nil {
+		return nil
+	}
+
+	counts := make(map[string]*blockingReason)
+	add := func(decider, example string) {
+		reason, ok := counts[decider]
+		if !ok {
+			reason = &blockingReason{decider: decider, example: example}
+			counts[decider] = reason
+		}
+		reason.shards++
+	}
+
+	for _, explanation := range report.Explanations {
+		seen := make(map[string]bool)
+		for _, node := range explanation.NodeDecisions {
+			for _, decider := range node.Deciders {
+				if decider.Decision != "NO" || seen[decider.Decider] {
+					continue
+				}
+				seen[decider.Decider] = true
+				add(decider.Decider, decider.Explanation)
+			}
+		}
+		if len(seen) == 0 && explanation.CanAllocate != "" && explanation.CanAllocate != "yes" {
+			add(explanation.CanAllocate, explanation.AllocateExplanation)
+		}
+	}
+
+	reasons := make([]blockingReason, 0, len(counts))
+	for _, reason := range counts {
+		reasons = append(reasons, *reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if reasons[i].shards != reasons[j].shards {
+			return reasons[i].shards > reasons[j].shards
+		}
+		return reasons[i].decider < reasons[j].decider
+	})
+	return reasons
+}
+
+// renderProblemShards renders the Shards view's unassigned and initializing
+// shards with a cursor, plus the top reasons shards can't be allocated
+func (a *App) renderProblemShards(b *strings.Builder) {
+	shards := a.problemShards()
+	if len(shards) == 0 {
+		return
+	}
+
+	unassigned := 0
+	for _, shard := range shards {
+		if shard.State == "UNASSIGNED" {
+			unassigned++
+		}
+	}
+	if unassigned > 0 {
+		b.WriteString(statusRed.Render(fmt.Sprintf("⚠ Unassigned Shards: %d", unassigned)))
+		b.WriteString("\n")
+	}
+	if initializing := len(shards) - unassigned; initializing > 0 {
+		b.WriteString(statusYellow.Render(fmt.Sprintf("⚠ Initializing Shards: %d", initializing)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if reasons := blockingReasons(a.unassigned); len(reasons) > 0 {
+		b.WriteString(valueStyle.Render("Top blocking reasons"))
+		b.WriteString(labelStyle.Render(fmt.Sprintf(" (%d of %d unassigned shards explained",
+			len(a.unassigned.Explanations), a.unassigned.Total)))
+		if updated, ok := a.sourceUpdated[SourceUnassigned]; ok {
+			b.WriteString(labelStyle.Render(fmt.Sprintf(" at %s, refreshed every %s or on r",
+				updated.Format("15:04:05"), shortDuration(slowSources[SourceUnassigned]))))
+		}
+		b.WriteString(labelStyle.Render(")"))
+		b.WriteString("\n")
+		for _, reason := range reasons {
+			example := reason.example
+			if len(example) > maxReasonWidth {
+				example = example[:maxReasonWidth-3] + "..."
+			}
+			b.WriteString(fmt.Sprintf("  %s %s  %s\n",
+				statusRed.Render(fmt.Sprintf("%-22s", reason.decider)),
+				fmt.Sprintf("%4d shards", reason.shards),
+				labelStyle.Render(example)))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(helpStyle.Render("Press Enter to explain the selected shard's allocation"))
+	b.WriteString("\n")
+
+	a.selectedProblemShard() // Clamps the cursor
+	for i, shard := range shards {
+		cursor := "  "
+		if i == a.selectedShard {
+			cursor = statusGreen.Render("▶ ")
+		}
+
+		state := statusRed.Render(fmt.Sprintf("%-12s", shard.State))
+		if shard.State == "INITIALIZING" {
+			state = statusYellow.Render(fmt.Sprintf("%-12s", shard.State))
+		}
+		b.WriteString(fmt.Sprintf("%s%s %-40s %s\n", cursor, state, shardLabel(shard), orDash(shard.Node)))
+	}
+	b.WriteString("\n")
+}
+
+// renderShardExplainView renders the allocation explain drill-down for one
+// shard copy
+func (a *App) renderShardExplainView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Shard Allocation: %s", shardLabel(a.explainShard))))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Press Esc or Backspace to return to shards list"))
+	b.WriteString("\n\n")
+
+	if a.shardExplainErr != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Failed to explain allocation: %v", a.shardExplainErr)))
+		b.WriteString("\n\n")
+	}
+	if a.shardExplain == nil {
+		if a.shardExplainErr == nil {
+			b.WriteString(labelStyle.Render("Loading allocation explanation..."))
+		}
+		return b.String()
+	}
+
+	explain := a.shardExplain
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("State:      "), valueStyle.Render(explain.CurrentState)))
+	if explain.CurrentNode != nil {
+		b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Node:       "), explain.CurrentNode.Name))
+	}
+	if info := explain.UnassignedInfo; info != nil {
+		b.WriteString(fmt.Sprintf("%s %s %s\n", labelStyle.Render("Unassigned: "), statusYellow.Render(info.Reason), labelStyle.Render("at "+info.At)))
+		if info.Details != "" {
+			b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Details:    "), info.Details))
+		}
+		if info.FailedAllocationAttempts > 0 {
+			b.WriteString(fmt.Sprintf("%s %d\n", labelStyle.Render("Failed:     "), info.FailedAllocationAttempts))
+		}
+		if info.LastAllocationStatus != "" {
+			b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Last status:"), info.LastAllocationStatus))
+		}
+	}
+	b.WriteString("\n")
+
+	// The overall decision
+	if explain.CanAllocate != "" {
+		b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Can allocate:"), renderDecision(explain.CanAllocate)))
+		if explain.AllocateExplanation != "" {
+			b.WriteString(explain.AllocateExplanation + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if explain.CanRemainOnCurrentNode != "" {
+		b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Can remain on current node:"), renderDecision(explain.CanRemainOnCurrentNode)))
+		renderDeciders(&b, explain.CanRemainDecisions)
+		if explain.Explanation != "" {
+			b.WriteString(explain.Explanation + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(explain.NodeDecisions) > 0 {
+		b.WriteString(headerStyle.Render(fmt.Sprintf("Node Decisions (%d)", len(explain.NodeDecisions))))
+		b.WriteString("\n")
+		for _, node := range explain.NodeDecisions {
+			b.WriteString(fmt.Sprintf("%s %s\n", valueStyle.Render(node.NodeName), renderDecision(node.NodeDecision)))
+			renderDeciders(&b, node.Deciders)
+		}
+	}
+
+	return b.String()
+}
+
+// renderDeciders renders the deciders that didn't say YES
+func renderDeciders(b *strings.Builder, deciders []source.AllocationDecider) {
+	for _, decider := range deciders {
+		if decider.Decision == "YES" {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("    %s %s\n", renderDecision(decider.Decision), valueStyle.Render(decider.Decider)))
+		b.WriteString(fmt.Sprintf("      %s\n", labelStyle.Render(decider.Explanation)))
+	}
+}
+
+// renderDecision colours an allocation decision such as yes, no or throttled
+func renderDecision(decision string) string {
+	switch strings.ToLower(decision) {
+	case "yes":
+		return statusGreen.Render(decision)
+	case "no", "no_valid_shard_copy", "no_attempt":
+		return statusRed.Render(decision)
+	default:
+		return statusYellow.Render(decision)
+	}
+}
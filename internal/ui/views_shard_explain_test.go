@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+func TestRenderShardExplainView(t *testing.T) {
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	explanation, err := source.NewOpenSearch(client).AllocationExplain(t.Context(), "test-index-2", 0, false)
+	if err != nil {
+		t.Fatalf("AllocationExplain() error = %v", err)
+	}
+
+	app := &App{
+		explainShard: ShardInfo{Index: "test-index-2", Shard: "0", Prirep: "r", State: "UNASSIGNED"},
+		shardExplain: explanation,
+	}
+	result := app.renderShardExplainView()
+
+	expected := []string{
+		"test-index-2[0] replica",
+		"NODE_LEFT",
+		"Node Decisions (2)",
+		"same_shard",
+		"disk_threshold",
+		"max_retry",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderShardExplainView() should contain %q", want)
+		}
+	}
+}
+
+func TestRenderShardExplainView_Loading(t *testing.T) {
+	app := &App{explainShard: ShardInfo{Index: "logs", Shard: "0", Prirep: "p"}}
+	if result := app.renderShardExplainView(); !strings.Contains(result, "Loading allocation explanation") {
+		t.Errorf("nil explanation should show loading, got %q", result)
+	}
+}
+
+func TestBlockingReasons(t *testing.T) {
+	decision := func(deciders ...string) source.NodeAllocationDecision {
+		node := source.NodeAllocationDecision{NodeDecision: "no"}
+		for _, d := range deciders {
+			node.Deciders = append(node.Deciders, source.AllocationDecider{Decider: d, Decision: "NO", Explanation: d + " says no"})
+		}
+		return node
+	}
+
+	report := &UnassignedReport{
+		Total: 4,
+		Explanations: []AllocationExplanation{
+			// A decider refusing on several nodes counts once per shard
+			{CanAllocate: "no", NodeDecisions: []source.NodeAllocationDecision{decision("disk_threshold"), decision("disk_threshold", "same_shard")}},
+			{CanAllocate: "no", NodeDecisions: []source.NodeAllocationDecision{decision("disk_threshold")}},
+			{CanAllocate: "no_valid_shard_copy", AllocateExplanation: "no copy"},
+		},
+	}
+
+	reasons := blockingReasons(report)
+	want := []struct {
+		decider string
+		shards  int
+	}{
+		{"disk_threshold", 2},
+		{"no_valid_shard_copy", 1},
+		{"same_shard", 1},
+	}
+	if len(reasons) != len(want) {
+		t.Fatalf("blockingReasons() = %+v, want %d reasons", reasons, len(want))
+	}
+	for i, w := range want {
+		if reasons[i].decider != w.decider || reasons[i].shards != w.shards {
+			t.Errorf("reasons[%d] = %s/%d, want %s/%d", i, reasons[i].decider, reasons[i].shards, w.decider, w.shards)
+		}
+	}
+
+	if blockingReasons(nil) != nil {
+		t.Error("blockingReasons(nil) should be nil")
+	}
+}
+
+func TestProblemShards_Order(t *testing.T) {
+	app := &App{shards: []ShardInfo{
+		{Index: "b", Shard: "0", Prirep: "p", State: "INITIALIZING"},
+		{Index: "a", Shard: "10", Prirep: "r", State: "UNASSIGNED"},
+		{Index: "a", Shard: "2", Prirep: "r", State: "UNASSIGNED"},
+		{Index: "a", Shard: "0", Prirep: "p", State: "STARTED"},
+	}}
+
+	shards := app.problemShards()
+	got := make([]string, len(shards))
+	for i, shard := range shards {
+		got[i] = shardLabel(shard)
+	}
+	want := []string{"a[2] replica", "a[10] replica", "b[0] primary"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("problemShards() = %v, want %v", got, want)
+	}
+}
+
+func TestRenderProblemShards(t *testing.T) {
+	app := &App{
+		shards: []ShardInfo{
+			{Index: "logs", Shard: "0", Prirep: "r", State: "UNASSIGNED"},
+			{Index: "logs", Shard: "1", Prirep: "p", State: "INITIALIZING", Node: "node-2"},
+		},
+		unassigned: &UnassignedReport{
+			Total: 1,
+			Explanations: []AllocationExplanation{{
+				CanAllocate: "no",
+				NodeDecisions: []source.NodeAllocationDecision{{
+					Deciders: []source.AllocationDecider{{Decider: "disk_threshold", Decision: "NO", Explanation: strings.Repeat("x", 200)}},
+				}},
+			}},
+		},
+	}
+
+	var b strings.Builder
+	app.renderProblemShards(&b)
+	result := b.String()
+
+	expected := []string{
+		"Unassigned Shards: 1",
+		"Initializing Shards: 1",
+		"Top blocking reasons",
+		"1 of 1 unassigned shards explained",
+		"disk_threshold",
+		"logs[0] replica",
+		"logs[1] primary",
+		"Press Enter",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderProblemShards() should contain %q", want)
+		}
+	}
+	if strings.Contains(result, strings.Repeat("x", maxReasonWidth)) {
+		t.Error("long decider explanations should be truncated in the summary")
+	}
+}
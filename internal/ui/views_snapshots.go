@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// DefaultSnapshotMaxAge is used when neither --snapshot-max-age nor the
+// config sets one
+const DefaultSnapshotMaxAge = 24 * time.Hour
+
+// maxSnapshotsPerRepo caps the snapshots listed per repository, newest first
+const maxSnapshotsPerRepo = 10
+
+// WithSnapshotMaxAge sets how old the newest successful snapshot may get
+// before the Snapshots view warns; zero keeps the default
+func (a *App) WithSnapshotMaxAge(d time.Duration) *App {
+	if d > 0 {
+		a.snapshotMaxAge = d
+	}
+	return a
+}
+
+// newestSuccessfulSnapshot returns the most recently completed successful
+// snapshot in any repository
+func newestSuccessfulSnapshot(overview *SnapshotOverview) (string, SnapshotInfo, bool) {
+	var repo string
+	var newest SnapshotInfo
+	found := false
+	if overview == nil {
+		return repo, newest, found
+	}
+
+	for _, r := range overview.Repositories {
+		for _, snapshot := range r.Snapshots {
+			if snapshot.State != "SUCCESS" {
+				continue
+			}
+			if !found || snapshot.EndTimeInMillis > newest.EndTimeInMillis {
+				repo, newest, found = r.Name, snapshot, true
+			}
+		}
+	}
+	return repo, newest, found
+}
+
+// renderSnapshotsView renders repositories, running snapshots and snapshot
+// history
+func (a *App) renderSnapshotsView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render("Snapshots"))
+	b.WriteString("\n\n")
+
+	if a.snapshots == nil {
+		b.WriteString(labelStyle.Render("No snapshot data available"))
+		return b.String()
+	}
+
+	a.renderSnapshotAge(&b, time.Now())
+	b.WriteString("\n\n")
+
+	if len(a.snapshots.InProgress) > 0 {
+		b.WriteString(headerStyle.Render(fmt.Sprintf("In Progress (%d)", len(a.snapshots.InProgress))))
+		b.WriteString("\n\n")
+		for _, status := range a.snapshots.InProgress {
+			renderSnapshotProgress(&b, status)
+		}
+	}
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Repositories (%d)", len(a.snapshots.Repositories))))
+	b.WriteString("\n\n")
+	for _, repo := range a.snapshots.Repositories {
+		renderSnapshotRepository(&b, repo)
+	}
+
+	return b.String()
+}
+
+// renderSnapshotAge warns when the cluster has no recent successful snapshot
+func (a *App) renderSnapshotAge(b *strings.Builder, now time.Time) {
+	if len(a.snapshots.Repositories) == 0 {
+		b.WriteString(statusRed.Render("⚠ No snapshot repositories registered; this cluster has no backups"))
+		return
+	}
+
+	repo, newest, ok := newestSuccessfulSnapshot(a.snapshots)
+	if !ok {
+		b.WriteString(statusRed.Render("⚠ No successful snapshot in any repository"))
+		return
+	}
+
+	age := now.Sub(newest.EndTime())
+	name := fmt.Sprintf("%s/%s", repo, newest.Snapshot)
+	if age > a.snapshotMaxAge {
+		b.WriteString(statusRed.Render(fmt.Sprintf("⚠ Newest successful snapshot %s finished %s ago (limit %s)",
+			name, formatUptime(age.Milliseconds()), formatUptime(a.snapshotMaxAge.Milliseconds()))))
+		return
+	}
+	b.WriteString(statusGreen.Render(fmt.Sprintf("✓ Newest successful snapshot %s finished %s ago",
+		name, formatUptime(age.Milliseconds()))))
+}
+
+// renderSnapshotProgress renders a running snapshot with progress bars
+func renderSnapshotProgress(b *strings.Builder, status source.SnapshotStatus) {
+	shards := status.ShardsStats
+	b.WriteString(fmt.Sprintf("%s %s\n",
+		valueStyle.Render(fmt.Sprintf("%s/%s", status.Repository, status.Snapshot)),
+		statusYellow.Render(status.State)))
+
+	shardLine := fmt.Sprintf("%d/%d done", shards.Done, shards.Total)
+	if shards.Failed > 0 {
+		shardLine += statusRed.Render(fmt.Sprintf(", %d failed", shards.Failed))
+	}
+	b.WriteString(fmt.Sprintf("    %s %s\n", labelStyle.Render("Shards:"), shardLine))
+
+	stats := status.Stats
+	filesPercent := percentOf(stats.Processed.FileCount, stats.Total.FileCount)
+	bytesPercent := percentOf(stats.Processed.SizeInBytes, stats.Total.SizeInBytes)
+
+	b.WriteString(fmt.Sprintf("    %s %s %.1f%%  %s\n",
+		labelStyle.Render("Files:"),
+		renderBar(fmt.Sprintf("%.1f", filesPercent), 15),
+		filesPercent,
+		labelStyle.Render(fmt.Sprintf("%d / %d", stats.Processed.FileCount, stats.Total.FileCount))))
+
+	b.WriteString(fmt.Sprintf("    %s %s %.1f%%  %s\n",
+		labelStyle.Render("Bytes:"),
+		renderBar(fmt.Sprintf("%.1f", bytesPercent), 15),
+		bytesPercent,
+		labelStyle.Render(fmt.Sprintf("%s / %s", formatBytes(stats.Processed.SizeInBytes), formatBytes(stats.Total.SizeInBytes)))))
+
+	b.WriteString(fmt.Sprintf("    %s %s\n", labelStyle.Render("Time:"), formatMillis(stats.TimeInMillis)))
+	b.WriteString("\n")
+}
+
+// percentOf returns part as a percentage of total
+func percentOf(part, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+// renderSnapshotRepository renders a repository and its newest snapshots
+func renderSnapshotRepository(b *strings.Builder, repo source.SnapshotRepository) {
+	b.WriteString(fmt.Sprintf("%s %s %s\n",
+		valueStyle.Render(repo.Name),
+		labelStyle.Render(fmt.Sprintf("(%s)", repo.Type)),
+		labelStyle.Render(fmt.Sprintf("%d snapshots", len(repo.Snapshots)))))
+
+	if repo.Error != "" {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("  Failed to list snapshots: %s", repo.Error)))
+		b.WriteString("\n\n")
+		return
+	}
+	if len(repo.Snapshots) == 0 {
+		b.WriteString(labelStyle.Render("  No snapshots"))
+		b.WriteString("\n\n")
+		return
+	}
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-30s %-12s %-16s %-10s %-12s %s",
+		"Snapshot", "State", "Started", "Duration", "Shards", "Indices")))
+	b.WriteString("\n")
+
+	for i, snapshot := range repo.Snapshots {
+		if i == maxSnapshotsPerRepo {
+			b.WriteString(labelStyle.Render(fmt.Sprintf("  ... and %d older snapshots", len(repo.Snapshots)-maxSnapshotsPerRepo)))
+			b.WriteString("\n")
+			break
+		}
+
+		duration := "-"
+		if !snapshot.EndTime().IsZero() {
+			duration = formatMillis(snapshot.DurationInMillis)
+		}
+
+		shards := fmt.Sprintf("%d/%d", snapshot.Shards.Successful, snapshot.Shards.Total)
+		shardsCell := fmt.Sprintf("%-12s", shards)
+		if snapshot.Shards.Failed > 0 {
+			shardsCell = statusRed.Render(fmt.Sprintf("%-12s", fmt.Sprintf("%s (%d✗)", shards, snapshot.Shards.Failed)))
+		}
+
+		b.WriteString(fmt.Sprintf("  %-30s %s %-16s %-10s %s %s\n",
+			truncateText(snapshot.Snapshot, 30),
+			renderSnapshotState(snapshot.State),
+			snapshot.StartTime().Format("2006-01-02 15:04"),
+			duration,
+			shardsCell,
+			summarizeIndices(snapshot.Indices)))
+	}
+	b.WriteString("\n")
+}
+
+// renderSnapshotState colours a snapshot state, padded for the table
+func renderSnapshotState(state string) string {
+	padded := fmt.Sprintf("%-12s", state)
+	switch state {
+	case "SUCCESS":
+		return statusGreen.Render(padded)
+	case "FAILED":
+		return statusRed.Render(padded)
+	default:
+		return statusYellow.Render(padded)
+	}
+}
+
+// summarizeIndices shows an index count and the first few names
+func summarizeIndices(indices []string) string {
+	const shown = 3
+	if len(indices) == 0 {
+		return "0"
+	}
+	if len(indices) <= shown {
+		return fmt.Sprintf("%d: %s", len(indices), strings.Join(indices, ", "))
+	}
+	return fmt.Sprintf("%d: %s, ...", len(indices), strings.Join(indices[:shown], ", "))
+}
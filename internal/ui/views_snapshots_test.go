@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+func TestRenderSnapshotsView(t *testing.T) {
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	overview, err := source.NewOpenSearch(client).Snapshots(t.Context())
+	if err != nil {
+		t.Fatalf("Snapshots() error = %v", err)
+	}
+
+	app := &App{snapshots: overview, snapshotMaxAge: DefaultSnapshotMaxAge}
+	result := app.renderSnapshotsView()
+
+	expected := []string{
+		"In Progress (1)",
+		"backups/hourly-2024.01.15-10",
+		"8/10 done",
+		"75.0%", // Files and bytes processed
+		"375.0 MB / 500.0 MB",
+		"Repositories (2)",
+		"s3-nightly",
+		"(s3)",
+		"nightly-2024.01.15",
+		"PARTIAL",
+		"11/12 (1✗)",
+		"4: test-index-1, test-index-2, logs-2024.01.15, ...",
+		"4m12.5s", // Duration of the successful snapshot
+		// The fixtures are from 2024, far beyond the default limit
+		"Newest successful snapshot backups/nightly-2024.01.14 finished",
+		"(limit 1d 0h 0m)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderSnapshotsView() should contain %q", want)
+		}
+	}
+}
+
+func TestRenderSnapshotAge(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	finished := func(ago time.Duration, state string) SnapshotInfo {
+		return SnapshotInfo{Snapshot: "snap", State: state, EndTimeInMillis: now.Add(-ago).UnixMilli()}
+	}
+
+	tests := []struct {
+		name     string
+		overview *SnapshotOverview
+		want     string
+	}{
+		{"no repositories", &SnapshotOverview{}, "No snapshot repositories registered"},
+		{"only failures", &SnapshotOverview{Repositories: []source.SnapshotRepository{
+			{Name: "r", Snapshots: []SnapshotInfo{finished(time.Hour, "FAILED"), finished(time.Hour, "PARTIAL")}},
+		}}, "No successful snapshot"},
+		{"recent", &SnapshotOverview{Repositories: []source.SnapshotRepository{
+			{Name: "r", Snapshots: []SnapshotInfo{finished(2*time.Hour, "SUCCESS")}},
+		}}, "✓ Newest successful snapshot r/snap finished 2h 0m ago"},
+		{"too old", &SnapshotOverview{Repositories: []source.SnapshotRepository{
+			{Name: "r", Snapshots: []SnapshotInfo{finished(30*time.Hour, "SUCCESS")}},
+		}}, "⚠ Newest successful snapshot r/snap finished 1d 6h 0m ago (limit 1d 0h 0m)"},
+		{"newest across repositories", &SnapshotOverview{Repositories: []source.SnapshotRepository{
+			{Name: "old", Snapshots: []SnapshotInfo{finished(30*time.Hour, "SUCCESS")}},
+			{Name: "new", Snapshots: []SnapshotInfo{finished(time.Hour, "SUCCESS")}},
+		}}, "new/snap finished 1h 0m ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &App{snapshots: tt.overview, snapshotMaxAge: DefaultSnapshotMaxAge}
+			var b strings.Builder
+			app.renderSnapshotAge(&b, now)
+			if !strings.Contains(b.String(), tt.want) {
+				t.Errorf("renderSnapshotAge() = %q, want %q", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderSnapshotsView_RepositoryError(t *testing.T) {
+	app := &App{
+		snapshotMaxAge: DefaultSnapshotMaxAge,
+		snapshots: &SnapshotOverview{Repositories: []source.SnapshotRepository{
+			{Name: "broken", Type: "fs", Error: "snapshots API error: 500 Internal Server Error"},
+		}},
+	}
+	result := app.renderSnapshotsView()
+	if !strings.Contains(result, "Failed to list snapshots") {
+		t.Error("a repository that can't be listed should show its error")
+	}
+}
+
+func TestWithSnapshotMaxAge(t *testing.T) {
+	app := NewTestApp(nil, "http://localhost:9200").WithSnapshotMaxAge(48 * time.Hour)
+	if app.snapshotMaxAge != 48*time.Hour {
+		t.Errorf("snapshotMaxAge = %v, want 48h", app.snapshotMaxAge)
+	}
+	app.WithSnapshotMaxAge(0)
+	if app.snapshotMaxAge != 48*time.Hour {
+		t.Error("WithSnapshotMaxAge(0) should keep the current age")
+	}
+}
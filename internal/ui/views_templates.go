@@ -0,0 +1,384 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vegasq/ostop/internal/source"
+)
+
+// templateConflict is two templates that both match some index names at the
+// same precedence
+type templateConflict struct {
+	first, second string
+	precedence    int64 // Priority, or order for legacy templates
+	legacy        bool
+	patterns      [2]string // The overlapping pattern of each template
+}
+
+// composableTemplates returns the composable templates, highest priority
+// first
+func (a *App) composableTemplates() []IndexTemplate {
+	if a.indexTemplates == nil {
+		return nil
+	}
+	templates := append([]IndexTemplate(nil), a.indexTemplates.IndexTemplates...)
+	sort.SliceStable(templates, func(i, j int) bool {
+		return templates[i].Priority > templates[j].Priority
+	})
+	return templates
+}
+
+// legacyTemplates returns the CAT templates rows that aren't composable
+// templates, since CAT templates lists both kinds
+func (a *App) legacyTemplates() []TemplateInfo {
+	composable := make(map[string]bool)
+	if a.indexTemplates != nil {
+		for _, template := range a.indexTemplates.IndexTemplates {
+			composable[template.Name] = true
+		}
+	}
+
+	var legacy []TemplateInfo
+	for _, template := range a.templates {
+		if !composable[template.Name] {
+			legacy = append(legacy, template)
+		}
+	}
+	return legacy
+}
+
+// selectedComposableTemplate returns the template under the Templates view
+// cursor
+func (a *App) selectedComposableTemplate() (IndexTemplate, bool) {
+	templates := a.composableTemplates()
+	if len(templates) == 0 {
+		return IndexTemplate{}, false
+	}
+	if a.selectedTemplate < 0 || a.selectedTemplate >= len(templates) {
+		a.selectedTemplate = 0
+	}
+	return templates[a.selectedTemplate], true
+}
+
+// clearTemplateDetail drops the template drill-down's state
+func (a *App) clearTemplateDetail() {
+	a.templateDetail = ""
+	a.templateSim = nil
+	a.templateSimErr = nil
+}
+
+// openTemplateDetail switches to the template drill-down and resolves the
+// template
+func (a *App) openTemplateDetail(name string) tea.Cmd {
+	a.clearTemplateDetail()
+	a.templateDetail = name
+	a.currentView = ViewTemplateDetail
+	a.updateViewportContent()
+	if a.viewportReady {
+		a.viewport.GotoTop()
+	}
+	return a.fetchTemplateSimulation()
+}
+
+// findTemplateConflicts returns pairs of templates whose index patterns
+// overlap at the same priority, or for legacy templates the same order
+func findTemplateConflicts(composable []IndexTemplate, legacy []TemplateInfo) []templateConflict {
+	type candidate struct {
+		name       string
+		precedence int64
+		patterns   []string
+	}
+
+	var conflicts []templateConflict
+	check := func(candidates []candidate, isLegacy bool) {
+		for i := 0; i < len(candidates); i++ {
+			for j := i + 1; j < len(candidates); j++ {
+				first, second := candidates[i], candidates[j]
+				if first.precedence != second.precedence {
+					continue
+				}
+				if p, q, ok := overlappingPatterns(first.patterns, second.patterns); ok {
+					conflicts = append(conflicts, templateConflict{
+						first:      first.name,
+						second:     second.name,
+						precedence: first.precedence,
+						legacy:     isLegacy,
+						patterns:   [2]string{p, q},
+					})
+				}
+			}
+		}
+	}
+
+	candidates := make([]candidate, 0, len(composable))
+	for _, template := range composable {
+		candidates = append(candidates, candidate{template.Name, template.Priority, template.IndexPatterns})
+	}
+	check(candidates, false)
+
+	candidates = candidates[:0]
+	for _, template := range legacy {
+		var order int64
+		fmt.Sscanf(template.Order, "%d", &order)
+		candidates = append(candidates, candidate{template.Name, order, parseCatPatterns(template.IndexPatterns)})
+	}
+	check(candidates, true)
+
+	return conflicts
+}
+
+// parseCatPatterns splits CAT templates' "[logs-*, metrics-*]" column
+func parseCatPatterns(s string) []string {
+	s = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "["), "]")
+	var patterns []string
+	for _, pattern := range strings.Split(s, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// overlappingPatterns returns the first pair of patterns, one from each
+// list, that some index name matches
+func overlappingPatterns(first, second []string) (string, string, bool) {
+	for _, p := range first {
+		for _, q := range second {
+			if patternsOverlap(p, q) {
+				return p, q, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// patternsOverlap reports whether some string matches both wildcard
+// patterns, where '*' matches any run of characters
+func patternsOverlap(p, q string) bool {
+	// Every step advances through p, q or both, so memoizing positions keeps
+	// this quadratic
+	memo := make(map[[2]int]bool)
+	var overlap func(i, j int) bool
+	overlap = func(i, j int) bool {
+		key := [2]int{i, j}
+		if result, ok := memo[key]; ok {
+			return result
+		}
+
+		var result bool
+		switch {
+		case i == len(p) && j == len(q):
+			result = true
+		case i < len(p) && p[i] == '*':
+			// The star matches nothing more, or swallows q's next character
+			result = overlap(i+1, j) || (j < len(q) && overlap(i, j+1))
+		case j < len(q) && q[j] == '*':
+			result = overlap(i, j+1) || (i < len(p) && overlap(i+1, j))
+		case i < len(p) && j < len(q) && p[i] == q[j]:
+			result = overlap(i+1, j+1)
+		}
+
+		memo[key] = result
+		return result
+	}
+	return overlap(0, 0)
+}
+
+// templateBodySummary counts what a template body sets
+func (a *App) templateBodySummary(body source.TemplateBody) string {
+	fields := 0
+	if properties, ok := body.Mappings["properties"].(map[string]interface{}); ok {
+		fields = a.countFields(properties)
+	}
+	return fmt.Sprintf("%d settings, %d fields, %d aliases", len(body.Settings), fields, len(body.Aliases))
+}
+
+// renderTemplateConflicts warns about templates that overlap at the same
+// precedence
+func renderTemplateConflicts(b *strings.Builder, conflicts []templateConflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+
+	b.WriteString(statusRed.Render(fmt.Sprintf("⚠ Overlapping Templates (%d)", len(conflicts))))
+	b.WriteString("\n")
+	for _, conflict := range conflicts {
+		kind := "priority"
+		if conflict.legacy {
+			kind = "legacy order"
+		}
+		b.WriteString(fmt.Sprintf("  %s and %s at %s %d: %s overlaps %s\n",
+			valueStyle.Render(conflict.first), valueStyle.Render(conflict.second),
+			kind, conflict.precedence, conflict.patterns[0], conflict.patterns[1]))
+	}
+	b.WriteString(helpStyle.Render("Composable templates like these are rejected when created or updated; legacy templates with the same order merge in no guaranteed order"))
+	b.WriteString("\n\n")
+}
+
+// renderComposableTemplates renders the composable templates with the
+// cursor
+func (a *App) renderComposableTemplates(b *strings.Builder, templates []IndexTemplate) {
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Composable Templates (%d)", len(templates))))
+	b.WriteString("\n")
+	if len(templates) == 0 {
+		b.WriteString(labelStyle.Render("No composable templates"))
+		b.WriteString("\n\n")
+		return
+	}
+	b.WriteString(helpStyle.Render("Press Enter to resolve the selected template with its component templates"))
+	b.WriteString("\n\n")
+
+	a.selectedComposableTemplate() // Clamps the cursor
+	for i, template := range templates {
+		cursor := "  "
+		if i == a.selectedTemplate {
+			cursor = statusGreen.Render("▶ ")
+		}
+		b.WriteString(cursor + valueStyle.Render(template.Name))
+		b.WriteString(labelStyle.Render(fmt.Sprintf("  priority %d", template.Priority)))
+		if template.Version != 0 {
+			b.WriteString(labelStyle.Render(fmt.Sprintf("  v%d", template.Version)))
+		}
+		if template.DataStream {
+			b.WriteString(statusGreen.Render("  data stream"))
+		}
+		b.WriteString("\n")
+
+		b.WriteString(fmt.Sprintf("    %s %s\n", labelStyle.Render("Patterns:   "), strings.Join(template.IndexPatterns, ", ")))
+		if len(template.ComposedOf) > 0 {
+			b.WriteString(fmt.Sprintf("    %s %s\n", labelStyle.Render("Composed of:"), strings.Join(template.ComposedOf, ", ")))
+		}
+		b.WriteString(fmt.Sprintf("    %s %s\n", labelStyle.Render("Template:   "), a.templateBodySummary(template.Template)))
+		b.WriteString("\n")
+	}
+}
+
+// renderComponentTemplates renders the component templates with their
+// settings and the composable templates using them
+func (a *App) renderComponentTemplates(b *strings.Builder) {
+	if a.indexTemplates == nil {
+		return
+	}
+
+	components := a.indexTemplates.ComponentTemplates
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Component Templates (%d)", len(components))))
+	b.WriteString("\n\n")
+	if len(components) == 0 {
+		b.WriteString(labelStyle.Render("No component templates"))
+		b.WriteString("\n\n")
+		return
+	}
+
+	usedBy := make(map[string][]string)
+	for _, template := range a.indexTemplates.IndexTemplates {
+		for _, name := range template.ComposedOf {
+			usedBy[name] = append(usedBy[name], template.Name)
+		}
+	}
+
+	for _, component := range components {
+		b.WriteString(valueStyle.Render(component.Name))
+		if component.Version != 0 {
+			b.WriteString(labelStyle.Render(fmt.Sprintf("  v%d", component.Version)))
+		}
+		b.WriteString("\n")
+
+		users := statusYellow.Render("not used by any index template")
+		if len(usedBy[component.Name]) > 0 {
+			users = strings.Join(usedBy[component.Name], ", ")
+		}
+		b.WriteString(fmt.Sprintf("    %s %s\n", labelStyle.Render("Used by: "), users))
+		b.WriteString(fmt.Sprintf("    %s %s\n", labelStyle.Render("Template:"), a.templateBodySummary(component.Template)))
+		for _, key := range sortedKeys(component.Template.Settings) {
+			b.WriteString(fmt.Sprintf("      %s = %s\n", labelStyle.Render(key), component.Template.Settings[key]))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// renderTemplateDetailView renders a composable template resolved with its
+// component templates
+func (a *App) renderTemplateDetailView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Index Template: %s", a.templateDetail)))
+	b.WriteString("\n\n")
+
+	for _, template := range a.composableTemplates() {
+		if template.Name != a.templateDetail {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Patterns:   "), strings.Join(template.IndexPatterns, ", ")))
+		b.WriteString(fmt.Sprintf("%s %d\n", labelStyle.Render("Priority:   "), template.Priority))
+		if len(template.ComposedOf) > 0 {
+			b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Composed of:"), strings.Join(template.ComposedOf, ", ")))
+		}
+		if template.DataStream {
+			b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Data stream:"), statusGreen.Render("yes")))
+		}
+		b.WriteString("\n")
+	}
+
+	if a.templateSimErr != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Failed to resolve template: %v", a.templateSimErr)))
+		b.WriteString("\n")
+		return b.String()
+	}
+	if a.templateSim == nil {
+		b.WriteString(labelStyle.Render("Resolving template..."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	resolved := a.templateSim.Template
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Resolved Settings (%d)", len(resolved.Settings))))
+	b.WriteString("\n")
+	for _, key := range sortedKeys(resolved.Settings) {
+		b.WriteString(fmt.Sprintf("  %s = %s\n", labelStyle.Render(key), resolved.Settings[key]))
+	}
+	b.WriteString("\n")
+
+	properties, _ := resolved.Mappings["properties"].(map[string]interface{})
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Resolved Mappings (%d fields)", a.countFields(properties))))
+	b.WriteString("\n")
+	renderTemplateFields(&b, properties, 1)
+	b.WriteString("\n")
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Resolved Aliases (%d)", len(resolved.Aliases))))
+	b.WriteString("\n")
+	for _, alias := range resolved.Aliases {
+		b.WriteString(fmt.Sprintf("  %s\n", alias))
+	}
+	b.WriteString("\n")
+
+	if len(a.templateSim.Overlapping) > 0 {
+		b.WriteString(statusYellow.Render(fmt.Sprintf("Overrides Lower Priority Templates (%d)", len(a.templateSim.Overlapping))))
+		b.WriteString("\n")
+		for _, overlap := range a.templateSim.Overlapping {
+			b.WriteString(fmt.Sprintf("  %s %s\n", valueStyle.Render(overlap.Name), labelStyle.Render(strings.Join(overlap.IndexPatterns, ", "))))
+		}
+	}
+
+	return b.String()
+}
+
+// renderTemplateFields lists mapped fields and their types, sorted by name
+func renderTemplateFields(b *strings.Builder, properties map[string]interface{}, indent int) {
+	for _, name := range sortedKeys(properties) {
+		field, ok := properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fieldType, _ := field["type"].(string)
+		if fieldType == "" {
+			fieldType = "object"
+		}
+		b.WriteString(fmt.Sprintf("%s%s %s\n", strings.Repeat("  ", indent), name, labelStyle.Render(fieldType)))
+		if nested, ok := field["properties"].(map[string]interface{}); ok {
+			renderTemplateFields(b, nested, indent+1)
+		}
+	}
+}
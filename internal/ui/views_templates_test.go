@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vegasq/ostop/internal/source"
+)
+
+func TestPatternsOverlap(t *testing.T) {
+	tests := []struct {
+		p, q string
+		want bool
+	}{
+		{"logs-*", "logs-*", true},
+		{"logs-*", "logs-app-*", true},
+		{"logs-app-*", "logs-*", true},
+		{"logs-*", "metrics-*", false},
+		{"*-2024", "logs-*", true},
+		{"logs", "logs", true},
+		{"logs", "logs-1", false},
+		{"logs-*-a", "logs-*-b", false},
+		{"*", "anything", true},
+		{"a*c", "ab*", true},
+		{"a*c", "ab*d", false},
+	}
+
+	for _, tt := range tests {
+		if got := patternsOverlap(tt.p, tt.q); got != tt.want {
+			t.Errorf("patternsOverlap(%q, %q) = %v, want %v", tt.p, tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestFindTemplateConflicts(t *testing.T) {
+	composable := []IndexTemplate{
+		{Name: "logs", IndexPatterns: []string{"logs-*"}, Priority: 100},
+		{Name: "logs-app", IndexPatterns: []string{"other-*", "logs-app-*"}, Priority: 100},
+		{Name: "logs-high", IndexPatterns: []string{"logs-*"}, Priority: 200},
+		{Name: "metrics", IndexPatterns: []string{"metrics-*"}, Priority: 100},
+	}
+	legacy := []TemplateInfo{
+		{Name: "old-a", IndexPatterns: "[old-*, legacy-*]", Order: "0"},
+		{Name: "old-b", IndexPatterns: "[legacy-*]", Order: "0"},
+		{Name: "old-c", IndexPatterns: "[legacy-*]", Order: "1"},
+	}
+
+	conflicts := findTemplateConflicts(composable, legacy)
+	if len(conflicts) != 2 {
+		t.Fatalf("conflicts = %+v, want 2", conflicts)
+	}
+
+	if c := conflicts[0]; c.first != "logs" || c.second != "logs-app" || c.precedence != 100 || c.legacy ||
+		c.patterns != [2]string{"logs-*", "logs-app-*"} {
+		t.Errorf("composable conflict = %+v", c)
+	}
+	if c := conflicts[1]; c.first != "old-a" || c.second != "old-b" || !c.legacy || c.patterns[0] != "legacy-*" {
+		t.Errorf("legacy conflict = %+v", c)
+	}
+}
+
+func TestRenderTemplatesView_Composable(t *testing.T) {
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	src := source.NewOpenSearch(client)
+	overview, err := src.IndexTemplates(t.Context())
+	if err != nil {
+		t.Fatalf("IndexTemplates() error = %v", err)
+	}
+	legacy, err := src.Templates(t.Context())
+	if err != nil {
+		t.Fatalf("Templates() error = %v", err)
+	}
+
+	app := &App{indexTemplates: overview, templates: legacy}
+	result := app.renderTemplatesView()
+
+	expected := []string{
+		"Index Templates (4)",
+		"⚠ Overlapping Templates (1)",
+		"logs-app-debug at priority 100: logs-app-* overlaps logs-app-debug-*",
+		"Composable Templates (2)",
+		"▶ logs-app",
+		"data stream",
+		"base-settings, logs-mappings",
+		"1 settings, 0 fields, 1 aliases",
+		"Component Templates (3)",
+		"Used by:  logs-app, logs-app-debug",
+		"index.number_of_replicas = 1",
+		"0 settings, 4 fields, 0 aliases",
+		"not used by any index template",
+		"Legacy Templates (2)",
+		"metrics-template",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderTemplatesView() should contain %q", want)
+		}
+	}
+}
+
+func TestRenderTemplateDetailView(t *testing.T) {
+	client, err := NewMockClientWithFixtures()
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	simulated, err := source.NewOpenSearch(client).SimulateIndexTemplate(t.Context(), "logs-app")
+	if err != nil {
+		t.Fatalf("SimulateIndexTemplate() error = %v", err)
+	}
+
+	app := &App{
+		templateDetail: "logs-app",
+		indexTemplates: &TemplateOverview{IndexTemplates: []IndexTemplate{
+			{Name: "logs-app", IndexPatterns: []string{"logs-app-*"}, Priority: 100, ComposedOf: []string{"base-settings"}},
+		}},
+	}
+	if result := app.renderTemplateDetailView(); !strings.Contains(result, "Resolving template...") {
+		t.Errorf("detail without a simulation should show loading, got %q", result)
+	}
+
+	app.templateSim = simulated
+	result := app.renderTemplateDetailView()
+	expected := []string{
+		"Index Template: logs-app",
+		"Priority:    100",
+		"Composed of: base-settings",
+		"Resolved Settings (3)",
+		"index.refresh_interval = 5s",
+		"Resolved Mappings (4 fields)",
+		"    name keyword", // host.name, nested under host
+		"Resolved Aliases (1)",
+		"logs-app-read",
+		"Overrides Lower Priority Templates (1)",
+		"logs-catchall",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderTemplateDetailView() should contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/sparkline"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// topIndicesLimit caps the rows of the Top Indices leaderboard
+const topIndicesLimit = 20
+
+// indexRanking is the metric the Top Indices leaderboard ranks indices by
+type indexRanking int
+
+const (
+	rankByIndexRate indexRanking = iota
+	rankByQueryRate
+	rankByQueryLatency
+	rankByIndexLatency
+	indexRankingCount
+)
+
+// String returns the ranking as shown in the view header
+func (r indexRanking) String() string {
+	switch r {
+	case rankByQueryRate:
+		return "query rate"
+	case rankByQueryLatency:
+		return "query latency"
+	case rankByIndexLatency:
+		return "indexing latency"
+	default:
+		return "index rate"
+	}
+}
+
+// value returns the metric an index is ranked by
+func (r indexRanking) value(rates IndexRates) float64 {
+	switch r {
+	case rankByQueryRate:
+		return rates.QueryRate
+	case rankByQueryLatency:
+		return rates.QueryLatency
+	case rankByIndexLatency:
+		return rates.IndexLatency
+	default:
+		return rates.IndexRate
+	}
+}
+
+// topIndices returns the indices the leaderboard's cursor moves over,
+// busiest by the current ranking first
+func (a *App) topIndices() []string {
+	latest := a.indexTimeSeries.Latest()
+	names := make([]string, 0, len(latest))
+	for name := range latest {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		vi, vj := a.topIndicesRank.value(latest[names[i]]), a.topIndicesRank.value(latest[names[j]])
+		if vi != vj {
+			return vi > vj
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > topIndicesLimit {
+		names = names[:topIndicesLimit]
+	}
+	return names
+}
+
+// selectedTopIndexName returns the index under the leaderboard cursor
+func (a *App) selectedTopIndexName() (string, bool) {
+	names := a.topIndices()
+	if len(names) == 0 {
+		return "", false
+	}
+	if a.selectedTopIndex < 0 || a.selectedTopIndex >= len(names) {
+		a.selectedTopIndex = 0
+	}
+	return names[a.selectedTopIndex], true
+}
+
+// cycleTopIndicesRank ranks the leaderboard by the next metric
+func (a *App) cycleTopIndicesRank() {
+	a.topIndicesRank = (a.topIndicesRank + 1) % indexRankingCount
+	a.selectedTopIndex = 0
+	a.updateViewportContent()
+	if a.viewportReady {
+		a.viewport.GotoTop()
+	}
+}
+
+// indexPinned reports whether an index is graphed in Live Metrics
+func (a *App) indexPinned(index string) bool {
+	for _, pinned := range a.pinnedIndices {
+		if pinned == index {
+			return true
+		}
+	}
+	return false
+}
+
+// togglePinnedIndex pins an index to Live Metrics, or unpins it
+func (a *App) togglePinnedIndex(index string) {
+	for i, pinned := range a.pinnedIndices {
+		if pinned == index {
+			a.pinnedIndices = append(a.pinnedIndices[:i], a.pinnedIndices[i+1:]...)
+			delete(a.pinnedSeries, index)
+			a.updateViewportContent()
+			return
+		}
+	}
+	a.pinnedIndices = append(a.pinnedIndices, index)
+	a.updateViewportContent()
+}
+
+// renderTopIndicesView renders the per-index activity leaderboard with a
+// trend of the ranked metric for each index
+func (a *App) renderTopIndicesView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render("Top Indices"))
+	b.WriteString("\n")
+	b.WriteString(subtleStyle.Render(fmt.Sprintf("Ranked by %s, auto-refresh: 5s", a.topIndicesRank)))
+	b.WriteString("\n")
+
+	if len(a.pinnedIndices) > 0 {
+		b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Pinned to Live Metrics:"), valueStyle.Render(strings.Join(a.pinnedIndices, ", "))))
+	}
+	b.WriteString(helpStyle.Render(fmt.Sprintf("s: Rank by %s | P: Pin or unpin the selected index in Live Metrics", (a.topIndicesRank+1)%indexRankingCount)))
+	b.WriteString("\n\n")
+
+	if a.indexTimeSeries.Size() == 0 {
+		b.WriteString(subtleStyle.Render("Collecting index stats..."))
+		b.WriteString("\n")
+		b.WriteString(subtleStyle.Render("Rankings will appear after first data point (5 seconds)"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	names := a.topIndices()
+	if len(names) == 0 {
+		b.WriteString(labelStyle.Render("No indices found"))
+		return b.String()
+	}
+	a.selectedTopIndexName() // Clamps the cursor
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("    %-30s %10s %10s %10s %10s  %s",
+		"Index", "Index/s", "Query/s", "Index ms", "Query ms", "Trend")))
+	b.WriteString("\n")
+
+	latest := a.indexTimeSeries.Latest()
+	for i, name := range names {
+		cursor := "  "
+		if i == a.selectedTopIndex {
+			cursor = statusGreen.Render("▶ ")
+		}
+		pin := "  "
+		if a.indexPinned(name) {
+			pin = statusYellow.Render("* ")
+		}
+		rates := latest[name]
+		b.WriteString(cursor + pin)
+		b.WriteString(fmt.Sprintf("%-30s %10.1f %10.1f %10.2f %10.2f  %s\n",
+			truncateText(name, 30),
+			rates.IndexRate,
+			rates.QueryRate,
+			rates.IndexLatency,
+			rates.QueryLatency,
+			renderSparkline(a.indexTimeSeries.Series(name, a.topIndicesRank.value), a.indexTimeSeries.maxDataPoints)))
+	}
+
+	lastUpdate := "Never"
+	if !a.lastTopIndicesUpdate.IsZero() {
+		lastUpdate = a.lastTopIndicesUpdate.Format("15:04:05")
+	}
+	b.WriteString("\n")
+	b.WriteString(subtleStyle.Render(fmt.Sprintf("ℹLast updated: %s  │  Data points: %d  │  Counts from primaries  │  Latencies are per operation over the last interval",
+		lastUpdate, a.indexTimeSeries.Size())))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// renderSparkline renders values as a one-line column chart, newest on the
+// right
+func renderSparkline(values []float64, width int) string {
+	sl := sparkline.New(width, 1)
+	sl.PushAll(values)
+	sl.Draw()
+	return sl.View()
+}
+
+// topIndicesTick creates a command that triggers after 5 seconds
+func topIndicesTick() tea.Cmd {
+	return tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+		return topIndicesTickMsg{timestamp: t}
+	})
+}
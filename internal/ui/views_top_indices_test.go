@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTopIndicesApp returns an app with one interval of activity for three
+// indices
+func newTopIndicesApp() *App {
+	app := &App{indexTimeSeries: NewIndexTimeSeries(12)}
+	app.indexTimeSeries.AddSnapshot(newIndexActivitySnapshot([]IndexActivity{
+		{Index: "logs"}, {Index: "products"}, {Index: "users"},
+	}, time.Unix(0, 0)))
+	app.indexTimeSeries.AddSnapshot(newIndexActivitySnapshot([]IndexActivity{
+		{Index: "logs", IndexTotal: 5000, IndexTimeInMillis: 1000, QueryTotal: 5, QueryTimeInMillis: 50},
+		{Index: "products", IndexTotal: 50, IndexTimeInMillis: 250, QueryTotal: 500, QueryTimeInMillis: 500},
+		{Index: "users", QueryTotal: 10, QueryTimeInMillis: 300},
+	}, time.Unix(5, 0)))
+	return app
+}
+
+func TestTopIndices_Ranking(t *testing.T) {
+	tests := []struct {
+		rank indexRanking
+		want []string
+	}{
+		{rankByIndexRate, []string{"logs", "products", "users"}},
+		{rankByQueryRate, []string{"products", "users", "logs"}},
+		{rankByQueryLatency, []string{"users", "logs", "products"}},
+		{rankByIndexLatency, []string{"products", "logs", "users"}},
+	}
+
+	app := newTopIndicesApp()
+	for _, tt := range tests {
+		app.topIndicesRank = tt.rank
+		if got := app.topIndices(); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("topIndices() by %s = %v, want %v", tt.rank, got, tt.want)
+		}
+	}
+
+	app.topIndicesRank = rankByIndexLatency
+	app.cycleTopIndicesRank()
+	if app.topIndicesRank != rankByIndexRate {
+		t.Errorf("cycling past the last ranking should wrap to %s, got %s", rankByIndexRate, app.topIndicesRank)
+	}
+}
+
+func TestTogglePinnedIndex(t *testing.T) {
+	app := newTopIndicesApp()
+	app.togglePinnedIndex("logs")
+	app.togglePinnedIndex("users")
+	if !app.indexPinned("logs") || len(app.pinnedIndices) != 2 {
+		t.Fatalf("pinnedIndices = %v, want logs and users", app.pinnedIndices)
+	}
+	app.togglePinnedIndex("logs")
+	if app.indexPinned("logs") || strings.Join(app.pinnedIndices, ",") != "users" {
+		t.Errorf("pinnedIndices = %v, want users", app.pinnedIndices)
+	}
+}
+
+func TestRenderTopIndicesView(t *testing.T) {
+	empty := &App{indexTimeSeries: NewIndexTimeSeries(12)}
+	if result := empty.renderTopIndicesView(); !strings.Contains(result, "Collecting index stats") {
+		t.Errorf("renderTopIndicesView() without data = %q", result)
+	}
+
+	app := newTopIndicesApp()
+	app.pinnedIndices = []string{"products"}
+	result := app.renderTopIndicesView()
+
+	expected := []string{
+		"Ranked by index rate",
+		"s: Rank by query rate",
+		"Pinned to Live Metrics: products",
+		"logs",
+		"1000.0", // logs index rate
+		"100.0",  // products query rate
+		"30.00",  // users query latency
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("renderTopIndicesView() should contain %q", want)
+		}
+	}
+	if strings.Index(result, "logs") > strings.Index(result, "products ") {
+		t.Error("logs should rank above products by index rate")
+	}
+}
+
+func TestRenderMetricsView_PinnedIndex(t *testing.T) {
+	app := newTopIndicesApp()
+	app.metricsTimeSeries = NewMetricsTimeSeries(12)
+	app.metricsTimeSeries.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(0, 0)})
+	app.metricsTimeSeries.AddSnapshot(&MetricsSnapshot{Timestamp: time.Unix(5, 0), IndexTotal: 5050})
+	app.pinnedIndices = []string{"logs"}
+	app.addPinnedSnapshot(newIndexActivitySnapshot([]IndexActivity{{Index: "logs"}}, time.Unix(0, 0)))
+	app.addPinnedSnapshot(newIndexActivitySnapshot([]IndexActivity{{Index: "logs", IndexTotal: 5000}}, time.Unix(5, 0)))
+
+	result := app.renderMetricsView()
+	if !strings.Contains(result, "Pinned Index: logs") {
+		t.Error("Live Metrics should graph the pinned index")
+	}
+	if !strings.Contains(result, "Current: 1,000/s") {
+		t.Error("Live Metrics should show the pinned index's indexing rate")
+	}
+}
+
+func TestRefreshPinnedIndices(t *testing.T) {
+	fake := &FakeSource{TopIndicesData: []IndexActivity{{Index: "logs"}, {Index: "products"}}}
+	app := NewApp(fake, "fake://", "none")
+	if app.refreshPinnedIndices() != nil {
+		t.Error("nothing pinned should skip the request")
+	}
+
+	app.pinnedIndices = []string{"products"}
+	msg, ok := app.refreshPinnedIndices()().(pinnedIndicesRefreshMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("refreshPinnedIndices() = %#v", msg)
+	}
+	if _, ok := msg.snapshot.Indices["products"]; !ok || len(msg.snapshot.Indices) != 1 {
+		t.Errorf("pinned snapshot = %v, want only products", msg.snapshot.Indices)
+	}
+}
+
+func TestPinnedSeries_FollowsMetricsWindow(t *testing.T) {
+	app := NewApp(&FakeSource{}, "fake://", "none")
+	app.pinnedIndices = []string{"logs"}
+	app.cycleMetricsWindow() // 5m
+	for i := 0; i <= 300; i++ {
+		app.addPinnedSnapshot(newIndexActivitySnapshot([]IndexActivity{
+			{Index: "logs", IndexTotal: int64(i * 10)},
+			{Index: "other"},
+		}, time.Unix(int64(i), 0)))
+	}
+
+	// Like the cluster series, older samples are downsampled into buckets
+	series := app.pinnedSeries["logs"]
+	if series.Size() != metricsRawPoints || len(series.Buckets) == 0 || series.Window != 5*time.Minute {
+		t.Errorf("pinned series = %d raw points, %d buckets over %s, want %d raw points downsampled over 5m",
+			series.Size(), len(series.Buckets), series.Window, metricsRawPoints)
+	}
+	if _, ok := app.pinnedSeries["other"]; ok {
+		t.Error("only pinned indices should be sampled")
+	}
+
+	app.cycleMetricsWindow() // 15m
+	if series.Window != 15*time.Minute {
+		t.Errorf("pinned window = %s, want 15m after changing the Live Metrics window", series.Window)
+	}
+
+	app.togglePinnedIndex("logs")
+	if _, ok := app.pinnedSeries["logs"]; ok {
+		t.Error("unpinning should drop the index's series")
+	}
+}
@@ -1,12 +1,19 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	opensearch "github.com/opensearch-project/opensearch-go/v2"
 	"github.com/vegasq/ostop/internal/client"
+	"github.com/vegasq/ostop/internal/config"
+	"github.com/vegasq/ostop/internal/source"
 	"github.com/vegasq/ostop/internal/ui"
 )
 
@@ -15,11 +22,18 @@ var (
 )
 
 func main() {
-	// CLI flags
-	endpoint := flag.String("endpoint", "", "OpenSearch endpoint URL (required)")
-	region := flag.String("region", "", "AWS region (required for AWS OpenSearch)")
-	profile := flag.String("profile", "", "AWS profile name (optional)")
-	insecure := flag.Bool("insecure", false, "Skip TLS verification (development only)")
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "snapshot":
+			os.Exit(runSnapshot(os.Args[2:]))
+		case "check":
+			os.Exit(runCheck(os.Args[2:]))
+		}
+	}
+
+	conn := registerConnectionFlags(flag.CommandLine)
+	flag.DurationVar(&conn.cluster.RefreshInterval, "refresh", ui.DefaultRefreshInterval, "Auto-refresh interval, e.g. 5s or 1m (overrides refresh_interval in the config)")
+	flag.DurationVar(&conn.cluster.SnapshotMaxAge, "snapshot-max-age", ui.DefaultSnapshotMaxAge, "Warn when the newest successful snapshot is older than this (overrides snapshot_max_age in the config)")
 	showVersion := flag.Bool("version", false, "Show version information")
 	flag.Parse()
 
@@ -29,26 +43,50 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Validate required flags
-	if *endpoint == "" {
-		fmt.Fprintln(os.Stderr, "Error: --endpoint is required")
+	cfg, cluster, err := conn.resolve(flag.CommandLine)
+	if errors.Is(err, errNoEndpoint) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		fmt.Fprintln(os.Stderr, "\nUsage:")
 		flag.PrintDefaults()
 		fmt.Fprintln(os.Stderr, "\nExamples:")
-		fmt.Fprintln(os.Stderr, "  Local:  ostop --endpoint http://localhost:9200")
-		fmt.Fprintln(os.Stderr, "  AWS:    ostop --endpoint https://search-xxx.us-east-1.es.amazonaws.com --region us-east-1")
+		fmt.Fprintln(os.Stderr, "  Local:    ostop --endpoint http://localhost:9200")
+		fmt.Fprintln(os.Stderr, "  AWS:      ostop --endpoint https://search-xxx.us-east-1.es.amazonaws.com --region us-east-1")
+		fmt.Fprintf(os.Stderr, "  Profile:  ostop --cluster prod   (clusters defined in %s)\n", conn.configPath)
+		fmt.Fprintln(os.Stderr, "  Snapshot: ostop snapshot --cluster prod --view nodes --format json")
+		fmt.Fprintln(os.Stderr, "  Check:    ostop check --cluster prod --skip threadpool")
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if interval := cluster.RefreshInterval; interval != 0 && interval < config.MinRefreshInterval {
+		fmt.Fprintf(os.Stderr, "Error: --refresh must be at least %s\n", config.MinRefreshInterval)
+		os.Exit(1)
+	}
+
+	// Resolve credentials (never from argv); prompting is allowed at startup
+	opts, err := cluster.ClientOptions(true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Create OpenSearch client
-	osClient, err := client.NewClient(*endpoint, *region, *profile, *insecure)
+	osClient, warnings, err := connect(opts, conn.timeout)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating OpenSearch client: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	printWarnings(warnings)
 
 	// Initialize Bubble Tea application
-	app := ui.NewApp(osClient, *endpoint)
+	app := ui.NewApp(source.NewOpenSearch(osClient), cluster.Endpoint, client.AuthLabel(opts)).
+		WithClusters(cfg.Names(), cluster.Name, connector(cfg, conn.cluster, conn.timeout)).
+		WithRefreshInterval(cluster.RefreshInterval).
+		WithSnapshotMaxAge(cluster.SnapshotMaxAge).
+		WithRequestTimeout(conn.timeout)
 	p := tea.NewProgram(app, tea.WithAltScreen())
 
 	// Run the TUI
@@ -57,3 +95,210 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// errNoEndpoint is returned by resolve when neither flags nor the config
+// name a cluster
+var errNoEndpoint = errors.New("--endpoint or --cluster is required")
+
+// connectionFlags are the flags shared by the TUI and the subcommands
+type connectionFlags struct {
+	cluster     config.Cluster // Bound to a profile so flags can override a config file entry
+	timeout     time.Duration
+	configPath  string
+	clusterName string
+}
+
+// registerConnectionFlags defines the cluster selection, auth, TLS and
+// timeout flags on fs
+func registerConnectionFlags(fs *flag.FlagSet) *connectionFlags {
+	c := &connectionFlags{}
+	fs.StringVar(&c.cluster.Endpoint, "endpoint", "", "OpenSearch endpoint URL (required unless a cluster profile is used)")
+	fs.StringVar(&c.cluster.Region, "region", "", "AWS region (required for AWS OpenSearch)")
+	fs.StringVar(&c.cluster.Profile, "profile", "", "AWS profile name (optional)")
+	fs.BoolVar(&c.cluster.Insecure, "insecure", false, "Skip TLS verification (development only)")
+	fs.StringVar(&c.cluster.TLS.CACert, "ca-cert", "", "PEM bundle of CAs used to verify the cluster certificate")
+	fs.StringVar(&c.cluster.TLS.ClientCert, "client-cert", "", "PEM client certificate for mutual TLS")
+	fs.StringVar(&c.cluster.TLS.ClientKey, "client-key", "", "PEM private key for --client-cert")
+	fs.StringVar(&c.cluster.Auth.Mode, "auth", "", "Authentication mode for non-AWS clusters: none, basic, bearer, apikey (default: basic if --username is set)")
+	fs.StringVar(&c.cluster.Auth.Username, "username", "", "Username for basic auth")
+	fs.StringVar(&c.cluster.Auth.PasswordEnv, "password-env", "", "Environment variable holding the basic auth password")
+	fs.StringVar(&c.cluster.Auth.PasswordFile, "password-file", "", "File holding the basic auth password")
+	fs.StringVar(&c.cluster.Auth.TokenEnv, "token-env", "", "Environment variable holding the bearer token or API key")
+	fs.StringVar(&c.cluster.Auth.TokenFile, "token-file", "", "File holding the bearer token or API key")
+	fs.StringVar(&c.cluster.Auth.APIKeyHeader, "api-key-header", client.DefaultAPIKeyHeader, "Header carrying the API key in apikey mode")
+	fs.DurationVar(&c.timeout, "timeout", ui.DefaultRequestTimeout, "Timeout for each cluster API request")
+	fs.StringVar(&c.configPath, "config", config.DefaultPath(), "Path to the config file with named cluster profiles")
+	fs.StringVar(&c.clusterName, "cluster", "", "Name of a cluster profile from the config file")
+	return c
+}
+
+// resolve loads the config file and picks the cluster to connect to. Call
+// after fs has been parsed.
+func (c *connectionFlags) resolve(fs *flag.FlagSet) (*config.Config, config.Cluster, error) {
+	// Track which flags were given explicitly so they override the profile
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
+
+	// Load cluster profiles (a missing default config file is fine)
+	cfg, err := config.Load(c.configPath, setFlags["config"])
+	if err != nil {
+		return nil, config.Cluster{}, err
+	}
+
+	cluster, err := selectCluster(cfg, c.clusterName, c.cluster, setFlags)
+	if err != nil {
+		return nil, config.Cluster{}, err
+	}
+
+	if cluster.Endpoint == "" {
+		return nil, config.Cluster{}, errNoEndpoint
+	}
+	if c.timeout <= 0 {
+		return nil, config.Cluster{}, errors.New("--timeout must be positive")
+	}
+
+	return cfg, cluster, nil
+}
+
+// selectCluster picks the startup profile (named, default, or ad-hoc) and
+// overlays any flags given explicitly on the command line
+func selectCluster(cfg *config.Config, name string, flags config.Cluster, set map[string]bool) (config.Cluster, error) {
+	var cluster config.Cluster
+
+	switch {
+	case name != "":
+		found, err := cfg.Find(name)
+		if err != nil {
+			return config.Cluster{}, err
+		}
+		cluster = found
+	case !set["endpoint"]:
+		if found, ok := cfg.DefaultCluster(); ok {
+			cluster = found
+		}
+	}
+
+	if set["endpoint"] {
+		cluster.Endpoint = flags.Endpoint
+	}
+	if set["region"] {
+		cluster.Region = flags.Region
+	}
+	if set["profile"] {
+		cluster.Profile = flags.Profile
+	}
+	if set["insecure"] {
+		cluster.Insecure = flags.Insecure
+	}
+	if set["ca-cert"] {
+		cluster.TLS.CACert = flags.TLS.CACert
+	}
+	if set["client-cert"] {
+		cluster.TLS.ClientCert = flags.TLS.ClientCert
+	}
+	if set["client-key"] {
+		cluster.TLS.ClientKey = flags.TLS.ClientKey
+	}
+	if set["auth"] {
+		cluster.Auth.Mode = flags.Auth.Mode
+	}
+	if set["username"] {
+		cluster.Auth.Username = flags.Auth.Username
+	}
+	if set["password-env"] {
+		cluster.Auth.PasswordEnv = flags.Auth.PasswordEnv
+	}
+	if set["password-file"] {
+		cluster.Auth.PasswordFile = flags.Auth.PasswordFile
+	}
+	if set["token-env"] {
+		cluster.Auth.TokenEnv = flags.Auth.TokenEnv
+	}
+	if set["token-file"] {
+		cluster.Auth.TokenFile = flags.Auth.TokenFile
+	}
+	if set["api-key-header"] {
+		cluster.Auth.APIKeyHeader = flags.Auth.APIKeyHeader
+	}
+	if set["refresh"] {
+		cluster.RefreshInterval = flags.RefreshInterval
+	}
+	if set["snapshot-max-age"] {
+		cluster.SnapshotMaxAge = flags.SnapshotMaxAge
+	}
+
+	return cluster, nil
+}
+
+// connect creates a client and surfaces certificate problems immediately
+// instead of as a failed first refresh, waiting at most the request timeout.
+// Non-fatal problems are returned as warnings for the caller to show.
+func connect(opts client.Options, timeout time.Duration) (*opensearch.Client, []string, error) {
+	osClient, warnings, err := client.NewClient(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating OpenSearch client: %w", err)
+	}
+
+	if strings.HasPrefix(opts.Endpoint, "https://") {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := client.CheckTLS(ctx, osClient); err != nil {
+			return nil, nil, fmt.Errorf("TLS connection to %s failed: %w", opts.Endpoint, err)
+		}
+	}
+
+	return osClient, warnings, nil
+}
+
+// printWarnings writes connection warnings to stderr, before any UI takes
+// over the terminal
+func printWarnings(warnings []string) {
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+}
+
+// connector lets the TUI switch to another configured cluster. Secrets must
+// come from env vars or files since the terminal is in use by the UI. Settings
+// a profile leaves unset fall back to the command line flags (which default
+// to the UI defaults).
+func connector(cfg *config.Config, flags config.Cluster, timeout time.Duration) ui.Connector {
+	return func(name string) (*ui.Connection, error) {
+		cluster, err := cfg.Find(name)
+		if err != nil {
+			return nil, err
+		}
+
+		opts, err := cluster.ClientOptions(false)
+		if err != nil {
+			return nil, err
+		}
+
+		osClient, warnings, err := connect(opts, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ui.Connection{
+			Name:            cluster.Name,
+			Endpoint:        cluster.Endpoint,
+			AuthMode:        client.AuthLabel(opts),
+			Source:          source.NewOpenSearch(osClient),
+			RefreshInterval: firstNonZero(cluster.RefreshInterval, flags.RefreshInterval, ui.DefaultRefreshInterval),
+			SnapshotMaxAge:  firstNonZero(cluster.SnapshotMaxAge, flags.SnapshotMaxAge, ui.DefaultSnapshotMaxAge),
+			Warnings:        warnings,
+		}, nil
+	}
+}
+
+// firstNonZero returns the first non-zero duration, or zero if all are
+func firstNonZero(durations ...time.Duration) time.Duration {
+	for _, d := range durations {
+		if d != 0 {
+			return d
+		}
+	}
+	return 0
+}
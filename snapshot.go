@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vegasq/ostop/internal/snapshot"
+	"github.com/vegasq/ostop/internal/source"
+	"golang.org/x/term"
+)
+
+// runSnapshot implements `ostop snapshot`: fetch one view once, print it and
+// exit. Returns the process exit code.
+func runSnapshot(args []string) int {
+	fs := flag.NewFlagSet("ostop snapshot", flag.ContinueOnError)
+	conn := registerConnectionFlags(fs)
+	view := fs.String("view", "", "Data to print: "+strings.Join(snapshot.Views(), ", ")+
+		" (ISM, ingest pipelines, index templates, node memory and allocation explanations are only in the UI)")
+	formatName := fs.String("format", string(snapshot.FormatTable), "Output format: json, yaml, csv, table")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *view == "" {
+		fmt.Fprintf(os.Stderr, "Error: --view is required (valid: %s)\n", strings.Join(snapshot.Views(), ", "))
+		return 2
+	}
+	if err := snapshot.CheckView(*view); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	format, err := snapshot.ParseFormat(*formatName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	_, cluster, err := conn.resolve(fs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, errNoEndpoint) {
+			return 2
+		}
+		return 1
+	}
+
+	opts, err := cluster.ClientOptions(true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	osClient, warnings, err := connect(opts, conn.timeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	printWarnings(warnings)
+
+	ctx, cancel := context.WithTimeout(context.Background(), conn.timeout)
+	defer cancel()
+
+	data, err := snapshot.Fetch(ctx, source.NewOpenSearch(osClient), *view)
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("request timed out after %s", conn.timeout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// Styling is only for people; pipes and files get plain text
+	color := term.IsTerminal(int(os.Stdout.Fd()))
+	if err := snapshot.Write(os.Stdout, data, format, color); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}